@@ -0,0 +1,239 @@
+package main
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"Cryptoproject/internal/adapters/importer"
+	"Cryptoproject/internal/cases"
+	"Cryptoproject/internal/entities"
+	"Cryptoproject/pkg/dto"
+)
+
+// backend — операции, доступные как через запущенный сервер, так и напрямую через хранилище.
+type backend interface {
+	Prices(ctx context.Context, titles []string) ([]entities.Coin, error)
+	Aggregate(ctx context.Context, titles []string, aggFunc string) ([]entities.Coin, error)
+	Actualize(ctx context.Context) error
+	Watchlist(ctx context.Context) ([]string, error)
+	AddToWatchlist(ctx context.Context, titles []string) (int, error)
+	RemoveFromWatchlist(ctx context.Context, titles []string) (int, error)
+	// Import loads a price history dump; gzipped reports whether body is gzip-compressed.
+	Import(ctx context.Context, body io.Reader, format entities.ExportFormat, gzipped bool) (*entities.ImportReport, error)
+}
+
+type directBackend struct {
+	service *cases.Service
+}
+
+func (b directBackend) Prices(ctx context.Context, titles []string) ([]entities.Coin, error) {
+	return b.service.GetLastRates(ctx, titles)
+}
+
+func (b directBackend) Aggregate(ctx context.Context, titles []string, aggFunc string) ([]entities.Coin, error) {
+	return b.service.GetRatesWithAgg(ctx, titles, aggFunc)
+}
+
+func (b directBackend) Actualize(ctx context.Context) error {
+	return b.service.ActualizeRates(ctx)
+}
+
+func (b directBackend) Watchlist(ctx context.Context) ([]string, error) {
+	return b.service.GetWatchlist(ctx)
+}
+
+func (b directBackend) AddToWatchlist(ctx context.Context, titles []string) (int, error) {
+	return b.service.AddToWatchlist(ctx, titles)
+}
+
+func (b directBackend) RemoveFromWatchlist(ctx context.Context, titles []string) (int, error) {
+	return b.service.RemoveFromWatchlist(ctx, titles)
+}
+
+func (b directBackend) Import(ctx context.Context, body io.Reader, format entities.ExportFormat, gzipped bool) (*entities.ImportReport, error) {
+	if gzipped {
+		gzReader, err := gzip.NewReader(body)
+		if err != nil {
+			return nil, err
+		}
+		defer gzReader.Close()
+		body = gzReader
+	}
+
+	reader, err := importer.NewReader(body, format)
+	if err != nil {
+		return nil, err
+	}
+	return b.service.Import(ctx, reader)
+}
+
+// requestTimeout ограничивает обычные запросы к серверу; импорт идёт без него.
+const requestTimeout = time.Minute
+
+type remoteBackend struct {
+	baseURL    string
+	adminToken string
+	client     *http.Client
+}
+
+func newRemoteBackend(server, adminToken string) *remoteBackend {
+	return &remoteBackend{
+		baseURL:    strings.TrimSuffix(server, "/") + "/api/v1",
+		adminToken: adminToken,
+		client:     &http.Client{},
+	}
+}
+
+func (b *remoteBackend) Prices(ctx context.Context, titles []string) ([]entities.Coin, error) {
+	var response []dto.CoinResponse
+	query := url.Values{"titles": {strings.Join(titles, ",")}}
+	if err := b.do(ctx, http.MethodGet, "/coins?"+query.Encode(), nil, &response); err != nil {
+		return nil, err
+	}
+
+	coins := make([]entities.Coin, 0, len(response))
+	for _, coin := range response {
+		coins = append(coins, entities.Coin{
+			CoinName:   coin.CoinName,
+			Price:      coin.Price,
+			Currency:   coin.Currency,
+			Source:     coin.Source,
+			ObservedAt: coin.ObservedAt,
+			CreatedAt:  coin.IngestedAt,
+		})
+	}
+	return coins, nil
+}
+
+func (b *remoteBackend) Aggregate(ctx context.Context, titles []string, aggFunc string) ([]entities.Coin, error) {
+	var response []dto.AggregateCoinResponse
+	query := url.Values{"titles": {strings.Join(titles, ",")}}
+	path := "/coins/aggregate/" + url.PathEscape(aggFunc) + "?" + query.Encode()
+	if err := b.do(ctx, http.MethodPost, path, nil, &response); err != nil {
+		return nil, err
+	}
+
+	coins := make([]entities.Coin, 0, len(response))
+	for _, coin := range response {
+		coins = append(coins, entities.Coin{CoinName: coin.CoinName, Price: coin.Price})
+	}
+	return coins, nil
+}
+
+func (b *remoteBackend) Actualize(ctx context.Context) error {
+	return b.do(ctx, http.MethodPost, "/watchlist/actualize", nil, nil)
+}
+
+func (b *remoteBackend) Watchlist(ctx context.Context) ([]string, error) {
+	var response dto.WatchlistResponse
+	if err := b.do(ctx, http.MethodGet, "/watchlist", nil, &response); err != nil {
+		return nil, err
+	}
+	return response.Titles, nil
+}
+
+func (b *remoteBackend) AddToWatchlist(ctx context.Context, titles []string) (int, error) {
+	var response dto.WatchlistUpdateResponse
+	if err := b.do(ctx, http.MethodPost, "/watchlist", dto.WatchlistRequest{Titles: titles}, &response); err != nil {
+		return 0, err
+	}
+	return response.Added, nil
+}
+
+func (b *remoteBackend) RemoveFromWatchlist(ctx context.Context, titles []string) (int, error) {
+	removed := 0
+	for _, title := range titles {
+		var response dto.WatchlistUpdateResponse
+		if err := b.do(ctx, http.MethodDelete, "/watchlist/"+url.PathEscape(title), nil, &response); err != nil {
+			return removed, err
+		}
+		removed += response.Removed
+	}
+	return removed, nil
+}
+
+func (b *remoteBackend) Import(ctx context.Context, body io.Reader, format entities.ExportFormat, gzipped bool) (*entities.ImportReport, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, b.baseURL+"/admin/import?format="+string(format), body)
+	if err != nil {
+		return nil, err
+	}
+	if gzipped {
+		req.Header.Set("Content-Encoding", "gzip")
+	}
+
+	var response dto.ImportResponse
+	if err = b.send(req, &response); err != nil {
+		return nil, err
+	}
+
+	report := &entities.ImportReport{
+		Lines:    response.Lines,
+		Imported: response.Imported,
+		Skipped:  response.Skipped,
+		Rejected: response.Rejected,
+	}
+	for _, lineErr := range response.Errors {
+		report.Errors = append(report.Errors, entities.LineError{Line: lineErr.Line, Err: errors.New(lineErr.Error)})
+	}
+	return report, nil
+}
+
+// do отправляет запрос к API и декодирует ответ в out; ошибки API возвращаются с их текстом.
+func (b *remoteBackend) do(ctx context.Context, method, path string, body, out any) error {
+	var reader io.Reader
+	if body != nil {
+		payload, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(payload)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, method, b.baseURL+path, reader)
+	if err != nil {
+		return err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	return b.send(req, out)
+}
+
+func (b *remoteBackend) send(req *http.Request, out any) error {
+	if b.adminToken != "" {
+		req.Header.Set("Authorization", "Bearer "+b.adminToken)
+	}
+
+	resp, err := b.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		var problem dto.ProblemResponse
+		if err := json.NewDecoder(resp.Body).Decode(&problem); err != nil || problem.Code == "" {
+			return fmt.Errorf("%s %s: %s", req.Method, req.URL.Path, resp.Status)
+		}
+		if problem.Detail == "" {
+			problem.Detail = problem.Title
+		}
+		return fmt.Errorf("%s %s: %s (%s)", req.Method, req.URL.Path, problem.Detail, problem.Code)
+	}
+	if out == nil || resp.StatusCode == http.StatusNoContent {
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
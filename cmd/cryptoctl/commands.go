@@ -0,0 +1,391 @@
+package main
+
+import (
+	"compress/gzip"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"Cryptoproject/internal/adapters/export"
+	"Cryptoproject/internal/entities"
+	"Cryptoproject/pkg/migrations"
+)
+
+const dateLayout = "2006-01-02"
+
+func runPrice(ctx context.Context, args []string, out io.Writer) error {
+	fs, opts := newFlagSet("price")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	titles := titlesArgs(fs.Args())
+	if len(titles) == 0 {
+		return fmt.Errorf("%w: price needs at least one title", errUsage)
+	}
+
+	b, closeBackend, err := newBackend(opts)
+	if err != nil {
+		return err
+	}
+	defer closeBackend()
+
+	coins, err := b.Prices(ctx, titles)
+	if err != nil {
+		return err
+	}
+
+	result := table{header: []string{"coin", "price", "currency", "source", "observed_at", "ingested_at"}}
+	for _, coin := range coins {
+		result.add(coin.CoinName, coin.Price, coin.Currency, coin.Source, coin.ObservedAt, coin.CreatedAt)
+	}
+	return result.write(out, opts.format)
+}
+
+func runAggregate(ctx context.Context, args []string, out io.Writer) error {
+	fs, opts := newFlagSet("aggregate")
+	aggFunc := fs.String("func", "AVG", "aggregate function: AVG, MAX or MIN")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	titles := titlesArgs(fs.Args())
+	if len(titles) == 0 {
+		return fmt.Errorf("%w: aggregate needs at least one title", errUsage)
+	}
+
+	b, closeBackend, err := newBackend(opts)
+	if err != nil {
+		return err
+	}
+	defer closeBackend()
+
+	coins, err := b.Aggregate(ctx, titles, strings.ToUpper(*aggFunc))
+	if err != nil {
+		return err
+	}
+
+	result := table{header: []string{"coin", strings.ToLower(*aggFunc)}}
+	for _, coin := range coins {
+		result.add(coin.CoinName, coin.Price)
+	}
+	return result.write(out, opts.format)
+}
+
+func runActualize(ctx context.Context, args []string, out io.Writer) error {
+	fs, opts := newFlagSet("actualize")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	b, closeBackend, err := newBackend(opts)
+	if err != nil {
+		return err
+	}
+	defer closeBackend()
+
+	startTime := time.Now()
+	if err := b.Actualize(ctx); err != nil {
+		return err
+	}
+	fmt.Fprintf(out, "rates actualized in %s\n", time.Since(startTime).Round(time.Millisecond))
+	return nil
+}
+
+func runWatchlist(ctx context.Context, args []string, out io.Writer) error {
+	fs, opts := newFlagSet("watchlist")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() == 0 {
+		return fmt.Errorf("%w: watchlist needs an action: list, add or remove", errUsage)
+	}
+	action, titles := fs.Arg(0), titlesArgs(fs.Args()[1:])
+	if action != "list" && len(titles) == 0 {
+		return fmt.Errorf("%w: watchlist %s needs at least one title", errUsage, action)
+	}
+
+	b, closeBackend, err := newBackend(opts)
+	if err != nil {
+		return err
+	}
+	defer closeBackend()
+
+	switch action {
+	case "list":
+		titles, err := b.Watchlist(ctx)
+		if err != nil {
+			return err
+		}
+		result := table{header: []string{"coin"}}
+		for _, title := range titles {
+			result.add(title)
+		}
+		return result.write(out, opts.format)
+	case "add":
+		added, err := b.AddToWatchlist(ctx, titles)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(out, "added %d of %d\n", added, len(titles))
+		return nil
+	case "remove":
+		removed, err := b.RemoveFromWatchlist(ctx, titles)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(out, "removed %d of %d\n", removed, len(titles))
+		return nil
+	default:
+		return fmt.Errorf("%w: unknown watchlist action %q", errUsage, action)
+	}
+}
+
+func runMigrate(ctx context.Context, args []string, out io.Writer) error {
+	fs := flag.NewFlagSet("migrate", flag.ContinueOnError)
+	steps := fs.Int("steps", 1, "number of migrations to roll back")
+	to := fs.String("to", "", "last migration already present in the schema (baseline)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	direction := fs.Arg(0)
+	switch direction {
+	case "up", "down":
+	case "baseline":
+		if *to == "" {
+			return fmt.Errorf("%w: migrate baseline needs -to MIGRATION", errUsage)
+		}
+	default:
+		return fmt.Errorf("%w: migrate needs a direction: up, down or baseline", errUsage)
+	}
+
+	list, err := migrations.Postgres()
+	if err != nil {
+		return err
+	}
+	storage, err := openStorage()
+	if err != nil {
+		return err
+	}
+	defer storage.Close()
+
+	var names []string
+	switch direction {
+	case "up":
+		names, err = storage.MigrateUp(ctx, list)
+	case "down":
+		names, err = storage.MigrateDown(ctx, list, *steps)
+	default:
+		names, err = storage.MigrateBaseline(ctx, list, *to)
+	}
+	for _, name := range names {
+		fmt.Fprintf(out, "%s %s\n", direction, name)
+	}
+	if err != nil {
+		return err
+	}
+	if len(names) == 0 {
+		fmt.Fprintln(out, "nothing to do")
+	}
+	return nil
+}
+
+func runBackfill(ctx context.Context, args []string, out io.Writer) error {
+	fs, opts := newFlagSet("backfill")
+	from, to := rangeFlags(fs)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	titles := titlesArgs(fs.Args())
+	if len(titles) == 0 {
+		return fmt.Errorf("%w: backfill needs at least one title", errUsage)
+	}
+	fromTime, toTime, err := parseRange(*from, *to)
+	if err != nil {
+		return err
+	}
+
+	storage, err := openStorage()
+	if err != nil {
+		return err
+	}
+	defer storage.Close()
+	service, err := newService(storage)
+	if err != nil {
+		return err
+	}
+
+	results, err := service.Backfill(ctx, titles, fromTime, toTime)
+	result := table{header: []string{"coin", "fetched", "stored"}}
+	for _, backfilled := range results {
+		result.add(backfilled.CoinName, backfilled.Fetched, backfilled.Stored)
+	}
+	if writeErr := result.write(out, opts.format); writeErr != nil {
+		return writeErr
+	}
+	return err
+}
+
+func runExport(ctx context.Context, args []string, out io.Writer) error {
+	fs := flag.NewFlagSet("export", flag.ContinueOnError)
+	from, to := rangeFlags(fs)
+	formatName := fs.String("format", "csv", "file format: csv, ndjson or parquet")
+	path := fs.String("out", "", "output file; stdout by default")
+	gzipped := fs.Bool("gzip", false, "gzip the output")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	fromTime, toTime, err := parseRange(*from, *to)
+	if err != nil {
+		return err
+	}
+	format, err := entities.ParseExportFormat(*formatName)
+	if err != nil {
+		return err
+	}
+
+	storage, err := openStorage()
+	if err != nil {
+		return err
+	}
+	defer storage.Close()
+	service, err := newService(storage)
+	if err != nil {
+		return err
+	}
+
+	dst := out
+	if *path != "" {
+		file, err := os.Create(*path)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		dst = file
+	}
+	var gzWriter *gzip.Writer
+	if *gzipped {
+		gzWriter = gzip.NewWriter(dst)
+		dst = gzWriter
+	}
+
+	writer, err := export.NewWriter(dst, format)
+	if err != nil {
+		return err
+	}
+	rows := 0
+	query := entities.ExportQuery{Titles: titlesArgs(fs.Args()), From: fromTime, To: toTime}
+	if err = service.Export(ctx, query, func(record entities.HistoryRecord) error {
+		rows++
+		return writer.Write(record)
+	}); err != nil {
+		return err
+	}
+	if err = writer.Close(); err != nil {
+		return err
+	}
+	if gzWriter != nil {
+		if err = gzWriter.Close(); err != nil {
+			return err
+		}
+	}
+
+	if *path != "" {
+		fmt.Fprintf(out, "exported %d rows to %s\n", rows, *path)
+	}
+	return nil
+}
+
+func runImport(ctx context.Context, args []string, out io.Writer) error {
+	fs, opts := newFlagSet("import")
+	formatName := fs.String("format", "", "file format: csv or ndjson; guessed from the file name by default")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return fmt.Errorf("%w: import needs exactly one file", errUsage)
+	}
+	path := fs.Arg(0)
+	gzipped := strings.HasSuffix(path, ".gz")
+	if *formatName == "" && strings.HasSuffix(strings.TrimSuffix(path, ".gz"), ".ndjson") {
+		*formatName = string(entities.ExportNDJSON)
+	}
+	format, err := entities.ParseImportFormat(*formatName)
+	if err != nil {
+		return err
+	}
+
+	var body io.Reader = os.Stdin
+	if path != "-" {
+		file, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		body = file
+	}
+
+	b, closeBackend, err := newBackend(opts)
+	if err != nil {
+		return err
+	}
+	defer closeBackend()
+
+	report, err := b.Import(ctx, body, format, gzipped)
+	if err != nil {
+		return err
+	}
+
+	result := table{header: []string{"line", "error"}}
+	for _, lineErr := range report.Errors {
+		result.add(lineErr.Line, lineErr.Err.Error())
+	}
+	if len(result.rows) > 0 {
+		if err = result.write(os.Stderr, opts.format); err != nil {
+			return err
+		}
+	}
+	fmt.Fprintf(out, "lines %d, imported %d, skipped %d, rejected %d\n",
+		report.Lines, report.Imported, report.Skipped, report.Rejected)
+	return nil
+}
+
+func rangeFlags(fs *flag.FlagSet) (from, to *string) {
+	from = fs.String("from", "", "range start, "+dateLayout+" or RFC 3339 (required)")
+	to = fs.String("to", "", "range end, exclusive; defaults to now")
+	return from, to
+}
+
+func parseRange(from, to string) (time.Time, time.Time, error) {
+	if from == "" {
+		return time.Time{}, time.Time{}, fmt.Errorf("%w: -from is required", errUsage)
+	}
+	fromTime, err := parseTime(from)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	toTime := time.Now().UTC()
+	if to != "" {
+		if toTime, err = parseTime(to); err != nil {
+			return time.Time{}, time.Time{}, err
+		}
+	}
+	if !fromTime.Before(toTime) {
+		return time.Time{}, time.Time{}, errors.New("-from must be before -to")
+	}
+	return fromTime, toTime, nil
+}
+
+func parseTime(value string) (time.Time, error) {
+	if t, err := time.Parse(dateLayout, value); err == nil {
+		return t, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid time %q: use %s or RFC 3339", value, dateLayout)
+	}
+	return t, nil
+}
@@ -0,0 +1,178 @@
+// Command cryptoctl queries and administers the crypto service from the
+// command line, either through a running server or directly against storage.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+
+	"Cryptoproject/internal/adapters/provider/cryptocompare"
+	"Cryptoproject/internal/adapters/storage/postgres"
+	"Cryptoproject/internal/cases"
+	"Cryptoproject/pkg/application"
+)
+
+const usage = `Usage: cryptoctl <command> [flags] [args]
+
+Commands:
+  price TITLES...                   latest prices
+  aggregate -func AVG TITLES...     aggregated prices (AVG, MAX, MIN)
+  actualize                         refresh all tracked coins now
+                                    (remote mode needs CRYPTOCTL_ADMIN_TOKEN)
+  watchlist list|add|remove [TITLES...]
+                                    (add and remove in remote mode need CRYPTOCTL_ADMIN_TOKEN)
+  migrate up|down [-steps N]        apply or roll back migrations (direct only)
+  migrate baseline -to MIGRATION    mark migrations up to MIGRATION as applied without running
+                                    them, for schemas created before schema_migrations (direct only)
+  backfill -from DATE -to DATE TITLES...
+                                    load hourly history from the provider (direct only)
+  export -from DATE -to DATE [-format csv|ndjson|parquet] [-out FILE] [-gzip] [TITLES...]
+                                    dump stored history, all tracked coins by default (direct only)
+  import [-format csv|ndjson] FILE  load a price history dump; "-" reads stdin, *.gz is decompressed
+                                    (remote mode needs CRYPTOCTL_ADMIN_TOKEN)
+
+Common flags:
+  -server URL   server to query (env CRYPTOCTL_SERVER, default http://localhost:8080)
+  -direct       use storage from PG_URL instead of the server
+  -o FORMAT     output format: table, json or csv
+
+Run "cryptoctl <command> -h" for command flags.
+`
+
+var errUsage = errors.New("invalid usage")
+
+// options — общие флаги всех команд.
+type options struct {
+	server string
+	direct bool
+	format string
+}
+
+func main() {
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	if err := run(ctx, os.Args[1:], os.Stdout); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			os.Exit(2)
+		}
+		fmt.Fprintln(os.Stderr, "cryptoctl:", err)
+		if errors.Is(err, errUsage) {
+			os.Exit(2)
+		}
+		os.Exit(1)
+	}
+}
+
+func run(ctx context.Context, args []string, out io.Writer) error {
+	if len(args) == 0 {
+		fmt.Fprint(os.Stderr, usage)
+		return fmt.Errorf("%w: missing command", errUsage)
+	}
+
+	command, args := args[0], args[1:]
+	switch command {
+	case "price":
+		return runPrice(ctx, args, out)
+	case "aggregate":
+		return runAggregate(ctx, args, out)
+	case "actualize":
+		return runActualize(ctx, args, out)
+	case "watchlist":
+		return runWatchlist(ctx, args, out)
+	case "migrate":
+		return runMigrate(ctx, args, out)
+	case "backfill":
+		return runBackfill(ctx, args, out)
+	case "export":
+		return runExport(ctx, args, out)
+	case "import":
+		return runImport(ctx, args, out)
+	case "help", "-h", "--help":
+		fmt.Fprint(out, usage)
+		return nil
+	default:
+		fmt.Fprint(os.Stderr, usage)
+		return fmt.Errorf("%w: unknown command %q", errUsage, command)
+	}
+}
+
+func newFlagSet(name string) (*flag.FlagSet, *options) {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	opts := &options{}
+	fs.StringVar(&opts.server, "server", getEnv("CRYPTOCTL_SERVER", "http://localhost:8080"), "server base URL")
+	fs.BoolVar(&opts.direct, "direct", false, "use storage from PG_URL instead of the server")
+	fs.StringVar(&opts.format, "o", formatTable, "output format: table, json or csv")
+	return fs, opts
+}
+
+// titlesArgs собирает монеты из аргументов; допускаются и списки через запятую.
+func titlesArgs(args []string) []string {
+	var titles []string
+	for _, arg := range args {
+		for _, title := range strings.Split(arg, ",") {
+			if title = strings.ToUpper(strings.TrimSpace(title)); title != "" {
+				titles = append(titles, title)
+			}
+		}
+	}
+	return titles
+}
+
+func newBackend(opts *options) (backend, func(), error) {
+	if !opts.direct {
+		return newRemoteBackend(opts.server, os.Getenv("CRYPTOCTL_ADMIN_TOKEN")), func() {}, nil
+	}
+
+	storage, err := openStorage()
+	if err != nil {
+		return nil, nil, err
+	}
+	service, err := newService(storage)
+	if err != nil {
+		storage.Close()
+		return nil, nil, err
+	}
+	return directBackend{service: service}, storage.Close, nil
+}
+
+func openStorage() (*postgres.Storage, error) {
+	connectionString := os.Getenv("PG_URL")
+	if connectionString == "" {
+		return nil, errors.New("PG_URL is required for direct access")
+	}
+	return postgres.NewStorage(connectionString, newLogger())
+}
+
+// newService собирает сервис с той же конфигурацией, что и сервер: валидация цен,
+// допустимый возраст и отдача сохранённых цен при сбое провайдера не расходятся.
+func newService(storage *postgres.Storage) (*cases.Service, error) {
+	logger := newLogger()
+	cfg := application.LoadConfig(logger)
+	provider, err := cryptocompare.NewClient(getEnv("CRYPTOCOMPARE_API_KEY", "your_api_key"), logger,
+		cryptocompare.WithPriceIn(cfg.QuoteCurrency))
+	if err != nil {
+		return nil, err
+	}
+	return cases.NewService(storage, provider, logger, cfg.ServiceOptions()...)
+}
+
+// newLogger пишет в stderr только предупреждения, чтобы не смешивать логи с выводом команды.
+func newLogger() *slog.Logger {
+	return slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelWarn}))
+}
+
+func getEnv(key, fallback string) string {
+	if value, ok := os.LookupEnv(key); ok && value != "" {
+		return value
+	}
+	return fallback
+}
@@ -0,0 +1,89 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+const (
+	formatTable = "table"
+	formatJSON  = "json"
+	formatCSV   = "csv"
+)
+
+// table — результат команды, одинаково печатаемый во всех форматах вывода.
+type table struct {
+	header []string
+	rows   [][]any
+}
+
+func (t *table) add(values ...any) {
+	t.rows = append(t.rows, values)
+}
+
+func (t *table) write(w io.Writer, format string) error {
+	switch format {
+	case formatTable:
+		tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(tw, strings.Join(t.header, "\t"))
+		for _, row := range t.rows {
+			fmt.Fprintln(tw, strings.Join(formatRow(row), "\t"))
+		}
+		return tw.Flush()
+	case formatCSV:
+		cw := csv.NewWriter(w)
+		if err := cw.Write(t.header); err != nil {
+			return err
+		}
+		for _, row := range t.rows {
+			if err := cw.Write(formatRow(row)); err != nil {
+				return err
+			}
+		}
+		cw.Flush()
+		return cw.Error()
+	case formatJSON:
+		// в JSON значения сохраняют свои типы, поэтому строки собираются в объекты по заголовку
+		records := make([]map[string]any, 0, len(t.rows))
+		for _, row := range t.rows {
+			record := make(map[string]any, len(t.header))
+			for i, name := range t.header {
+				record[name] = row[i]
+			}
+			records = append(records, record)
+		}
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(records)
+	default:
+		return fmt.Errorf("unknown output format %q (allowed: table, json, csv)", format)
+	}
+}
+
+func formatRow(row []any) []string {
+	cells := make([]string, 0, len(row))
+	for _, value := range row {
+		cells = append(cells, formatValue(value))
+	}
+	return cells
+}
+
+func formatValue(value any) string {
+	switch v := value.(type) {
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case time.Time:
+		if v.IsZero() {
+			return ""
+		}
+		return v.UTC().Format(time.RFC3339)
+	default:
+		return fmt.Sprint(v)
+	}
+}
@@ -0,0 +1,44 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_TableWrite(t *testing.T) {
+	t.Parallel()
+
+	observedAt := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	result := table{header: []string{"coin", "price", "observed_at"}}
+	result.add("BTC", 50000.5, observedAt)
+	result.add("ETH, classic", 3000.0, time.Time{})
+
+	var out bytes.Buffer
+	require.NoError(t, result.write(&out, formatTable))
+	assert.Equal(t, "coin          price    observed_at\n"+
+		"BTC           50000.5  2025-01-01T12:00:00Z\n"+
+		"ETH, classic  3000     \n", out.String())
+
+	out.Reset()
+	require.NoError(t, result.write(&out, formatCSV))
+	assert.Equal(t, "coin,price,observed_at\nBTC,50000.5,2025-01-01T12:00:00Z\n\"ETH, classic\",3000,\n", out.String())
+
+	out.Reset()
+	require.NoError(t, result.write(&out, formatJSON))
+	assert.JSONEq(t, `[
+		{"coin":"BTC","price":50000.5,"observed_at":"2025-01-01T12:00:00Z"},
+		{"coin":"ETH, classic","price":3000,"observed_at":"0001-01-01T00:00:00Z"}
+	]`, out.String())
+
+	assert.Error(t, result.write(&out, "xml"))
+}
+
+func Test_TitlesArgs(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, []string{"BTC", "ETH", "SOL"}, titlesArgs([]string{"btc,eth", " sol ", ","}))
+}
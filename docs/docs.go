@@ -9,15 +9,293 @@ const docTemplate = `{
     "info": {
         "description": "{{escape .Description}}",
         "title": "{{.Title}}",
-        "contact": {},
+        "termsOfService": "http://swagger.io/terms/",
+        "contact": {
+            "name": "@GradDia",
+            "email": "not support"
+        },
+        "license": {
+            "name": "for free",
+            "url": "for free"
+        },
         "version": "{{.Version}}"
     },
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/api/v1/coins/actual": {
+        "/api/v1/admin/import": {
+            "post": {
+                "security": [
+                    {
+                        "AdminToken": []
+                    }
+                ],
+                "description": "Loads historical prices from a CSV or NDJSON body; gzip bodies are accepted with Content-Encoding: gzip. CSV needs a header with coin_name, price and observed_at columns, currency and source are optional; currency must be the quote currency. Invalid lines are reported and skipped, prices already stored are skipped, so an import can be safely re-run",
+                "consumes": [
+                    "text/csv",
+                    "application/x-ndjson"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Import price history",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "csv",
+                        "description": "Body format: csv or ndjson; taken from Content-Type by default",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ImportResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/alerts": {
+            "get": {
+                "description": "Returns alert events raised by monitors, newest first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "alerts"
+                ],
+                "summary": "Alert events",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "\"USDC\"",
+                        "description": "Coin symbol",
+                        "name": "coin",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"depeg_started,depeg_recovered\"",
+                        "description": "Comma-separated event kinds",
+                        "name": "kind",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"2025-01-01T00:00:00Z\"",
+                        "description": "RFC3339 timestamp",
+                        "name": "since",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 100,
+                        "description": "Maximum number of events",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.AlertEventResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/analytics/correlation": {
+            "get": {
+                "description": "Pearson correlation of log returns for every pair of coins, aligned on the buckets both coins have. A null entry means the pair has fewer than two common returns or one coin did not move",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "analytics"
+                ],
+                "summary": "Correlation matrix",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "\"BTC,ETH,SOL\"",
+                        "description": "Comma-separated coin symbols, 2 to 20",
+                        "name": "titles",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "7d",
+                            "30d",
+                            "90d",
+                            "365d"
+                        ],
+                        "type": "string",
+                        "default": "30d",
+                        "description": "Window",
+                        "name": "window",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "5m",
+                            "15m",
+                            "1h",
+                            "4h",
+                            "1d"
+                        ],
+                        "type": "string",
+                        "default": "1h",
+                        "description": "Bucket size",
+                        "name": "interval",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.CorrelationResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/analytics/volatility": {
+            "get": {
+                "description": "Annualised standard deviation of log returns between adjacent buckets of stored history. Without titles all tracked coins are returned",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "analytics"
+                ],
+                "summary": "Realised volatility",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "\"BTC,ETH\"",
+                        "description": "Comma-separated coin symbols",
+                        "name": "titles",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "7d",
+                            "30d",
+                            "90d",
+                            "365d"
+                        ],
+                        "type": "string",
+                        "default": "30d",
+                        "description": "Window",
+                        "name": "window",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "5m",
+                            "15m",
+                            "1h",
+                            "4h",
+                            "1d"
+                        ],
+                        "type": "string",
+                        "default": "1h",
+                        "description": "Bucket size",
+                        "name": "interval",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.VolatilityListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/batch": {
             "post": {
-                "description": "Returns latest prices for requested coins",
+                "description": "Runs up to 20 latest, quotes, aggregate and history queries and returns a result per query in request order. Every query is validated, rate limited and fails on its own: the batch itself returns 200 unless the body is malformed. Latest and quotes queries count against the coins_actual limit, the others against coins_aggregate",
                 "consumes": [
                     "application/json"
                 ],
@@ -27,6 +305,43 @@ const docTemplate = `{
                 "tags": [
                     "coins"
                 ],
+                "summary": "Run several queries at once",
+                "parameters": [
+                    {
+                        "description": "Queries to run",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.BatchRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.BatchResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/coins": {
+            "get": {
+                "description": "Cacheable equivalent of POST /coins/actual. Supports ETag/If-None-Match and Last-Modified/If-Modified-Since",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "coins"
+                ],
                 "summary": "Get latest coin prices",
                 "parameters": [
                     {
@@ -48,24 +363,45 @@ const docTemplate = `{
                             }
                         }
                     },
+                    "304": {
+                        "description": "Not modified"
+                    },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/dto.ErrorResponseDto"
+                            "$ref": "#/definitions/dto.ProblemResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemResponse"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/dto.ErrorResponseDto"
+                            "$ref": "#/definitions/dto.ProblemResponse"
+                        }
+                    },
+                    "502": {
+                        "description": "Bad Gateway",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemResponse"
                         }
                     }
                 }
             }
         },
-        "/api/v1/coins/aggregate/{aggFunc}": {
+        "/api/v1/coins/actual": {
             "post": {
-                "description": "Returns aggregated data (AVG/MAX/MIN) for requested coins",
+                "description": "Returns latest prices for requested coins. Titles are read from the JSON body, or from the titles query parameter when the body has none. At most 100 titles of latin letters and digits; duplicates are ignored. Coins the provider does not list are left out. When the provider fails, the last stored prices are returned with stale set and their age, as long as every coin has one within the configured limit; otherwise the whole request fails. Use /coins/quotes for a per-coin status",
                 "consumes": [
                     "application/json"
                 ],
@@ -75,27 +411,22 @@ const docTemplate = `{
                 "tags": [
                     "coins"
                 ],
-                "summary": "Get aggregated coin data",
+                "summary": "Get latest coin prices",
                 "parameters": [
                     {
-                        "enum": [
-                            "AVG",
-                            "MAX",
-                            "MIN"
-                        ],
-                        "type": "string",
-                        "description": "Aggregation function (AVG, MAX, MIN)",
-                        "name": "aggFunc",
-                        "in": "path",
-                        "required": true
+                        "description": "Coins to query",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/dto.CoinsRequest"
+                        }
                     },
                     {
                         "type": "string",
                         "example": "\"BTC,ETH\"",
-                        "description": "Comma-separated list of coin titles",
+                        "description": "Comma-separated list of coin titles, used when the body has no titles",
                         "name": "titles",
-                        "in": "query",
-                        "required": true
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -104,75 +435,2336 @@ const docTemplate = `{
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/dto.AggregateCoinResponse"
+                                "$ref": "#/definitions/dto.CoinResponse"
                             }
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/dto.ErrorResponseDto"
+                            "$ref": "#/definitions/dto.ProblemResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemResponse"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/dto.ErrorResponseDto"
+                            "$ref": "#/definitions/dto.ProblemResponse"
+                        }
+                    },
+                    "502": {
+                        "description": "Bad Gateway",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemResponse"
                         }
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "dto.AggregateCoinResponse": {
-            "type": "object",
-            "properties": {
-                "coin_name": {
-                    "type": "string"
-                },
-                "price": {
-                    "description": "AVG, MAX или MIN",
-                    "type": "number"
-                }
-            }
         },
-        "dto.CoinResponse": {
-            "type": "object",
-            "properties": {
-                "coin_name": {
-                    "type": "string"
-                },
+        "/api/v1/coins/aggregate": {
+            "post": {
+                "description": "Same as /coins/aggregate/{aggFunc} with the function taken from agg in the body",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "coins"
+                ],
+                "summary": "Get aggregated coin data",
+                "parameters": [
+                    {
+                        "description": "Coins and aggregation function",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.CoinsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.AggregateCoinResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemResponse"
+                        }
+                    },
+                    "502": {
+                        "description": "Bad Gateway",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/coins/aggregate/{aggFunc}": {
+            "post": {
+                "description": "Returns aggregated data (AVG/MAX/MIN) for requested coins. The function is taken from the path or from agg in the body; when both are given they must match. Titles are read as for /coins/actual",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "coins"
+                ],
+                "summary": "Get aggregated coin data",
+                "parameters": [
+                    {
+                        "enum": [
+                            "AVG",
+                            "MAX",
+                            "MIN"
+                        ],
+                        "type": "string",
+                        "description": "Aggregation function (AVG, MAX, MIN)",
+                        "name": "aggFunc",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Coins to query",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/dto.CoinsRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"BTC,ETH\"",
+                        "description": "Comma-separated list of coin titles, used when the body has no titles",
+                        "name": "titles",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.AggregateCoinResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemResponse"
+                        }
+                    },
+                    "502": {
+                        "description": "Bad Gateway",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/coins/quotes": {
+            "post": {
+                "description": "Returns a result for every requested coin in request order instead of failing the whole request. Status is ok, unknown_symbol (the provider does not list the coin), rejected (the fetched quote failed validation; reason holds the quarantine reason), stale (the provider failed and the last stored price is returned) or provider_error (the provider failed and no price is stored). Titles are read as for /coins/actual",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "coins"
+                ],
+                "summary": "Get latest coin prices with per-coin status",
+                "parameters": [
+                    {
+                        "description": "Coins to query",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/dto.CoinsRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"BTC,ETH\"",
+                        "description": "Comma-separated list of coin titles, used when the body has no titles",
+                        "name": "titles",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.QuotesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/coins/{title}": {
+            "get": {
+                "description": "Supports ETag/If-None-Match and Last-Modified/If-Modified-Since",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "coins"
+                ],
+                "summary": "Get latest price of a single coin",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "\"BTC\"",
+                        "description": "Coin title",
+                        "name": "title",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.CoinResponse"
+                        }
+                    },
+                    "304": {
+                        "description": "Not modified"
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemResponse"
+                        }
+                    },
+                    "502": {
+                        "description": "Bad Gateway",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/coins/{title}/indicators": {
+            "get": {
+                "description": "Computes a technical indicator over close prices of stored history bucketed by interval. Values are keyed by line: \"value\" for sma, ema and rsi; \"upper\", \"middle\", \"lower\" for bollinger (2 standard deviations); \"macd\", \"signal\", \"histogram\" for macd (periods 12/26/9, period is ignored)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "coins"
+                ],
+                "summary": "Technical indicator",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "\"BTC\"",
+                        "description": "Coin symbol",
+                        "name": "title",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "sma",
+                            "ema",
+                            "rsi",
+                            "bollinger",
+                            "macd"
+                        ],
+                        "type": "string",
+                        "description": "Indicator",
+                        "name": "type",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Period in buckets (default 20, 14 for rsi)",
+                        "name": "period",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "5m",
+                            "15m",
+                            "1h",
+                            "4h",
+                            "1d"
+                        ],
+                        "type": "string",
+                        "default": "1h",
+                        "description": "Bucket size",
+                        "name": "interval",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 100,
+                        "description": "Number of latest points",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.IndicatorResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/convert": {
+            "get": {
+                "description": "Converts using the latest stored prices of both legs, triangulating through the quote currency (USD). Refuses when a leg is older than the staleness limit. from_price_at and to_price_at are the provider observation times of the legs",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "convert"
+                ],
+                "summary": "Convert an amount between currencies",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "\"ETH\"",
+                        "description": "Source currency",
+                        "name": "from",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"BTC\"",
+                        "description": "Target currency",
+                        "name": "to",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "number",
+                        "example": 2.5,
+                        "description": "Amount of source currency",
+                        "name": "amount",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ConversionResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/export": {
+            "get": {
+                "description": "Streams stored price history as CSV, NDJSON or Parquet. Periods already downsampled by retention are exported as hourly or daily aggregates, see the resolution column. CSV and NDJSON are gzip-compressed when the client accepts it",
+                "produces": [
+                    "text/csv",
+                    "application/x-ndjson",
+                    "application/vnd.apache.parquet"
+                ],
+                "tags": [
+                    "export"
+                ],
+                "summary": "Export price history",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "\"BTC,ETH\"",
+                        "description": "Comma-separated coin symbols, all tracked coins by default",
+                        "name": "titles",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"2025-01-01\"",
+                        "description": "Range start, YYYY-MM-DD or RFC 3339",
+                        "name": "from",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"2025-02-01\"",
+                        "description": "Range end, exclusive; defaults to now",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "csv",
+                        "description": "Output format: csv, ndjson or parquet",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/graphql": {
+            "post": {
+                "description": "Runs a GraphQL query over coins: latest price, aggregates, summaries, recent prices and candles. Fields of all coins in one list are loaded with a single storage query per field. Errors are returned in the errors array with extensions.code",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "graphql"
+                ],
+                "summary": "GraphQL query",
+                "parameters": [
+                    {
+                        "description": "GraphQL query",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.GraphQLRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.GraphQLResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/market/movers": {
+            "get": {
+                "description": "Ranks tracked coins by price change over the window computed from stored history: top gainers, top losers, most volatile and the number of coins up and down",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "market"
+                ],
+                "summary": "Top market movers",
+                "parameters": [
+                    {
+                        "enum": [
+                            "1h",
+                            "24h",
+                            "7d"
+                        ],
+                        "type": "string",
+                        "default": "24h",
+                        "description": "Window",
+                        "name": "window",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Coins per list",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.MarketMoversResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/portfolios": {
+            "get": {
+                "security": [
+                    {
+                        "AdminToken": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "portfolios"
+                ],
+                "summary": "List portfolios",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.PortfolioResponse"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "AdminToken": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "portfolios"
+                ],
+                "summary": "Create portfolio",
+                "parameters": [
+                    {
+                        "description": "Portfolio",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.CreatePortfolioRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dto.PortfolioResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/portfolios/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "AdminToken": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "portfolios"
+                ],
+                "summary": "Get portfolio",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Portfolio ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.PortfolioResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/portfolios/{id}/transactions": {
+            "get": {
+                "security": [
+                    {
+                        "AdminToken": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "portfolios"
+                ],
+                "summary": "List portfolio transactions",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Portfolio ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.TransactionResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "AdminToken": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "portfolios"
+                ],
+                "summary": "Record a buy or sell transaction",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Portfolio ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Transaction",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.TransactionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dto.TransactionResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/portfolios/{id}/valuation": {
+            "get": {
+                "security": [
+                    {
+                        "AdminToken": []
+                    }
+                ],
+                "description": "Values holdings at the latest stored prices, or at a historical point when \"at\" is set. P\u0026L uses FIFO cost basis",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "portfolios"
+                ],
+                "summary": "Value portfolio",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Portfolio ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"2025-01-01T00:00:00Z\"",
+                        "description": "RFC3339 timestamp",
+                        "name": "at",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ValuationResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/stablecoins": {
+            "get": {
+                "description": "Returns the current deviation from the peg in basis points of every monitored stablecoin that has recent prices",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stablecoins"
+                ],
+                "summary": "Stablecoin peg deviations",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.DepegStatusResponse"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/stablecoins/{title}/history": {
+            "get": {
+                "description": "Returns the deviation from the peg in basis points at the close of every interval over the window",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stablecoins"
+                ],
+                "summary": "Stablecoin deviation history",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "\"USDT\"",
+                        "description": "Stablecoin symbol",
+                        "name": "title",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "7d",
+                            "30d",
+                            "90d",
+                            "365d"
+                        ],
+                        "type": "string",
+                        "default": "30d",
+                        "description": "Window",
+                        "name": "window",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "5m",
+                            "15m",
+                            "1h",
+                            "4h",
+                            "1d"
+                        ],
+                        "type": "string",
+                        "default": "1h",
+                        "description": "Bucket size",
+                        "name": "interval",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.DeviationHistoryResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/tickers": {
+            "get": {
+                "description": "Returns the latest stored 24h market data. Without titles all tracked coins are returned. Prefix the sort field with \"-\" for descending order",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tickers"
+                ],
+                "summary": "List market tickers",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "\"BTC,ETH\"",
+                        "description": "Comma-separated coin symbols",
+                        "name": "titles",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "-market_cap",
+                        "description": "Sort field: market_cap, change, volume or name",
+                        "name": "sort",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.TickerResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/watchlist": {
+            "get": {
+                "description": "Returns the coins refreshed by the scheduled actualization",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "watchlist"
+                ],
+                "summary": "List tracked coins",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.WatchlistResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "AdminToken": []
+                    }
+                ],
+                "description": "Checks the coins against the provider and adds them to the watchlist",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "watchlist"
+                ],
+                "summary": "Track coins",
+                "parameters": [
+                    {
+                        "description": "Coins to track",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.WatchlistRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.WatchlistUpdateResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemResponse"
+                        }
+                    },
+                    "502": {
+                        "description": "Bad Gateway",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/watchlist/actualize": {
+            "post": {
+                "security": [
+                    {
+                        "AdminToken": []
+                    }
+                ],
+                "description": "Runs the rates actualization immediately instead of waiting for the schedule",
+                "tags": [
+                    "watchlist"
+                ],
+                "summary": "Refresh tracked coins",
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemResponse"
+                        }
+                    },
+                    "502": {
+                        "description": "Bad Gateway",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/watchlist/{title}": {
+            "delete": {
+                "security": [
+                    {
+                        "AdminToken": []
+                    }
+                ],
+                "description": "Removes the coin from the watchlist, stored history is kept",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "watchlist"
+                ],
+                "summary": "Stop tracking coin",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "\"BTC\"",
+                        "description": "Coin symbol",
+                        "name": "title",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.WatchlistUpdateResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/readyz": {
+            "get": {
+                "description": "Reports the state of every dependency. Returns 503 when a required one fails; failing optional ones, such as an open provider circuit, only make the status degraded",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "health"
+                ],
+                "summary": "Readiness probe",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ReadinessResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ReadinessResponse"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "dto.AggregateCoinResponse": {
+            "type": "object",
+            "properties": {
+                "coin_name": {
+                    "type": "string"
+                },
+                "price": {
+                    "description": "AVG, MAX или MIN",
+                    "type": "number"
+                }
+            }
+        },
+        "dto.AlertEventResponse": {
+            "type": "object",
+            "properties": {
+                "coin_name": {
+                    "type": "string",
+                    "example": "USDC"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "kind": {
+                    "type": "string",
+                    "example": "depeg_started"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "price": {
+                    "type": "number"
+                },
+                "threshold": {
+                    "type": "number"
+                },
+                "value": {
+                    "type": "number"
+                }
+            }
+        },
+        "dto.BatchRequest": {
+            "type": "object",
+            "properties": {
+                "requests": {
+                    "description": "не больше 20",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.BatchSubRequest"
+                    }
+                }
+            }
+        },
+        "dto.BatchResponse": {
+            "type": "object",
+            "properties": {
+                "results": {
+                    "description": "в порядке запросов",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.BatchResult"
+                    }
+                }
+            }
+        },
+        "dto.BatchResult": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "стабильный код ошибки при status \u003e= 400, как в ProblemResponse",
+                    "type": "string"
+                },
+                "data": {
+                    "description": "[]CoinResponse, QuotesResponse, []AggregateCoinResponse или []CandleResponse"
+                },
+                "error": {
+                    "description": "detail ошибки при status \u003e= 400",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "status": {
+                    "description": "HTTP-статус, который вернул бы отдельный запрос",
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.BatchSubRequest": {
+            "type": "object",
+            "properties": {
+                "agg": {
+                    "description": "только для агрегатов: AVG, MAX или MIN",
+                    "type": "string",
+                    "example": "AVG"
+                },
+                "currency": {
+                    "description": "должна совпадать с валютой котировок",
+                    "type": "string",
+                    "example": "USD"
+                },
+                "from": {
+                    "description": "только для history",
+                    "type": "string"
+                },
+                "id": {
+                    "description": "по умолчанию номер запроса в пакете",
+                    "type": "string",
+                    "example": "btc-latest"
+                },
+                "interval": {
+                    "description": "только для history",
+                    "type": "string",
+                    "example": "1h"
+                },
+                "titles": {
+                    "description": "до 100 символов, дубликаты отбрасываются",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "BTC",
+                        "ETH"
+                    ]
+                },
+                "to": {
+                    "description": "только для history, по умолчанию сейчас",
+                    "type": "string"
+                },
+                "type": {
+                    "description": "latest, quotes, aggregate или history",
+                    "type": "string",
+                    "example": "latest"
+                }
+            }
+        },
+        "dto.CoinResponse": {
+            "type": "object",
+            "properties": {
+                "age_seconds": {
+                    "type": "number"
+                },
+                "coin_name": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "description": "Deprecated: совпадает с ingested_at, оставлено для совместимости",
+                    "type": "string"
+                },
+                "currency": {
+                    "type": "string"
+                },
+                "ingested_at": {
+                    "description": "время записи в хранилище",
+                    "type": "string"
+                },
+                "observed_at": {
+                    "description": "время котировки у провайдера",
+                    "type": "string"
+                },
+                "price": {
+                    "type": "number"
+                },
+                "source": {
+                    "type": "string"
+                },
+                "stale": {
+                    "description": "Stale — провайдер недоступен, отдана последняя сохранённая цена возрастом AgeSeconds",
+                    "type": "boolean"
+                }
+            }
+        },
+        "dto.CoinsRequest": {
+            "type": "object",
+            "properties": {
+                "agg": {
+                    "description": "только для агрегатов: AVG, MAX или MIN",
+                    "type": "string",
+                    "example": "AVG"
+                },
+                "currency": {
+                    "description": "должна совпадать с валютой котировок",
+                    "type": "string",
+                    "example": "USD"
+                },
+                "titles": {
+                    "description": "до 100 символов, дубликаты отбрасываются",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "BTC",
+                        "ETH"
+                    ]
+                }
+            }
+        },
+        "dto.ConversionResponse": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number"
+                },
+                "from": {
+                    "type": "string"
+                },
+                "from_price_at": {
+                    "description": "время наблюдения цены у провайдера",
+                    "type": "string"
+                },
+                "rate": {
+                    "type": "number"
+                },
+                "result": {
+                    "type": "number"
+                },
+                "to": {
+                    "type": "string"
+                },
+                "to_price_at": {
+                    "type": "string"
+                },
+                "via": {
+                    "description": "валюта триангуляции",
+                    "type": "string"
+                }
+            }
+        },
+        "dto.CorrelationResponse": {
+            "type": "object",
+            "properties": {
+                "interval": {
+                    "type": "string",
+                    "example": "1h"
+                },
+                "matrix": {
+                    "type": "array",
+                    "items": {
+                        "type": "array",
+                        "items": {
+                            "type": "number"
+                        }
+                    }
+                },
+                "observations": {
+                    "type": "array",
+                    "items": {
+                        "type": "array",
+                        "items": {
+                            "type": "integer"
+                        }
+                    }
+                },
+                "titles": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "window": {
+                    "type": "string",
+                    "example": "30d"
+                }
+            }
+        },
+        "dto.CreatePortfolioRequest": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "example": "main"
+                }
+            }
+        },
+        "dto.DepegStatusResponse": {
+            "type": "object",
+            "properties": {
+                "breached_since": {
+                    "type": "string"
+                },
+                "coin_name": {
+                    "type": "string",
+                    "example": "USDT"
+                },
+                "depegged": {
+                    "type": "boolean"
+                },
+                "deviation_bps": {
+                    "type": "number",
+                    "example": -13
+                },
+                "observed_at": {
+                    "type": "string"
+                },
+                "peg": {
+                    "type": "number",
+                    "example": 1
+                },
+                "price": {
+                    "type": "number",
+                    "example": 0.9987
+                }
+            }
+        },
+        "dto.DeviationHistoryResponse": {
+            "type": "object",
+            "properties": {
+                "coin_name": {
+                    "type": "string",
+                    "example": "USDT"
+                },
+                "interval": {
+                    "type": "string",
+                    "example": "1h"
+                },
+                "points": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.DeviationPointResponse"
+                    }
+                },
+                "window": {
+                    "type": "string",
+                    "example": "7d"
+                }
+            }
+        },
+        "dto.DeviationPointResponse": {
+            "type": "object",
+            "properties": {
+                "deviation_bps": {
+                    "type": "number"
+                },
+                "price": {
+                    "type": "number"
+                },
+                "time": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.GraphQLError": {
+            "type": "object",
+            "properties": {
+                "extensions": {
+                    "description": "code: INVALID_ARGUMENT, NOT_FOUND, STALE_DATA или INTERNAL",
+                    "type": "object",
+                    "additionalProperties": {}
+                },
+                "message": {
+                    "type": "string"
+                },
+                "path": {
+                    "type": "array",
+                    "items": {}
+                }
+            }
+        },
+        "dto.GraphQLRequest": {
+            "type": "object",
+            "properties": {
+                "operationName": {
+                    "type": "string"
+                },
+                "query": {
+                    "type": "string",
+                    "example": "{ coins(titles: [\"BTC\", \"ETH\"]) { title latest { price } avg: aggregate(function: AVG) } }"
+                },
+                "variables": {
+                    "type": "object",
+                    "additionalProperties": {}
+                }
+            }
+        },
+        "dto.GraphQLResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "object",
+                    "additionalProperties": {}
+                },
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.GraphQLError"
+                    }
+                }
+            }
+        },
+        "dto.ImportLineErrorResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                },
+                "line": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.ImportResponse": {
+            "type": "object",
+            "properties": {
+                "errors": {
+                    "description": "первые 100 отклонённых строк",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.ImportLineErrorResponse"
+                    }
+                },
+                "imported": {
+                    "type": "integer"
+                },
+                "lines": {
+                    "type": "integer"
+                },
+                "rejected": {
+                    "type": "integer"
+                },
+                "skipped": {
+                    "description": "уже были в хранилище",
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.IndicatorPointResponse": {
+            "type": "object",
+            "properties": {
+                "close": {
+                    "type": "number"
+                },
+                "time": {
+                    "type": "string"
+                },
+                "values": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "number"
+                    },
+                    "example": {
+                        "value": 42000.5
+                    }
+                }
+            }
+        },
+        "dto.IndicatorResponse": {
+            "type": "object",
+            "properties": {
+                "coin_name": {
+                    "type": "string",
+                    "example": "BTC"
+                },
+                "interval": {
+                    "type": "string",
+                    "example": "1h"
+                },
+                "period": {
+                    "type": "integer",
+                    "example": 20
+                },
+                "points": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.IndicatorPointResponse"
+                    }
+                },
+                "type": {
+                    "type": "string",
+                    "example": "sma"
+                }
+            }
+        },
+        "dto.MarketMoverResponse": {
+            "type": "object",
+            "properties": {
+                "change_pct": {
+                    "type": "number"
+                },
+                "coin_name": {
+                    "type": "string"
+                },
+                "last_price": {
+                    "type": "number"
+                },
+                "open_price": {
+                    "type": "number"
+                },
+                "volatility": {
+                    "description": "стандартное отклонение цены в % от средней",
+                    "type": "number"
+                }
+            }
+        },
+        "dto.MarketMoversResponse": {
+            "type": "object",
+            "properties": {
+                "down": {
+                    "type": "integer"
+                },
+                "gainers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.MarketMoverResponse"
+                    }
+                },
+                "losers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.MarketMoverResponse"
+                    }
+                },
+                "most_volatile": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.MarketMoverResponse"
+                    }
+                },
+                "since": {
+                    "type": "string"
+                },
+                "unchanged": {
+                    "type": "integer"
+                },
+                "up": {
+                    "type": "integer"
+                },
+                "window": {
+                    "type": "string",
+                    "example": "24h"
+                }
+            }
+        },
+        "dto.PortfolioResponse": {
+            "type": "object",
+            "properties": {
                 "created_at": {
                     "type": "string"
                 },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.PositionResponse": {
+            "type": "object",
+            "properties": {
+                "coin_name": {
+                    "type": "string"
+                },
+                "cost_basis": {
+                    "type": "number"
+                },
+                "market_value": {
+                    "type": "number"
+                },
                 "price": {
                     "type": "number"
+                },
+                "price_at": {
+                    "type": "string"
+                },
+                "quantity": {
+                    "type": "number"
+                },
+                "realized_pnl": {
+                    "type": "number"
+                },
+                "unrealized_pnl": {
+                    "type": "number"
                 }
             }
         },
-        "dto.ErrorResponseDto": {
+        "dto.ProblemResponse": {
             "type": "object",
             "properties": {
                 "code": {
+                    "description": "стабильный код ошибки",
+                    "type": "string",
+                    "example": "UNKNOWN_SYMBOL"
+                },
+                "detail": {
+                    "description": "для ошибок сервера — без внутренних подробностей",
+                    "type": "string",
+                    "example": "unknown titles: [XXX]"
+                },
+                "instance": {
+                    "type": "string",
+                    "example": "/api/v1/coins/actual"
+                },
+                "status": {
+                    "type": "integer",
+                    "example": 404
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Unknown coin symbol"
+                },
+                "type": {
+                    "type": "string",
+                    "example": "/problems/unknown-symbol"
+                }
+            }
+        },
+        "dto.QuoteResponse": {
+            "type": "object",
+            "properties": {
+                "coin": {
+                    "description": "есть при статусах ok и stale",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/dto.CoinResponse"
+                        }
+                    ]
+                },
+                "detail": {
+                    "description": "причина, если статус не ok",
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "ok",
+                        "unknown_symbol",
+                        "rejected",
+                        "stale",
+                        "provider_error"
+                    ],
+                    "example": "ok"
+                },
+                "title": {
+                    "type": "string",
+                    "example": "BTC"
+                }
+            }
+        },
+        "dto.QuotesResponse": {
+            "type": "object",
+            "properties": {
+                "complete": {
+                    "description": "у всех монет статус ok",
+                    "type": "boolean"
+                },
+                "results": {
+                    "description": "в порядке запроса",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.QuoteResponse"
+                    }
+                }
+            }
+        },
+        "dto.ReadinessResponse": {
+            "type": "object",
+            "properties": {
+                "checks": {
+                    "description": "состояние каждой зависимости, например \"provider\": \"open\"",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "ready",
+                        "degraded",
+                        "not_ready"
+                    ],
+                    "example": "ready"
+                }
+            }
+        },
+        "dto.TickerResponse": {
+            "type": "object",
+            "properties": {
+                "change_24h_pct": {
+                    "type": "number"
+                },
+                "coin_name": {
+                    "type": "string"
+                },
+                "currency": {
+                    "type": "string"
+                },
+                "high_24h": {
+                    "type": "number"
+                },
+                "low_24h": {
+                    "type": "number"
+                },
+                "market_cap": {
+                    "type": "number"
+                },
+                "observed_at": {
+                    "type": "string"
+                },
+                "price": {
+                    "type": "number"
+                },
+                "source": {
+                    "type": "string"
+                },
+                "supply": {
+                    "type": "number"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "volume_24h": {
+                    "description": "объём торгов за 24 часа в currency",
+                    "type": "number"
+                }
+            }
+        },
+        "dto.TransactionRequest": {
+            "type": "object",
+            "properties": {
+                "coin_name": {
+                    "type": "string",
+                    "example": "BTC"
+                },
+                "executed_at": {
+                    "description": "по умолчанию текущее время",
+                    "type": "string"
+                },
+                "fee": {
+                    "type": "number",
+                    "example": 10
+                },
+                "price": {
+                    "type": "number",
+                    "example": 30000
+                },
+                "quantity": {
+                    "type": "number",
+                    "example": 0.5
+                },
+                "side": {
+                    "type": "string",
+                    "enum": [
+                        "buy",
+                        "sell"
+                    ],
+                    "example": "buy"
+                }
+            }
+        },
+        "dto.TransactionResponse": {
+            "type": "object",
+            "properties": {
+                "coin_name": {
+                    "type": "string"
+                },
+                "executed_at": {
+                    "type": "string"
+                },
+                "fee": {
+                    "type": "number"
+                },
+                "id": {
                     "type": "integer"
                 },
-                "error": {
+                "portfolio_id": {
+                    "type": "integer"
+                },
+                "price": {
+                    "type": "number"
+                },
+                "quantity": {
+                    "type": "number"
+                },
+                "side": {
                     "type": "string"
                 }
             }
+        },
+        "dto.ValuationResponse": {
+            "type": "object",
+            "properties": {
+                "at": {
+                    "type": "string"
+                },
+                "cost_basis": {
+                    "type": "number"
+                },
+                "market_value": {
+                    "type": "number"
+                },
+                "portfolio_id": {
+                    "type": "integer"
+                },
+                "positions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.PositionResponse"
+                    }
+                },
+                "realized_pnl": {
+                    "type": "number"
+                },
+                "unrealized_pnl": {
+                    "type": "number"
+                }
+            }
+        },
+        "dto.VolatilityListResponse": {
+            "type": "object",
+            "properties": {
+                "coins": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.VolatilityResponse"
+                    }
+                },
+                "interval": {
+                    "type": "string",
+                    "example": "1h"
+                },
+                "window": {
+                    "type": "string",
+                    "example": "30d"
+                }
+            }
+        },
+        "dto.VolatilityResponse": {
+            "type": "object",
+            "properties": {
+                "coin_name": {
+                    "type": "string",
+                    "example": "BTC"
+                },
+                "returns": {
+                    "type": "integer",
+                    "example": 720
+                },
+                "volatility": {
+                    "description": "годовая, доля (0.54 = 54%)",
+                    "type": "number",
+                    "example": 0.54
+                }
+            }
+        },
+        "dto.WatchlistRequest": {
+            "type": "object",
+            "properties": {
+                "titles": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "BTC",
+                        "ETH"
+                    ]
+                }
+            }
+        },
+        "dto.WatchlistResponse": {
+            "type": "object",
+            "properties": {
+                "titles": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "dto.WatchlistUpdateResponse": {
+            "type": "object",
+            "properties": {
+                "added": {
+                    "type": "integer"
+                },
+                "removed": {
+                    "type": "integer"
+                }
+            }
+        }
+    },
+    "securityDefinitions": {
+        "AdminToken": {
+            "description": "Admin token as \"Bearer \u003ctoken\u003e\"; admin endpoints are disabled when ADMIN_TOKEN is not set",
+            "type": "apiKey",
+            "name": "Authorization",
+            "in": "header"
         }
     }
 }`
 
 // SwaggerInfo holds exported Swagger Info so clients can modify it
 var SwaggerInfo = &swag.Spec{
-	Version:          "",
-	Host:             "",
-	BasePath:         "",
-	Schemes:          []string{},
-	Title:            "",
-	Description:      "",
+	Version:          "1.0",
+	Host:             "localhost:8080",
+	BasePath:         "/api/v1",
+	Schemes:          []string{"http"},
+	Title:            "Cryptocurrency API",
+	Description:      "API for cryptocurrency data management",
 	InfoInstanceName: "swagger",
 	SwaggerTemplate:  docTemplate,
 	LeftDelim:        "{{",
@@ -0,0 +1,156 @@
+// Package export encodes price history for bulk downloads. Writers encode
+// records as they arrive, so an export never holds the whole dataset in memory.
+package export
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/xitongsys/parquet-go/parquet"
+	"github.com/xitongsys/parquet-go/writer"
+
+	"Cryptoproject/internal/entities"
+)
+
+// parquetRowGroupSize ограничивает буфер parquet-писателя: группа строк держится в памяти до сброса.
+const parquetRowGroupSize = 8 << 20
+
+var csvHeader = []string{"coin_name", "time", "resolution", "open", "high", "low", "close", "avg", "count"}
+
+// Writer encodes history records. Close flushes buffered data and writes the
+// format footer, if any; it does not close the underlying writer.
+type Writer interface {
+	Write(record entities.HistoryRecord) error
+	Close() error
+}
+
+// NewWriter returns a Writer encoding records in format to w.
+func NewWriter(w io.Writer, format entities.ExportFormat) (Writer, error) {
+	switch format {
+	case entities.ExportCSV:
+		return newCSVWriter(w)
+	case entities.ExportNDJSON:
+		buffered := bufio.NewWriter(w)
+		return &ndjsonWriter{buffered: buffered, encoder: json.NewEncoder(buffered)}, nil
+	case entities.ExportParquet:
+		return newParquetWriter(w)
+	default:
+		return nil, errors.Wrapf(entities.ErrInvalidParam, "unsupported format: %q", format)
+	}
+}
+
+// ContentType returns the media type of format.
+func ContentType(format entities.ExportFormat) string {
+	switch format {
+	case entities.ExportNDJSON:
+		return "application/x-ndjson"
+	case entities.ExportParquet:
+		return "application/vnd.apache.parquet"
+	default:
+		return "text/csv; charset=utf-8"
+	}
+}
+
+// Compressed reports whether format is already compressed and gains nothing from gzip.
+func Compressed(format entities.ExportFormat) bool {
+	return format == entities.ExportParquet
+}
+
+type csvWriter struct {
+	writer *csv.Writer
+	row    []string
+}
+
+func newCSVWriter(w io.Writer) (*csvWriter, error) {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(csvHeader); err != nil {
+		return nil, err
+	}
+	return &csvWriter{writer: writer, row: make([]string, len(csvHeader))}, nil
+}
+
+func (w *csvWriter) Write(record entities.HistoryRecord) error {
+	w.row[0] = record.CoinName
+	w.row[1] = record.Time.UTC().Format(time.RFC3339)
+	w.row[2] = record.Resolution
+	w.row[3] = formatFloat(record.Open)
+	w.row[4] = formatFloat(record.High)
+	w.row[5] = formatFloat(record.Low)
+	w.row[6] = formatFloat(record.Close)
+	w.row[7] = formatFloat(record.Avg)
+	w.row[8] = strconv.FormatInt(record.Count, 10)
+	return w.writer.Write(w.row)
+}
+
+func (w *csvWriter) Close() error {
+	w.writer.Flush()
+	return w.writer.Error()
+}
+
+type ndjsonWriter struct {
+	buffered *bufio.Writer
+	encoder  *json.Encoder
+}
+
+func (w *ndjsonWriter) Write(record entities.HistoryRecord) error {
+	record.Time = record.Time.UTC()
+	return w.encoder.Encode(record)
+}
+
+func (w *ndjsonWriter) Close() error {
+	return w.buffered.Flush()
+}
+
+// parquetRecord — схема parquet-файла; время хранится в микросекундах UTC.
+type parquetRecord struct {
+	CoinName   string  `parquet:"name=coin_name, type=BYTE_ARRAY, convertedtype=UTF8, encoding=PLAIN_DICTIONARY"`
+	Time       int64   `parquet:"name=time, type=INT64, convertedtype=TIMESTAMP_MICROS"`
+	Resolution string  `parquet:"name=resolution, type=BYTE_ARRAY, convertedtype=UTF8, encoding=PLAIN_DICTIONARY"`
+	Open       float64 `parquet:"name=open, type=DOUBLE"`
+	High       float64 `parquet:"name=high, type=DOUBLE"`
+	Low        float64 `parquet:"name=low, type=DOUBLE"`
+	Close      float64 `parquet:"name=close, type=DOUBLE"`
+	Avg        float64 `parquet:"name=avg, type=DOUBLE"`
+	Count      int64   `parquet:"name=count, type=INT64"`
+}
+
+type parquetWriter struct {
+	writer *writer.ParquetWriter
+}
+
+func newParquetWriter(w io.Writer) (*parquetWriter, error) {
+	pw, err := writer.NewParquetWriterFromWriter(w, new(parquetRecord), 1)
+	if err != nil {
+		return nil, errors.Wrapf(entities.ErrInternal, "create parquet writer: %v", err)
+	}
+	pw.RowGroupSize = parquetRowGroupSize
+	pw.CompressionType = parquet.CompressionCodec_SNAPPY
+	return &parquetWriter{writer: pw}, nil
+}
+
+func (w *parquetWriter) Write(record entities.HistoryRecord) error {
+	return w.writer.Write(parquetRecord{
+		CoinName:   record.CoinName,
+		Time:       record.Time.UnixMicro(),
+		Resolution: record.Resolution,
+		Open:       record.Open,
+		High:       record.High,
+		Low:        record.Low,
+		Close:      record.Close,
+		Avg:        record.Avg,
+		Count:      record.Count,
+	})
+}
+
+func (w *parquetWriter) Close() error {
+	return w.writer.WriteStop()
+}
+
+func formatFloat(value float64) string {
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
@@ -0,0 +1,97 @@
+package export_test
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xitongsys/parquet-go-source/buffer"
+	"github.com/xitongsys/parquet-go/reader"
+
+	"Cryptoproject/internal/adapters/export"
+	"Cryptoproject/internal/entities"
+)
+
+var records = []entities.HistoryRecord{
+	{CoinName: "BTC", Time: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), Resolution: "day",
+		Open: 42000, High: 43000.5, Low: 41500, Close: 42800, Avg: 42250.25, Count: 1440},
+	{CoinName: "BTC", Time: time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC), Resolution: "raw",
+		Open: 94000.1, High: 94000.1, Low: 94000.1, Close: 94000.1, Avg: 94000.1, Count: 1},
+}
+
+func writeAll(t *testing.T, format entities.ExportFormat) []byte {
+	t.Helper()
+
+	var out bytes.Buffer
+	w, err := export.NewWriter(&out, format)
+	require.NoError(t, err)
+	for _, record := range records {
+		require.NoError(t, w.Write(record))
+	}
+	require.NoError(t, w.Close())
+	return out.Bytes()
+}
+
+func Test_Writer_CSV(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "coin_name,time,resolution,open,high,low,close,avg,count\n"+
+		"BTC,2024-01-01T00:00:00Z,day,42000,43000.5,41500,42800,42250.25,1440\n"+
+		"BTC,2025-01-01T12:00:00Z,raw,94000.1,94000.1,94000.1,94000.1,94000.1,1\n",
+		string(writeAll(t, entities.ExportCSV)))
+}
+
+func Test_Writer_NDJSON(t *testing.T) {
+	t.Parallel()
+
+	scanner := bufio.NewScanner(bytes.NewReader(writeAll(t, entities.ExportNDJSON)))
+	var decoded []entities.HistoryRecord
+	for scanner.Scan() {
+		var record entities.HistoryRecord
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &record))
+		decoded = append(decoded, record)
+	}
+	assert.Equal(t, records, decoded)
+}
+
+func Test_Writer_Parquet(t *testing.T) {
+	t.Parallel()
+
+	type row struct {
+		CoinName   string  `parquet:"name=coin_name, type=BYTE_ARRAY, convertedtype=UTF8"`
+		Time       int64   `parquet:"name=time, type=INT64, convertedtype=TIMESTAMP_MICROS"`
+		Resolution string  `parquet:"name=resolution, type=BYTE_ARRAY, convertedtype=UTF8"`
+		Open       float64 `parquet:"name=open, type=DOUBLE"`
+		High       float64 `parquet:"name=high, type=DOUBLE"`
+		Low        float64 `parquet:"name=low, type=DOUBLE"`
+		Close      float64 `parquet:"name=close, type=DOUBLE"`
+		Avg        float64 `parquet:"name=avg, type=DOUBLE"`
+		Count      int64   `parquet:"name=count, type=INT64"`
+	}
+
+	file, err := buffer.NewBufferFile(writeAll(t, entities.ExportParquet))
+	require.NoError(t, err)
+	pr, err := reader.NewParquetReader(file, new(row), 1)
+	require.NoError(t, err)
+	defer pr.ReadStop()
+
+	require.EqualValues(t, len(records), pr.GetNumRows())
+	rows := make([]row, len(records))
+	require.NoError(t, pr.Read(&rows))
+	assert.Equal(t, "BTC", rows[1].CoinName)
+	assert.Equal(t, records[1].Time.UnixMicro(), rows[1].Time)
+	assert.Equal(t, "day", rows[0].Resolution)
+	assert.Equal(t, 42250.25, rows[0].Avg)
+	assert.EqualValues(t, 1440, rows[0].Count)
+}
+
+func Test_NewWriter_UnsupportedFormat(t *testing.T) {
+	t.Parallel()
+
+	_, err := export.NewWriter(&bytes.Buffer{}, "xlsx")
+	assert.ErrorIs(t, err, entities.ErrInvalidParam)
+}
@@ -0,0 +1,211 @@
+// Package importer parses price history dumps into coins line by line, so
+// arbitrarily large files can be imported without loading them into memory.
+package importer
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"Cryptoproject/internal/entities"
+)
+
+// maxLineSize — предельная длина строки NDJSON.
+const maxLineSize = 1 << 20
+
+// Колонки и их синонимы; синонимы совпадают с выгрузкой export, так что её можно загрузить обратно.
+var columnAliases = map[string]string{
+	"coin_name":   "coin_name",
+	"coin":        "coin_name",
+	"price":       "price",
+	"close":       "price",
+	"observed_at": "observed_at",
+	"time":        "observed_at",
+	"currency":    "currency",
+	"source":      "source",
+}
+
+// NewReader returns a CoinReader parsing r in format. CSV input must start with a
+// header naming at least coin_name, price and observed_at columns.
+func NewReader(r io.Reader, format entities.ExportFormat) (entities.CoinReader, error) {
+	switch format {
+	case entities.ExportCSV:
+		return newCSVReader(r)
+	case entities.ExportNDJSON:
+		scanner := bufio.NewScanner(r)
+		scanner.Buffer(make([]byte, 0, 64*1024), maxLineSize)
+		return &ndjsonReader{scanner: scanner}, nil
+	default:
+		return nil, errors.Wrapf(entities.ErrInvalidParam, "unsupported import format: %q", format)
+	}
+}
+
+type csvReader struct {
+	reader  *csv.Reader
+	columns map[string]int
+	line    int
+}
+
+func newCSVReader(r io.Reader) (*csvReader, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, errors.Wrap(entities.ErrInvalidParam, "empty input")
+	}
+	if err != nil {
+		return nil, errors.Wrapf(entities.ErrInvalidParam, "invalid header: %v", err)
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		if column, ok := columnAliases[strings.ToLower(strings.TrimSpace(name))]; ok {
+			if _, seen := columns[column]; !seen {
+				columns[column] = i
+			}
+		}
+	}
+	for _, required := range []string{"coin_name", "price", "observed_at"} {
+		if _, ok := columns[required]; !ok {
+			return nil, errors.Wrapf(entities.ErrInvalidParam, "header has no %s column", required)
+		}
+	}
+	return &csvReader{reader: reader, columns: columns, line: 1}, nil
+}
+
+func (r *csvReader) Next() (entities.Coin, error) {
+	record, err := r.reader.Read()
+	if err == io.EOF {
+		return entities.Coin{}, io.EOF
+	}
+	var parseErr *csv.ParseError
+	if errors.As(err, &parseErr) {
+		r.line = parseErr.StartLine
+		return entities.Coin{}, &entities.LineError{Line: r.line, Err: parseErr.Err}
+	}
+	if err != nil {
+		return entities.Coin{}, err
+	}
+	r.line, _ = r.reader.FieldPos(0)
+
+	field := func(column string) string {
+		if i, ok := r.columns[column]; ok && i < len(record) {
+			return strings.TrimSpace(record[i])
+		}
+		return ""
+	}
+
+	coin := entities.Coin{
+		CoinName: strings.ToUpper(field("coin_name")),
+		Currency: strings.ToUpper(field("currency")),
+		Source:   field("source"),
+	}
+	if coin.Price, err = strconv.ParseFloat(field("price"), 64); err != nil {
+		return entities.Coin{}, &entities.LineError{Line: r.line, Err: errors.Errorf("invalid price %q", field("price"))}
+	}
+	if coin.ObservedAt, err = parseTime(field("observed_at")); err != nil {
+		return entities.Coin{}, &entities.LineError{Line: r.line, Err: err}
+	}
+	return coin, nil
+}
+
+func (r *csvReader) Line() int {
+	return r.line
+}
+
+type ndjsonReader struct {
+	scanner *bufio.Scanner
+	line    int
+}
+
+// ndjsonRecord принимает и поля выгрузки export: close вместо price и time вместо observed_at.
+type ndjsonRecord struct {
+	CoinName   string          `json:"coin_name"`
+	Price      *float64        `json:"price"`
+	Close      *float64        `json:"close"`
+	ObservedAt json.RawMessage `json:"observed_at"`
+	Time       json.RawMessage `json:"time"`
+	Currency   string          `json:"currency"`
+	Source     string          `json:"source"`
+}
+
+func (r *ndjsonReader) Next() (entities.Coin, error) {
+	for r.scanner.Scan() {
+		r.line++
+		line := strings.TrimSpace(r.scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		var record ndjsonRecord
+		if err := json.Unmarshal([]byte(line), &record); err != nil {
+			return entities.Coin{}, &entities.LineError{Line: r.line, Err: errors.Errorf("invalid json: %v", err)}
+		}
+
+		coin := entities.Coin{
+			CoinName: strings.ToUpper(strings.TrimSpace(record.CoinName)),
+			Currency: strings.ToUpper(strings.TrimSpace(record.Currency)),
+			Source:   strings.TrimSpace(record.Source),
+		}
+		switch {
+		case record.Price != nil:
+			coin.Price = *record.Price
+		case record.Close != nil:
+			coin.Price = *record.Close
+		}
+
+		observedAt := record.ObservedAt
+		if len(observedAt) == 0 {
+			observedAt = record.Time
+		}
+		var err error
+		if coin.ObservedAt, err = parseJSONTime(observedAt); err != nil {
+			return entities.Coin{}, &entities.LineError{Line: r.line, Err: err}
+		}
+		return coin, nil
+	}
+	if err := r.scanner.Err(); err != nil {
+		if errors.Is(err, bufio.ErrTooLong) {
+			return entities.Coin{}, errors.Wrapf(entities.ErrInvalidParam, "line %d is longer than %d bytes", r.line+1, maxLineSize)
+		}
+		return entities.Coin{}, err
+	}
+	return entities.Coin{}, io.EOF
+}
+
+func (r *ndjsonReader) Line() int {
+	return r.line
+}
+
+func parseJSONTime(raw json.RawMessage) (time.Time, error) {
+	if len(raw) == 0 || string(raw) == "null" {
+		return time.Time{}, nil
+	}
+	var text string
+	if err := json.Unmarshal(raw, &text); err == nil {
+		return parseTime(text)
+	}
+	return parseTime(string(raw))
+}
+
+// parseTime принимает RFC 3339 или unix-время в секундах.
+func parseTime(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t.UTC(), nil
+	}
+	if seconds, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return time.Unix(seconds, 0).UTC(), nil
+	}
+	return time.Time{}, errors.Errorf("invalid time %q: use RFC 3339 or unix seconds", value)
+}
@@ -0,0 +1,107 @@
+package importer_test
+
+import (
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"Cryptoproject/internal/adapters/importer"
+	"Cryptoproject/internal/entities"
+)
+
+type parsed struct {
+	line int
+	coin entities.Coin
+	err  string
+}
+
+func readAll(t *testing.T, reader entities.CoinReader) []parsed {
+	t.Helper()
+
+	var result []parsed
+	for {
+		coin, err := reader.Next()
+		if err == io.EOF {
+			return result
+		}
+		var lineErr *entities.LineError
+		if errors.As(err, &lineErr) {
+			result = append(result, parsed{line: lineErr.Line, err: lineErr.Err.Error()})
+			continue
+		}
+		require.NoError(t, err)
+		result = append(result, parsed{line: reader.Line(), coin: coin})
+	}
+}
+
+func Test_Reader_CSV(t *testing.T) {
+	t.Parallel()
+
+	input := "Coin_Name,observed_at,price,currency\n" +
+		"btc,2025-01-01T12:00:00Z,94000.5,usd\n" +
+		"ETH,1735732800,abc,\n" +
+		"\"SOL,bad\n"
+	reader, err := importer.NewReader(strings.NewReader(input), entities.ExportCSV)
+	require.NoError(t, err)
+
+	result := readAll(t, reader)
+	require.Len(t, result, 3)
+	assert.Equal(t, parsed{line: 2, coin: entities.Coin{
+		CoinName: "BTC", Price: 94000.5, Currency: "USD",
+		ObservedAt: time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC),
+	}}, result[0])
+	assert.Equal(t, parsed{line: 3, err: `invalid price "abc"`}, result[1])
+	assert.Equal(t, 4, result[2].line)
+	assert.NotEmpty(t, result[2].err)
+}
+
+func Test_Reader_CSV_ExportRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	input := "coin_name,time,resolution,open,high,low,close,avg,count\n" +
+		"BTC,2025-01-01T12:00:00Z,raw,94000,94000,94000,94000,94000,1\n"
+	reader, err := importer.NewReader(strings.NewReader(input), entities.ExportCSV)
+	require.NoError(t, err)
+
+	result := readAll(t, reader)
+	require.Len(t, result, 1)
+	assert.Equal(t, 94000.0, result[0].coin.Price)
+	assert.Equal(t, time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC), result[0].coin.ObservedAt)
+}
+
+func Test_Reader_CSV_MissingColumn(t *testing.T) {
+	t.Parallel()
+
+	_, err := importer.NewReader(strings.NewReader("coin_name,price\nBTC,1\n"), entities.ExportCSV)
+	assert.ErrorIs(t, err, entities.ErrInvalidParam)
+	assert.Contains(t, err.Error(), "observed_at")
+}
+
+func Test_Reader_NDJSON(t *testing.T) {
+	t.Parallel()
+
+	input := `{"coin_name":"btc","price":94000.5,"observed_at":"2025-01-01T12:00:00Z","source":"legacy"}
+
+{"coin_name":"ETH","close":3300,"time":1735732800}
+{"coin_name":
+`
+	reader, err := importer.NewReader(strings.NewReader(input), entities.ExportNDJSON)
+	require.NoError(t, err)
+
+	result := readAll(t, reader)
+	require.Len(t, result, 3)
+	assert.Equal(t, parsed{line: 1, coin: entities.Coin{
+		CoinName: "BTC", Price: 94000.5, Source: "legacy",
+		ObservedAt: time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC),
+	}}, result[0])
+	assert.Equal(t, 3, result[1].line)
+	assert.Equal(t, 3300.0, result[1].coin.Price)
+	assert.Equal(t, time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC), result[1].coin.ObservedAt)
+	assert.Equal(t, 4, result[2].line)
+	assert.Contains(t, result[2].err, "invalid json")
+}
@@ -0,0 +1,279 @@
+// Package breaker wraps a price provider in a circuit breaker, so that during
+// a provider outage calls fail at once instead of waiting for a timeout.
+package breaker
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"Cryptoproject/internal/cases"
+	"Cryptoproject/internal/entities"
+)
+
+const (
+	defaultFailureThreshold  = 5
+	defaultCooldown          = 30 * time.Second
+	defaultHalfOpenSuccesses = 1
+)
+
+var _ cases.CryptoProvider = (*Breaker)(nil)
+
+// State is the state of the circuit.
+type State int
+
+const (
+	// StateClosed — провайдер работает, вызовы проходят
+	StateClosed State = iota
+	// StateHalfOpen — после паузы пропускается по одному пробному вызову
+	StateHalfOpen
+	// StateOpen — провайдер считается недоступным, вызовы сразу отклоняются
+	StateOpen
+)
+
+func (s State) String() string {
+	switch s {
+	case StateClosed:
+		return "closed"
+	case StateHalfOpen:
+		return "half_open"
+	default:
+		return "open"
+	}
+}
+
+// Config configures the circuit breaker.
+type Config struct {
+	// FailureThreshold is the number of consecutive provider failures that opens the circuit.
+	FailureThreshold int
+	// Cooldown is how long the circuit stays open before a probe call is let through.
+	Cooldown time.Duration
+	// HalfOpenSuccesses is the number of successful probes that close the circuit again.
+	HalfOpenSuccesses int
+}
+
+// Breaker is a cases.CryptoProvider that stops calling the wrapped provider
+// after FailureThreshold consecutive failures. While the circuit is open
+// calls return entities.ErrCircuitOpen; after Cooldown single probe calls
+// are let through until HalfOpenSuccesses of them succeed.
+//
+// Only provider failures count: unknown symbols and invalid params mean the
+// provider answered, and cancelled calls say nothing about it.
+type Breaker struct {
+	provider cases.CryptoProvider
+	cfg      Config
+	logger   *slog.Logger
+	now      func() time.Time
+	metrics  *metrics
+
+	mu        sync.Mutex
+	state     State
+	failures  int
+	successes int
+	openedAt  time.Time
+	probing   bool
+}
+
+type Option func(b *Breaker)
+
+// WithRegisterer registers the breaker metrics in reg.
+func WithRegisterer(reg prometheus.Registerer) Option {
+	return func(b *Breaker) {
+		b.metrics = newMetrics(reg)
+	}
+}
+
+// WithClock replaces time.Now, for tests.
+func WithClock(now func() time.Time) Option {
+	return func(b *Breaker) {
+		b.now = now
+	}
+}
+
+func New(provider cases.CryptoProvider, cfg Config, logger *slog.Logger, opts ...Option) (*Breaker, error) {
+	const op = "breaker.New"
+	if logger == nil {
+		logger = slog.Default()
+	}
+	logger = logger.With(slog.String("component", "provider-breaker"))
+
+	if provider == nil {
+		err := errors.Wrap(entities.ErrInvalidParam, "provider not set")
+		logger.Error(op, slog.String("error", err.Error()))
+		return nil, err
+	}
+	if cfg.FailureThreshold <= 0 {
+		cfg.FailureThreshold = defaultFailureThreshold
+	}
+	if cfg.Cooldown <= 0 {
+		cfg.Cooldown = defaultCooldown
+	}
+	if cfg.HalfOpenSuccesses <= 0 {
+		cfg.HalfOpenSuccesses = defaultHalfOpenSuccesses
+	}
+
+	b := &Breaker{
+		provider: provider,
+		cfg:      cfg,
+		logger:   logger,
+		now:      time.Now,
+	}
+	for _, opt := range opts {
+		opt(b)
+	}
+	if b.metrics != nil {
+		if err := b.metrics.register(); err != nil {
+			logger.Error(op, slog.String("error", err.Error()))
+			return nil, errors.Wrap(err, "failed to register breaker metrics")
+		}
+	}
+
+	logger.Info("Circuit breaker initialized",
+		slog.Int("failure_threshold", cfg.FailureThreshold),
+		slog.Duration("cooldown", cfg.Cooldown),
+		slog.Int("half_open_successes", cfg.HalfOpenSuccesses))
+	return b, nil
+}
+
+func (b *Breaker) GetActualRates(ctx context.Context, titles []string) ([]entities.Coin, error) {
+	return call(b, func() ([]entities.Coin, error) {
+		return b.provider.GetActualRates(ctx, titles)
+	})
+}
+
+func (b *Breaker) GetTickers(ctx context.Context, titles []string) ([]entities.Ticker, error) {
+	return call(b, func() ([]entities.Ticker, error) {
+		return b.provider.GetTickers(ctx, titles)
+	})
+}
+
+func (b *Breaker) GetHistory(ctx context.Context, title string, from, to time.Time) ([]entities.Candle, error) {
+	return call(b, func() ([]entities.Candle, error) {
+		return b.provider.GetHistory(ctx, title, from, to)
+	})
+}
+
+// State returns the current state of the circuit.
+func (b *Breaker) State() State {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == StateOpen && b.now().Sub(b.openedAt) >= b.cfg.Cooldown {
+		// пробный вызов ещё не сделан, но уже разрешён
+		return StateHalfOpen
+	}
+	return b.state
+}
+
+// Check reports the state of the circuit for readiness probes and fails
+// while the circuit is open.
+func (b *Breaker) Check(_ context.Context) (string, error) {
+	state := b.State()
+	if state == StateOpen {
+		return state.String(), errors.Wrap(entities.ErrCircuitOpen, "provider circuit is open")
+	}
+	return state.String(), nil
+}
+
+func call[T any](b *Breaker, fn func() (T, error)) (T, error) {
+	probe, err := b.allow()
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	result, err := fn()
+	b.record(probe, err)
+	return result, err
+}
+
+// allow reports whether a call may go to the provider and whether it is a
+// probe of a half-open circuit.
+func (b *Breaker) allow() (bool, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := b.now()
+	if b.state == StateOpen {
+		if wait := b.cfg.Cooldown - now.Sub(b.openedAt); wait > 0 {
+			b.metrics.observeCall(resultRejected)
+			return false, errors.Wrapf(entities.ErrCircuitOpen, "provider calls suspended for %s", wait.Truncate(time.Millisecond))
+		}
+		b.setState(StateHalfOpen)
+	}
+	if b.state == StateHalfOpen {
+		if b.probing {
+			b.metrics.observeCall(resultRejected)
+			return false, errors.Wrap(entities.ErrCircuitOpen, "provider probe in progress")
+		}
+		b.probing = true
+		return true, nil
+	}
+	return false, nil
+}
+
+// record updates the circuit with the outcome of a provider call.
+func (b *Breaker) record(probe bool, err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if probe {
+		b.probing = false
+	}
+
+	switch {
+	case errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded):
+		// вызов прервал клиент: о провайдере это ничего не говорит
+		b.metrics.observeCall(resultCanceled)
+	case isFailure(err):
+		b.metrics.observeCall(resultFailure)
+		b.failures++
+		switch {
+		case b.state == StateHalfOpen && probe:
+			b.open(err)
+		case b.state == StateClosed && b.failures >= b.cfg.FailureThreshold:
+			b.open(err)
+		}
+	default:
+		b.metrics.observeCall(resultSuccess)
+		b.failures = 0
+		if b.state == StateHalfOpen && probe {
+			b.successes++
+			if b.successes >= b.cfg.HalfOpenSuccesses {
+				b.setState(StateClosed)
+			}
+		}
+	}
+}
+
+func (b *Breaker) open(cause error) {
+	b.logger.Warn("Provider circuit opened",
+		slog.Int("failures", b.failures),
+		slog.Duration("cooldown", b.cfg.Cooldown),
+		slog.String("error", cause.Error()))
+	b.openedAt = b.now()
+	b.setState(StateOpen)
+}
+
+func (b *Breaker) setState(state State) {
+	if b.state == state {
+		return
+	}
+	b.logger.Info("Provider circuit state changed",
+		slog.String("from", b.state.String()),
+		slog.String("to", state.String()))
+
+	b.state = state
+	b.failures = 0
+	b.successes = 0
+	b.metrics.observeState(state)
+}
+
+func isFailure(err error) bool {
+	return errors.Is(err, entities.ErrProviderUnavailable) ||
+		errors.Is(err, entities.ErrProviderError) ||
+		errors.Is(err, entities.ErrRateLimited)
+}
@@ -0,0 +1,137 @@
+package breaker_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"Cryptoproject/internal/adapters/provider/breaker"
+	"Cryptoproject/internal/entities"
+)
+
+// fakeProvider отвечает ошибкой err и считает вызовы
+type fakeProvider struct {
+	err   error
+	calls int
+}
+
+func (p *fakeProvider) GetActualRates(_ context.Context, titles []string) ([]entities.Coin, error) {
+	p.calls++
+	if p.err != nil {
+		return nil, p.err
+	}
+	return []entities.Coin{{CoinName: titles[0], Price: 1}}, nil
+}
+
+func (p *fakeProvider) GetTickers(_ context.Context, _ []string) ([]entities.Ticker, error) {
+	p.calls++
+	return nil, p.err
+}
+
+func (p *fakeProvider) GetHistory(_ context.Context, _ string, _, _ time.Time) ([]entities.Candle, error) {
+	p.calls++
+	return nil, p.err
+}
+
+type clock struct{ now time.Time }
+
+func (c *clock) Now() time.Time { return c.now }
+
+func Test_Breaker_OpensAndRecovers(t *testing.T) {
+	t.Parallel()
+
+	provider := &fakeProvider{err: errors.Wrap(entities.ErrProviderUnavailable, "connection refused")}
+	clk := &clock{now: time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)}
+	reg := prometheus.NewRegistry()
+	b, err := breaker.New(provider, breaker.Config{FailureThreshold: 3, Cooldown: 30 * time.Second}, nil,
+		breaker.WithClock(clk.Now), breaker.WithRegisterer(reg))
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	for i := 0; i < 3; i++ {
+		_, err = b.GetActualRates(ctx, []string{"BTC"})
+		assert.ErrorIs(t, err, entities.ErrProviderUnavailable)
+		assert.NotErrorIs(t, err, entities.ErrCircuitOpen)
+	}
+	assert.Equal(t, breaker.StateOpen, b.State())
+
+	// открытая цепь отвечает сразу, не обращаясь к провайдеру
+	_, err = b.GetTickers(ctx, []string{"BTC"})
+	assert.ErrorIs(t, err, entities.ErrCircuitOpen)
+	assert.ErrorIs(t, err, entities.ErrProviderUnavailable)
+	assert.Equal(t, 3, provider.calls)
+
+	state, err := b.Check(ctx)
+	assert.Equal(t, "open", state)
+	assert.ErrorIs(t, err, entities.ErrCircuitOpen)
+
+	// неудачная проба снова открывает цепь
+	clk.now = clk.now.Add(30 * time.Second)
+	assert.Equal(t, breaker.StateHalfOpen, b.State())
+	_, err = b.GetActualRates(ctx, []string{"BTC"})
+	assert.NotErrorIs(t, err, entities.ErrCircuitOpen)
+	assert.Equal(t, 4, provider.calls)
+	assert.Equal(t, breaker.StateOpen, b.State())
+
+	// удачная проба закрывает
+	clk.now = clk.now.Add(30 * time.Second)
+	provider.err = nil
+	coins, err := b.GetActualRates(ctx, []string{"BTC"})
+	require.NoError(t, err)
+	assert.Len(t, coins, 1)
+	assert.Equal(t, breaker.StateClosed, b.State())
+
+	state, err = b.Check(ctx)
+	assert.Equal(t, "closed", state)
+	assert.NoError(t, err)
+
+	families, err := reg.Gather()
+	require.NoError(t, err)
+	values := make(map[string]float64)
+	for _, family := range families {
+		for _, metric := range family.GetMetric() {
+			name := family.GetName()
+			for _, label := range metric.GetLabel() {
+				name += "|" + label.GetValue()
+			}
+			switch {
+			case metric.GetGauge() != nil:
+				values[name] = metric.GetGauge().GetValue()
+			case metric.GetCounter() != nil:
+				values[name] = metric.GetCounter().GetValue()
+			}
+		}
+	}
+	assert.Equal(t, 0.0, values["crypto_provider_circuit_state"])
+	assert.Equal(t, 2.0, values["crypto_provider_circuit_transitions_total|open"])
+	assert.Equal(t, 1.0, values["crypto_provider_circuit_transitions_total|closed"])
+	assert.Equal(t, 4.0, values["crypto_provider_calls_total|failure"])
+	assert.Equal(t, 1.0, values["crypto_provider_calls_total|rejected"])
+	assert.Equal(t, 1.0, values["crypto_provider_calls_total|success"])
+}
+
+func Test_Breaker_IgnoresClientErrors(t *testing.T) {
+	t.Parallel()
+
+	provider := &fakeProvider{err: errors.Wrap(entities.ErrUnknownSymbol, "market does not exist")}
+	b, err := breaker.New(provider, breaker.Config{FailureThreshold: 1}, nil)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	for i := 0; i < 3; i++ {
+		_, err = b.GetActualRates(ctx, []string{"FOO"})
+		assert.ErrorIs(t, err, entities.ErrUnknownSymbol)
+	}
+
+	provider.err = context.Canceled
+	_, err = b.GetHistory(ctx, "BTC", time.Time{}, time.Time{})
+	assert.ErrorIs(t, err, context.Canceled)
+
+	assert.Equal(t, breaker.StateClosed, b.State())
+	assert.Equal(t, 4, provider.calls)
+}
@@ -0,0 +1,62 @@
+package breaker
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	resultSuccess  = "success"
+	resultFailure  = "failure"
+	resultRejected = "rejected"
+	resultCanceled = "canceled"
+)
+
+// metrics — метрики автомата; nil, если реестр не передан
+type metrics struct {
+	reg         prometheus.Registerer
+	state       prometheus.Gauge
+	transitions *prometheus.CounterVec
+	calls       *prometheus.CounterVec
+}
+
+func newMetrics(reg prometheus.Registerer) *metrics {
+	return &metrics{
+		reg: reg,
+		state: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "crypto_provider_circuit_state",
+			Help: "State of the provider circuit breaker: 0 closed, 1 half-open, 2 open.",
+		}),
+		transitions: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "crypto_provider_circuit_transitions_total",
+			Help: "Number of provider circuit breaker state changes by the new state.",
+		}, []string{"state"}),
+		calls: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "crypto_provider_calls_total",
+			Help: "Number of provider calls by result: success, failure, rejected by the open circuit or canceled by the caller.",
+		}, []string{"result"}),
+	}
+}
+
+func (m *metrics) register() error {
+	for _, collector := range []prometheus.Collector{m.state, m.transitions, m.calls} {
+		if err := m.reg.Register(collector); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *metrics) observeState(state State) {
+	if m == nil {
+		return
+	}
+	m.state.Set(float64(state))
+	m.transitions.WithLabelValues(state.String()).Inc()
+}
+
+func (m *metrics) observeCall(result string) {
+	if m == nil {
+		return
+	}
+	m.calls.WithLabelValues(result).Inc()
+}
@@ -8,6 +8,8 @@ import (
 	"io"
 	"log/slog"
 	"net/http"
+	"net/url"
+	"sort"
 	"strings"
 	"time"
 
@@ -15,8 +17,10 @@ import (
 )
 
 const (
-	baseUrl     = "https://min-api.cryptocompare.com"
-	multivalues = "/data/pricemulti"
+	baseUrl          = "https://min-api.cryptocompare.com"
+	multivaluesFull  = "/data/pricemultifull"
+	sourceName       = "cryptocompare"
+	responseKindFail = "Error"
 
 	defaultPriceIn = "USD"
 	queryFsyms     = "fsyms"
@@ -30,6 +34,33 @@ type Client struct {
 	logger     *slog.Logger
 }
 
+// priceMultiFullResponse описывает нужную часть ответа pricemultifull:
+// RAW[монета][валюта] с ценой и временем последней сделки.
+type priceMultiFullResponse struct {
+	Response string                         `json:"Response"`
+	Message  string                         `json:"Message"`
+	Raw      map[string]map[string]rawQuote `json:"RAW"`
+}
+
+type rawQuote struct {
+	Price        float64 `json:"PRICE"`
+	LastUpdate   int64   `json:"LASTUPDATE"`
+	Volume24hTo  float64 `json:"VOLUME24HOURTO"`
+	High24h      float64 `json:"HIGH24HOUR"`
+	Low24h       float64 `json:"LOW24HOUR"`
+	ChangePct24h float64 `json:"CHANGEPCT24HOUR"`
+	MarketCap    float64 `json:"MKTCAP"`
+	Supply       float64 `json:"SUPPLY"`
+}
+
+// observedAt переводит LASTUPDATE (unix-секунды) во время; ноль означает, что провайдер его не прислал.
+func (q rawQuote) observedAt() time.Time {
+	if q.LastUpdate <= 0 {
+		return time.Time{}
+	}
+	return time.Unix(q.LastUpdate, 0).UTC()
+}
+
 type ClientOption func(client *Client)
 
 func WithPriceIn(priceIn string) ClientOption {
@@ -83,6 +114,73 @@ func (c *Client) GetActualRates(ctx context.Context, titles []string) ([]entitie
 		slog.Int("titles_count", len(titles)))
 	startTime := time.Now()
 
+	quotes, err := c.fetchQuotes(ctx, titles, logger)
+	if err != nil {
+		return nil, err
+	}
+
+	coins := make([]entities.Coin, 0, len(quotes))
+	for title, quote := range quotes {
+		coins = append(coins, entities.Coin{
+			CoinName:   title,
+			Price:      quote.Price,
+			Currency:   c.priceIn,
+			Source:     sourceName,
+			ObservedAt: quote.observedAt(),
+		})
+	}
+	sort.Slice(coins, func(i, j int) bool {
+		return coins[i].CoinName < coins[j].CoinName
+	})
+
+	logger.Info("Request completed successfully",
+		slog.Int("coins_received", len(coins)),
+		slog.Duration("duration", time.Since(startTime)))
+
+	return coins, nil
+}
+
+func (c *Client) GetTickers(ctx context.Context, titles []string) ([]entities.Ticker, error) {
+	const op = "cryptocompare.GetTickers"
+	logger := c.logger.With(
+		slog.String("op", op),
+		slog.Int("titles_count", len(titles)))
+	startTime := time.Now()
+
+	quotes, err := c.fetchQuotes(ctx, titles, logger)
+	if err != nil {
+		return nil, err
+	}
+
+	tickers := make([]entities.Ticker, 0, len(quotes))
+	for title, quote := range quotes {
+		tickers = append(tickers, entities.Ticker{
+			CoinName:     title,
+			Currency:     c.priceIn,
+			Source:       sourceName,
+			Price:        quote.Price,
+			Volume24h:    quote.Volume24hTo,
+			High24h:      quote.High24h,
+			Low24h:       quote.Low24h,
+			Change24hPct: quote.ChangePct24h,
+			MarketCap:    quote.MarketCap,
+			Supply:       quote.Supply,
+			ObservedAt:   quote.observedAt(),
+		})
+	}
+	sort.Slice(tickers, func(i, j int) bool {
+		return tickers[i].CoinName < tickers[j].CoinName
+	})
+
+	logger.Info("Request completed successfully",
+		slog.Int("tickers_received", len(tickers)),
+		slog.Duration("duration", time.Since(startTime)))
+
+	return tickers, nil
+}
+
+// fetchQuotes запрашивает pricemultifull и возвращает котировки монет в валюте клиента.
+func (c *Client) fetchQuotes(ctx context.Context, titles []string, logger *slog.Logger) (map[string]rawQuote, error) {
 	logger.Debug("Building API request")
 	if len(titles) == 0 {
 		err := errors.Wrap(entities.ErrInvalidParam, "titles list is empty")
@@ -90,30 +188,65 @@ func (c *Client) GetActualRates(ctx context.Context, titles []string) ([]entitie
 		return nil, err
 	}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("%s%s", baseUrl, multivalues), nil)
+	query := url.Values{}
+	query.Add(queryTsyms, c.priceIn)
+	query.Add(queryFsyms, strings.Join(titles, ","))
+
+	logger.Debug("Sending request",
+		slog.String("symbols", strings.Join(titles, ",")),
+		slog.String("target_currency", c.priceIn))
+
+	var result priceMultiFullResponse
+	if err := c.getJSON(ctx, multivaluesFull, query, &result, logger); err != nil {
+		return nil, err
+	}
+
+	if result.Response == responseKindFail {
+		logger.Error("API returned error",
+			slog.String("message", result.Message))
+		return nil, apiError(result.Message)
+	}
+
+	if len(result.Raw) == 0 {
+		return nil, errors.Wrap(entities.ErrUnknownSymbol, "empty response from API")
+	}
+
+	quotes := make(map[string]rawQuote, len(result.Raw))
+	for title, byCurrency := range result.Raw {
+		quote, ok := byCurrency[c.priceIn]
+		if !ok {
+			logger.Warn("Quote currency missing in response",
+				slog.String("coin", title))
+			continue
+		}
+		quotes[title] = quote
+	}
+	return quotes, nil
+}
+
+// getJSON выполняет GET-запрос к API и декодирует ответ в out.
+func (c *Client) getJSON(ctx context.Context, path string, query url.Values, out any, logger *slog.Logger) error {
+	startTime := time.Now()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, baseUrl+path, nil)
 	if err != nil {
 		logger.Error("Request creation failed",
 			slog.String("error", err.Error()),
-			slog.String("url", baseUrl+multivalues))
-		return nil, errors.Wrapf(entities.ErrInternal, "new request error: %v", err)
+			slog.String("url", baseUrl+path))
+		return errors.Wrapf(entities.ErrInternal, "new request error: %v", err)
 	}
-
-	q := req.URL.Query()
-	q.Add(queryTsyms, c.priceIn)
-	q.Add(queryFsyms, strings.Join(titles, ","))
-	req.URL.RawQuery = q.Encode()
+	req.URL.RawQuery = query.Encode()
 	req.Header.Set("Authorization", fmt.Sprintf("Apikey %s", c.apiKey))
 
-	logger.Debug("Sending request",
-		slog.String("symbols", strings.Join(titles, ",")),
-		slog.String("target_currency", c.priceIn))
-
 	resp, err := c.HttpClient.Do(req)
 	if err != nil {
 		logger.Error("Request failed",
 			slog.String("error", err.Error()),
 			slog.Duration("duration", time.Since(startTime)))
-		return nil, errors.Wrapf(entities.ErrInternal, "execute request failure: %v", err)
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return errors.Wrap(ctxErr, "execute request failure")
+		}
+		return errors.Wrapf(entities.ErrProviderUnavailable, "execute request failure: %v", err)
 	}
 	defer resp.Body.Close()
 
@@ -126,37 +259,38 @@ func (c *Client) GetActualRates(ctx context.Context, titles []string) ([]entitie
 		logger.Error("API returned error",
 			slog.Int("status_code", resp.StatusCode),
 			slog.String("response", string(body)))
-		return nil, errors.Wrapf(entities.ErrInvalidParam, "unexpected status code: %d", resp.StatusCode)
+		return errors.Wrapf(statusError(resp.StatusCode), "unexpected status code: %d", resp.StatusCode)
 	}
 
-	var result map[string]map[string]float64
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
+	if err = json.NewDecoder(resp.Body).Decode(out); err != nil {
 		logger.Error("Response parsing failed",
 			slog.String("error", err.Error()))
-		return nil, errors.Wrapf(entities.ErrInternal, "readAll resp Body: %v", err)
-	}
-
-	err = json.Unmarshal(body, &result)
-	if err != nil {
-		return nil, errors.Wrapf(entities.ErrInternal, "unmarshal body error :%v", err)
+		return errors.Wrapf(entities.ErrProviderError, "failed to decode response: %v", err)
 	}
+	return nil
+}
 
-	if len(result) == 0 {
-		return nil, errors.Wrap(entities.ErrNotFound, "empty response from API")
+// statusError классифицирует неуспешный HTTP-статус ответа провайдера.
+func statusError(code int) error {
+	switch {
+	case code == http.StatusTooManyRequests:
+		return entities.ErrRateLimited
+	case code >= http.StatusInternalServerError:
+		return entities.ErrProviderUnavailable
+	default:
+		return entities.ErrProviderError
 	}
+}
 
-	var coins []entities.Coin
-	for title, rates := range result {
-		coins = append(coins, entities.Coin{
-			CoinName: title,
-			Price:    rates[c.priceIn],
-		})
+// apiError классифицирует ошибку, которую API вернуло в теле успешного ответа.
+func apiError(message string) error {
+	lower := strings.ToLower(message)
+	switch {
+	case strings.Contains(lower, "does not exist"):
+		return errors.Wrapf(entities.ErrUnknownSymbol, "api error: %s", message)
+	case strings.Contains(lower, "rate limit"):
+		return errors.Wrapf(entities.ErrRateLimited, "api error: %s", message)
+	default:
+		return errors.Wrapf(entities.ErrProviderError, "api error: %s", message)
 	}
-
-	logger.Info("Request completed successfully",
-		slog.Int("coins_received", len(coins)),
-		slog.Duration("duration", time.Since(startTime)))
-
-	return coins, nil
 }
@@ -6,6 +6,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
@@ -19,7 +20,7 @@ func Test_NewClient_Success(t *testing.T) {
 	t.Parallel()
 
 	apiKey := "test-api-key"
-	client, err := cryptocompare.NewClient(apiKey)
+	client, err := cryptocompare.NewClient(apiKey, nil)
 	require.NoError(t, err)
 	require.NotNil(t, client)
 	require.NotNil(t, client.HttpClient)
@@ -28,7 +29,7 @@ func Test_NewClient_Success(t *testing.T) {
 func Test_NewClient_Error(t *testing.T) {
 	t.Parallel()
 
-	client, err := cryptocompare.NewClient("")
+	client, err := cryptocompare.NewClient("", nil)
 	require.Error(t, err)
 	assert.True(t, errors.Is(err, entities.ErrInvalidParam))
 	require.Nil(t, client)
@@ -46,20 +47,22 @@ func (m *mockTransport) RoundTrip(req *http.Request) (*http.Response, error) {
 func Test_GetActualRates_Success(t *testing.T) {
 	t.Parallel()
 
-	client, err := cryptocompare.NewClient("test-api-key")
+	client, err := cryptocompare.NewClient("test-api-key", nil)
 	require.NoError(t, err)
 
 	// Setup mock transport
 	client.HttpClient.Transport = &mockTransport{
 		roundTrip: func(req *http.Request) (*http.Response, error) {
-			require.Equal(t, "/data/pricemulti", req.URL.Path)
+			require.Equal(t, "/data/pricemultifull", req.URL.Path)
 			require.Equal(t, "BTC,ETH", req.URL.Query().Get("fsyms"))
 			require.Equal(t, "USD", req.URL.Query().Get("tsyms"))
 			require.Equal(t, "Apikey test-api-key", req.Header.Get("Authorization"))
 
-			response := map[string]map[string]float64{
-				"BTC": {"USD": 50000.5},
-				"ETH": {"USD": 3000.75},
+			response := map[string]any{
+				"RAW": map[string]map[string]map[string]any{
+					"ETH": {"USD": {"PRICE": 3000.75, "LASTUPDATE": 1735732800}},
+					"BTC": {"USD": {"PRICE": 50000.5, "LASTUPDATE": 1735732795}},
+				},
 			}
 
 			w := httptest.NewRecorder()
@@ -76,9 +79,13 @@ func Test_GetActualRates_Success(t *testing.T) {
 
 	assert.Equal(t, "BTC", coins[0].CoinName)
 	assert.Equal(t, 50000.5, coins[0].Price)
+	assert.Equal(t, "USD", coins[0].Currency)
+	assert.Equal(t, "cryptocompare", coins[0].Source)
+	assert.Equal(t, time.Date(2025, 1, 1, 11, 59, 55, 0, time.UTC), coins[0].ObservedAt)
 
 	assert.Equal(t, "ETH", coins[1].CoinName)
 	assert.Equal(t, 3000.75, coins[1].Price)
+	assert.Equal(t, time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC), coins[1].ObservedAt)
 }
 
 func Test_GetActualRates_ErrorCases(t *testing.T) {
@@ -92,14 +99,57 @@ func Test_GetActualRates_ErrorCases(t *testing.T) {
 		expectedError error
 		errorContains string
 	}{
-		// ... другие тест-кейсы остаются без изменений ...
+		{
+			name:          "unexpected status",
+			titles:        []string{"BTC"},
+			mockStatus:    http.StatusBadGateway,
+			expectedError: entities.ErrProviderUnavailable,
+			errorContains: "unexpected status code: 502",
+		},
+		{
+			name:   "api error",
+			titles: []string{"XXX"},
+			mockResponse: map[string]any{
+				"Response": "Error",
+				"Message":  "cccagg_or_exchange market does not exist for this coin pair",
+			},
+			mockStatus:    http.StatusOK,
+			expectedError: entities.ErrUnknownSymbol,
+			errorContains: "market does not exist",
+		},
+		{
+			name:   "api rate limit",
+			titles: []string{"BTC"},
+			mockResponse: map[string]any{
+				"Response": "Error",
+				"Message":  "You are over your rate limit please upgrade your account!",
+			},
+			mockStatus:    http.StatusOK,
+			expectedError: entities.ErrRateLimited,
+			errorContains: "rate limit",
+		},
+		{
+			name:          "too many requests",
+			titles:        []string{"BTC"},
+			mockStatus:    http.StatusTooManyRequests,
+			expectedError: entities.ErrRateLimited,
+			errorContains: "unexpected status code: 429",
+		},
+		{
+			name:          "empty raw section",
+			titles:        []string{"BTC"},
+			mockResponse:  map[string]any{"RAW": map[string]any{}},
+			mockStatus:    http.StatusOK,
+			expectedError: entities.ErrNotFound,
+			errorContains: "empty response from API",
+		},
 		{
 			name:          "invalid json response",
 			titles:        []string{"BTC"},
 			mockResponse:  "invalid json",
 			mockStatus:    http.StatusOK,
-			expectedError: entities.ErrInternal,
-			errorContains: "failed to decode response",
+			expectedError: entities.ErrProviderError,
+			errorContains: "failed to decode response: invalid character",
 		},
 	}
 
@@ -108,7 +158,7 @@ func Test_GetActualRates_ErrorCases(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			t.Parallel()
 
-			client, err := cryptocompare.NewClient("test-api-key")
+			client, err := cryptocompare.NewClient("test-api-key", nil)
 			require.NoError(t, err)
 
 			client.HttpClient.Transport = &mockTransport{
@@ -129,35 +179,11 @@ func Test_GetActualRates_ErrorCases(t *testing.T) {
 
 			coins, err := client.GetActualRates(context.Background(), tc.titles)
 
-			if tc.name == "invalid json response" {
-				// Специальная обработка для случая с невалидным JSON
-				require.Error(t, err)
-				assert.Nil(t, coins)
-				assert.Contains(t, err.Error(), tc.errorContains)
-
-				// Проверяем, что это ошибка декодирования
-				var jsonErr *json.SyntaxError
-				if errors.As(err, &jsonErr) {
-					// Это ожидаемая ошибка синтаксиса JSON
-					return
-				}
-
-				// Дополнительная проверка для других типов ошибок декодирования
-				var unmarshalErr *json.UnmarshalTypeError
-				if errors.As(err, &unmarshalErr) {
-					return
-				}
-
-				// Если ошибка не связана с декодированием JSON, проваливаем тест
-				t.Errorf("expected JSON decoding error, got %T", err)
-			} else {
-				// Стандартная проверка для других случаев
-				require.Error(t, err)
-				assert.Nil(t, coins)
-				assert.True(t, errors.Is(err, tc.expectedError),
-					"expected error %v, got %v", tc.expectedError, err)
-				assert.Contains(t, err.Error(), tc.errorContains)
-			}
+			require.Error(t, err)
+			assert.Nil(t, coins)
+			assert.True(t, errors.Is(err, tc.expectedError),
+				"expected error %v, got %v", tc.expectedError, err)
+			assert.Contains(t, err.Error(), tc.errorContains)
 		})
 	}
 }
@@ -165,7 +191,7 @@ func Test_GetActualRates_ErrorCases(t *testing.T) {
 func Test_WithPriceIn_Option(t *testing.T) {
 	t.Parallel()
 
-	client, err := cryptocompare.NewClient("test-api-key", cryptocompare.WithPriceIn("EUR"))
+	client, err := cryptocompare.NewClient("test-api-key", nil, cryptocompare.WithPriceIn("EUR"))
 	require.NoError(t, err)
 
 	client.HttpClient.Transport = &mockTransport{
@@ -173,8 +199,10 @@ func Test_WithPriceIn_Option(t *testing.T) {
 			require.Equal(t, "EUR", req.URL.Query().Get("tsyms"))
 			w := httptest.NewRecorder()
 			w.WriteHeader(http.StatusOK)
-			json.NewEncoder(w).Encode(map[string]map[string]float64{
-				"BTC": {"EUR": 45000.0},
+			json.NewEncoder(w).Encode(map[string]any{
+				"RAW": map[string]map[string]map[string]any{
+					"BTC": {"EUR": {"PRICE": 45000.0, "LASTUPDATE": 1735732800}},
+				},
 			})
 			return w.Result(), nil
 		},
@@ -185,4 +213,98 @@ func Test_WithPriceIn_Option(t *testing.T) {
 	require.Len(t, coins, 1)
 	assert.Equal(t, "BTC", coins[0].CoinName)
 	assert.Equal(t, 45000.0, coins[0].Price)
+	assert.Equal(t, "EUR", coins[0].Currency)
+}
+
+func Test_GetTickers_Success(t *testing.T) {
+	t.Parallel()
+
+	client, err := cryptocompare.NewClient("test-api-key", nil)
+	require.NoError(t, err)
+
+	client.HttpClient.Transport = &mockTransport{
+		roundTrip: func(req *http.Request) (*http.Response, error) {
+			require.Equal(t, "/data/pricemultifull", req.URL.Path)
+			require.Equal(t, "BTC", req.URL.Query().Get("fsyms"))
+
+			w := httptest.NewRecorder()
+			w.WriteHeader(http.StatusOK)
+			json.NewEncoder(w).Encode(map[string]any{
+				"RAW": map[string]map[string]map[string]any{
+					"BTC": {"USD": {
+						"PRICE":           50000.5,
+						"LASTUPDATE":      1735732800,
+						"VOLUME24HOURTO":  1.2e9,
+						"HIGH24HOUR":      51000.0,
+						"LOW24HOUR":       48000.0,
+						"CHANGEPCT24HOUR": 2.5,
+						"MKTCAP":          9.8e11,
+						"SUPPLY":          19600000.0,
+					}},
+				},
+			})
+			return w.Result(), nil
+		},
+	}
+
+	tickers, err := client.GetTickers(context.Background(), []string{"BTC"})
+	require.NoError(t, err)
+	require.Len(t, tickers, 1)
+
+	assert.Equal(t, entities.Ticker{
+		CoinName:     "BTC",
+		Currency:     "USD",
+		Source:       "cryptocompare",
+		Price:        50000.5,
+		Volume24h:    1.2e9,
+		High24h:      51000,
+		Low24h:       48000,
+		Change24hPct: 2.5,
+		MarketCap:    9.8e11,
+		Supply:       19600000,
+		ObservedAt:   time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC),
+	}, tickers[0])
+}
+
+func Test_GetHistory_SkipsUnlistedHours(t *testing.T) {
+	t.Parallel()
+
+	client, err := cryptocompare.NewClient("test-api-key", nil)
+	require.NoError(t, err)
+
+	from := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := from.Add(3 * time.Hour)
+	client.HttpClient.Transport = &mockTransport{
+		roundTrip: func(req *http.Request) (*http.Response, error) {
+			require.Equal(t, "/data/v2/histohour", req.URL.Path)
+			require.Equal(t, "NEW", req.URL.Query().Get("fsym"))
+			require.Equal(t, "3", req.URL.Query().Get("limit"))
+			require.Equal(t, "1735700400", req.URL.Query().Get("toTs"))
+
+			point := func(at time.Time, price float64) map[string]any {
+				return map[string]any{"time": at.Unix(), "open": price, "high": price, "low": price, "close": price}
+			}
+			response := map[string]any{
+				"Response": "Success",
+				"Data": map[string]any{"Data": []any{
+					point(from, 0),
+					point(from.Add(time.Hour), 10),
+					point(from.Add(2*time.Hour), 12),
+					point(from.Add(3*time.Hour), 11),
+				}},
+			}
+
+			w := httptest.NewRecorder()
+			w.WriteHeader(http.StatusOK)
+			json.NewEncoder(w).Encode(response)
+			return w.Result(), nil
+		},
+	}
+
+	candles, err := client.GetHistory(context.Background(), "NEW", from, to)
+	require.NoError(t, err)
+	require.Len(t, candles, 3)
+	assert.Equal(t, from.Add(time.Hour), candles[0].Bucket)
+	assert.Equal(t, 10.0, candles[0].Close)
+	assert.Equal(t, 11.0, candles[2].Close)
 }
@@ -0,0 +1,114 @@
+package cryptocompare
+
+import (
+	"context"
+	"log/slog"
+	"net/url"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"Cryptoproject/internal/entities"
+)
+
+const (
+	histoHour = "/data/v2/histohour"
+	// maxHistoryPoints — максимум часов в одном ответе histohour
+	maxHistoryPoints = 2000
+)
+
+type histoResponse struct {
+	Response string `json:"Response"`
+	Message  string `json:"Message"`
+	Data     struct {
+		Data []histoPoint `json:"Data"`
+	} `json:"Data"`
+}
+
+type histoPoint struct {
+	Time       int64   `json:"time"`
+	Open       float64 `json:"open"`
+	High       float64 `json:"high"`
+	Low        float64 `json:"low"`
+	Close      float64 `json:"close"`
+	VolumeFrom float64 `json:"volumefrom"`
+}
+
+// GetHistory returns hourly candles of title in the client currency for
+// [from, to], oldest first. Hours before the coin was listed are skipped.
+func (c *Client) GetHistory(ctx context.Context, title string, from, to time.Time) ([]entities.Candle, error) {
+	const op = "cryptocompare.GetHistory"
+	logger := c.logger.With(
+		slog.String("op", op),
+		slog.String("title", title),
+		slog.Time("from", from),
+		slog.Time("to", to))
+	startTime := time.Now()
+
+	if title == "" || !from.Before(to) {
+		return nil, errors.Wrap(entities.ErrInvalidParam, "title and a non-empty time range are required")
+	}
+
+	from = from.UTC().Truncate(time.Hour)
+	var candles []entities.Candle
+	// histohour отдаёт limit+1 часов, заканчивая toTs, поэтому идём страницами от конца диапазона
+	for pageEnd := to.UTC().Truncate(time.Hour); !pageEnd.Before(from); {
+		limit := min(int(pageEnd.Sub(from)/time.Hour), maxHistoryPoints)
+
+		query := url.Values{}
+		query.Add("fsym", title)
+		query.Add("tsym", c.priceIn)
+		query.Add("limit", strconv.Itoa(max(limit, 1)))
+		query.Add("toTs", strconv.FormatInt(pageEnd.Unix(), 10))
+
+		var result histoResponse
+		if err := c.getJSON(ctx, histoHour, query, &result, logger); err != nil {
+			return nil, err
+		}
+		if result.Response == responseKindFail {
+			logger.Error("API returned error",
+				slog.String("message", result.Message))
+			return nil, apiError(result.Message)
+		}
+
+		page := make([]entities.Candle, 0, len(result.Data.Data))
+		listed := true
+		for _, point := range result.Data.Data {
+			bucket := time.Unix(point.Time, 0).UTC()
+			if bucket.Before(from) || bucket.After(pageEnd) {
+				continue
+			}
+			// до листинга монеты API возвращает нули
+			if point.Close <= 0 {
+				listed = false
+				continue
+			}
+			page = append(page, entities.Candle{
+				Bucket: bucket,
+				Open:   point.Open,
+				High:   point.High,
+				Low:    point.Low,
+				Close:  point.Close,
+				Avg:    (point.Open + point.Close) / 2,
+				Count:  1,
+			})
+		}
+		candles = append(page, candles...)
+
+		if !listed || len(result.Data.Data) == 0 {
+			break
+		}
+		pageEnd = pageEnd.Add(-time.Duration(limit+1) * time.Hour)
+	}
+
+	candles = slices.CompactFunc(candles, func(a, b entities.Candle) bool {
+		return a.Bucket.Equal(b.Bucket)
+	})
+
+	logger.Info("History received",
+		slog.Int("candles_count", len(candles)),
+		slog.Duration("duration", time.Since(startTime)))
+	return candles, nil
+}
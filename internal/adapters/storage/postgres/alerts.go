@@ -0,0 +1,98 @@
+package postgres
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"Cryptoproject/internal/entities"
+)
+
+func (s *Storage) StoreAlertEvent(ctx context.Context, event entities.AlertEvent) (*entities.AlertEvent, error) {
+	const op = "postgres.StoreAlertEvent"
+	logger := s.logger.With(
+		slog.String("op", op),
+		slog.String("kind", string(event.Kind)),
+		slog.String("coin", event.CoinName),
+	)
+
+	if err := s.db.QueryRow(ctx, `
+        INSERT INTO alert_events (kind, coin_name, price, value, threshold, message)
+        VALUES ($1, $2, $3, $4, $5, $6)
+        RETURNING id, created_at
+    `, string(event.Kind), event.CoinName, event.Price, event.Value, event.Threshold, event.Message).
+		Scan(&event.ID, &event.CreatedAt); err != nil {
+		logger.Error("Insert failed", slog.String("error", err.Error()))
+		return nil, errors.Wrap(entities.ErrInternal, "failed to store alert event")
+	}
+
+	logger.Info("Alert event stored", slog.Int64("id", event.ID))
+	return &event, nil
+}
+
+func (s *Storage) GetAlertEvents(ctx context.Context, filter entities.AlertFilter) ([]entities.AlertEvent, error) {
+	const op = "postgres.GetAlertEvents"
+	logger := s.logger.With(
+		slog.String("op", op),
+		slog.String("coin", filter.CoinName),
+	)
+	startTime := time.Now()
+
+	kinds := make([]string, 0, len(filter.Kinds))
+	for _, kind := range filter.Kinds {
+		kinds = append(kinds, string(kind))
+	}
+	var since *time.Time
+	if !filter.Since.IsZero() {
+		since = &filter.Since
+	}
+	var limit *int
+	if filter.Limit > 0 {
+		limit = &filter.Limit
+	}
+
+	rows, err := s.db.Query(ctx, `
+        SELECT id, kind, coin_name, price, value, threshold, message, created_at
+        FROM alert_events
+        WHERE ($1 = '' OR coin_name = $1)
+          AND (COALESCE(cardinality($2::text[]), 0) = 0 OR kind = ANY($2))
+          AND ($3::timestamptz IS NULL OR created_at >= $3)
+        ORDER BY created_at DESC, id DESC
+        LIMIT $4
+    `, filter.CoinName, kinds, since, limit)
+	if err != nil {
+		logger.Error("Query failed",
+			slog.String("error", err.Error()),
+			slog.Duration("duration", time.Since(startTime)))
+		return nil, errors.Wrap(entities.ErrInternal, "failed to query alert events")
+	}
+	defer rows.Close()
+
+	var events []entities.AlertEvent
+	for rows.Next() {
+		var (
+			event entities.AlertEvent
+			kind  string
+		)
+		if err = rows.Scan(&event.ID, &kind, &event.CoinName, &event.Price, &event.Value,
+			&event.Threshold, &event.Message, &event.CreatedAt); err != nil {
+			logger.Error("Row scan failed",
+				slog.String("error", err.Error()))
+			return nil, errors.Wrap(entities.ErrInternal, "failed to query alert events")
+		}
+		event.Kind = entities.AlertKind(kind)
+		events = append(events, event)
+	}
+	if err = rows.Err(); err != nil {
+		logger.Error("Rows iteration failed",
+			slog.String("error", err.Error()))
+		return nil, errors.Wrap(entities.ErrInternal, "failed to query alert events")
+	}
+
+	logger.Debug("Alert events retrieved",
+		slog.Int("count", len(events)),
+		slog.Duration("duration", time.Since(startTime)))
+	return events, nil
+}
@@ -0,0 +1,127 @@
+package postgres
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/pkg/errors"
+
+	"Cryptoproject/internal/entities"
+)
+
+func (s *Storage) BackfillCandles(ctx context.Context, title, currency string, candles []entities.Candle) (int64, error) {
+	const op = "postgres.BackfillCandles"
+	logger := s.logger.With(
+		slog.String("op", op),
+		slog.String("title", title),
+		slog.Int("candles_count", len(candles)),
+	)
+	startTime := time.Now()
+
+	if len(candles) == 0 {
+		return 0, nil
+	}
+	if currency == "" {
+		currency = defaultCurrency
+	}
+
+	var inserted int64
+	// свечи раскладываются по уровням так же, как их видит coins_history:
+	// после raw_watermark — сырые строки, до него — часовые, до daily_watermark — дневные
+	err := pgx.BeginFunc(ctx, s.db, func(tx pgx.Tx) error {
+		if _, err := tx.Exec(ctx, `
+            CREATE TEMP TABLE backfill_staging (
+                bucket TIMESTAMPTZ,
+                open NUMERIC,
+                high NUMERIC,
+                low NUMERIC,
+                close NUMERIC,
+                avg NUMERIC,
+                count BIGINT
+            ) ON COMMIT DROP
+        `); err != nil {
+			return errors.Wrap(err, "create staging table")
+		}
+
+		if _, err := tx.CopyFrom(ctx,
+			pgx.Identifier{"backfill_staging"},
+			[]string{"bucket", "open", "high", "low", "close", "avg", "count"},
+			pgx.CopyFromSlice(len(candles), func(i int) ([]any, error) {
+				candle := candles[i]
+				return []any{candle.Bucket.UTC(), candle.Open, candle.High, candle.Low,
+					candle.Close, candle.Avg, max(candle.Count, 1)}, nil
+			}),
+		); err != nil {
+			return errors.Wrap(err, "copy candles")
+		}
+
+		var rawWatermark, dailyWatermark time.Time
+		if err := tx.QueryRow(ctx, `
+            SELECT GREATEST(raw_watermark, 'epoch'), GREATEST(daily_watermark, 'epoch')
+            FROM coins_retention_state
+        `).Scan(&rawWatermark, &dailyWatermark); err != nil {
+			return errors.Wrap(err, "read retention state")
+		}
+
+		statements := []struct {
+			name string
+			sql  string
+			args []any
+		}{
+			{"insert raw coins", `
+                INSERT INTO coins (coin_name, currency, source, price, observed_at)
+                SELECT $1, $2, $3, close, bucket FROM backfill_staging
+                WHERE bucket >= $4
+                ON CONFLICT (coin_name, currency, source, observed_at) DO NOTHING
+            `, []any{title, currency, defaultSource, rawWatermark}},
+			{"insert hourly buckets", `
+                INSERT INTO coins_hourly (coin_name, bucket, open, high, low, close, avg, count)
+                SELECT $1, bucket, open, high, low, close, avg, count FROM backfill_staging
+                WHERE bucket >= $2 AND bucket < $3
+                ON CONFLICT (coin_name, bucket) DO NOTHING
+            `, []any{title, dailyWatermark, rawWatermark}},
+			{"insert daily buckets", `
+                INSERT INTO coins_daily (coin_name, bucket, open, high, low, close, avg, count)
+                SELECT $1,
+                       date_trunc('day', bucket AT TIME ZONE 'UTC') AT TIME ZONE 'UTC' AS day,
+                       (array_agg(open ORDER BY bucket))[1],
+                       MAX(high),
+                       MIN(low),
+                       (array_agg(close ORDER BY bucket DESC))[1],
+                       SUM(avg * count) / SUM(count),
+                       SUM(count)
+                FROM backfill_staging
+                WHERE bucket < $2
+                GROUP BY day
+                ON CONFLICT (coin_name, bucket) DO NOTHING
+            `, []any{title, dailyWatermark}},
+		}
+		for _, statement := range statements {
+			tag, err := tx.Exec(ctx, statement.sql, statement.args...)
+			if err != nil {
+				return errors.Wrap(err, statement.name)
+			}
+			inserted += tag.RowsAffected()
+		}
+
+		if _, err := tx.Exec(ctx, `
+            INSERT INTO watchlist (coin_name) VALUES ($1) ON CONFLICT (coin_name) DO NOTHING
+        `, title); err != nil {
+			return errors.Wrap(err, "update watchlist")
+		}
+		return nil
+	})
+	if err != nil {
+		logger.Error("Backfill failed",
+			slog.String("error", err.Error()),
+			slog.Duration("duration", time.Since(startTime)))
+		return 0, errors.Wrap(entities.ErrInternal, "failed to backfill candles")
+	}
+
+	logger.Info("Candles backfilled",
+		slog.Int64("inserted", inserted),
+		slog.Duration("duration", time.Since(startTime)))
+	return inserted, nil
+}
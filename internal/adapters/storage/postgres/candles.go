@@ -0,0 +1,183 @@
+package postgres
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"Cryptoproject/internal/entities"
+)
+
+func (s *Storage) GetCandles(ctx context.Context, title string, interval time.Duration, since time.Time) ([]entities.Candle, error) {
+	const op = "postgres.GetCandles"
+	logger := s.logger.With(
+		slog.String("op", op),
+		slog.String("title", title),
+		slog.Duration("interval", interval),
+		slog.Time("since", since),
+	)
+	startTime := time.Now()
+
+	// coins_history склеивает сырые цены с часовыми и дневными агрегатами,
+	// так что свечи строятся и по периоду, уже прошедшему даунсемплинг
+	rows, err := s.db.Query(ctx, `
+        SELECT date_bin(make_interval(secs => $2), bucket, TIMESTAMPTZ 'epoch') AS candle,
+               (array_agg(open ORDER BY bucket))[1],
+               MAX(high),
+               MIN(low),
+               (array_agg(close ORDER BY bucket DESC))[1],
+               SUM(avg * count) / NULLIF(SUM(count), 0),
+               SUM(count)
+        FROM coins_history
+        WHERE coin_name = $1 AND bucket >= $3
+        GROUP BY candle
+        ORDER BY candle
+    `, title, interval.Seconds(), since)
+	if err != nil {
+		logger.Error("Query failed",
+			slog.String("error", err.Error()),
+			slog.Duration("duration", time.Since(startTime)))
+		return nil, errors.Wrap(entities.ErrInternal, "failed to query candles")
+	}
+	defer rows.Close()
+
+	var candles []entities.Candle
+	for rows.Next() {
+		var candle entities.Candle
+		if err = rows.Scan(&candle.Bucket, &candle.Open, &candle.High, &candle.Low,
+			&candle.Close, &candle.Avg, &candle.Count); err != nil {
+			logger.Error("Row scan failed",
+				slog.String("error", err.Error()))
+			return nil, errors.Wrap(entities.ErrInternal, "failed to query candles")
+		}
+		candles = append(candles, candle)
+	}
+	if err = rows.Err(); err != nil {
+		logger.Error("Rows iteration failed",
+			slog.String("error", err.Error()))
+		return nil, errors.Wrap(entities.ErrInternal, "failed to query candles")
+	}
+
+	logger.Info("Candles retrieved",
+		slog.Int("count", len(candles)),
+		slog.Duration("duration", time.Since(startTime)))
+	return candles, nil
+}
+
+func (s *Storage) GetCandlesBatch(ctx context.Context, titles []string, interval time.Duration, since time.Time) (map[string][]entities.Candle, error) {
+	const op = "postgres.GetCandlesBatch"
+	logger := s.logger.With(
+		slog.String("op", op),
+		slog.Int("titles_count", len(titles)),
+		slog.Duration("interval", interval),
+		slog.Time("since", since),
+	)
+	startTime := time.Now()
+
+	rows, err := s.db.Query(ctx, `
+        SELECT coin_name,
+               date_bin(make_interval(secs => $2), bucket, TIMESTAMPTZ 'epoch') AS candle,
+               (array_agg(open ORDER BY bucket))[1],
+               MAX(high),
+               MIN(low),
+               (array_agg(close ORDER BY bucket DESC))[1],
+               SUM(avg * count) / NULLIF(SUM(count), 0),
+               SUM(count)
+        FROM coins_history
+        WHERE coin_name = ANY($1) AND bucket >= $3
+        GROUP BY coin_name, candle
+        ORDER BY coin_name, candle
+    `, titles, interval.Seconds(), since)
+	if err != nil {
+		logger.Error("Query failed",
+			slog.String("error", err.Error()),
+			slog.Duration("duration", time.Since(startTime)))
+		return nil, errors.Wrap(entities.ErrInternal, "failed to query candles")
+	}
+	defer rows.Close()
+
+	candles := make(map[string][]entities.Candle, len(titles))
+	var count int
+	for rows.Next() {
+		var (
+			title  string
+			candle entities.Candle
+		)
+		if err = rows.Scan(&title, &candle.Bucket, &candle.Open, &candle.High, &candle.Low,
+			&candle.Close, &candle.Avg, &candle.Count); err != nil {
+			logger.Error("Row scan failed",
+				slog.String("error", err.Error()))
+			return nil, errors.Wrap(entities.ErrInternal, "failed to query candles")
+		}
+		candles[title] = append(candles[title], candle)
+		count++
+	}
+	if err = rows.Err(); err != nil {
+		logger.Error("Rows iteration failed",
+			slog.String("error", err.Error()))
+		return nil, errors.Wrap(entities.ErrInternal, "failed to query candles")
+	}
+
+	logger.Info("Candles retrieved",
+		slog.Int("count", count),
+		slog.Duration("duration", time.Since(startTime)))
+	return candles, nil
+}
+
+func (s *Storage) GetSummaries(ctx context.Context, titles []string, since time.Time) (map[string]entities.Candle, error) {
+	const op = "postgres.GetSummaries"
+	logger := s.logger.With(
+		slog.String("op", op),
+		slog.Int("titles_count", len(titles)),
+		slog.Time("since", since),
+	)
+	startTime := time.Now()
+
+	rows, err := s.db.Query(ctx, `
+        SELECT coin_name,
+               MIN(bucket),
+               (array_agg(open ORDER BY bucket))[1],
+               MAX(high),
+               MIN(low),
+               (array_agg(close ORDER BY bucket DESC))[1],
+               SUM(avg * count) / NULLIF(SUM(count), 0),
+               SUM(count)
+        FROM coins_history
+        WHERE coin_name = ANY($1) AND bucket >= $2
+        GROUP BY coin_name
+    `, titles, since)
+	if err != nil {
+		logger.Error("Query failed",
+			slog.String("error", err.Error()),
+			slog.Duration("duration", time.Since(startTime)))
+		return nil, errors.Wrap(entities.ErrInternal, "failed to query summaries")
+	}
+	defer rows.Close()
+
+	summaries := make(map[string]entities.Candle, len(titles))
+	for rows.Next() {
+		var (
+			title   string
+			summary entities.Candle
+		)
+		if err = rows.Scan(&title, &summary.Bucket, &summary.Open, &summary.High, &summary.Low,
+			&summary.Close, &summary.Avg, &summary.Count); err != nil {
+			logger.Error("Row scan failed",
+				slog.String("error", err.Error()))
+			return nil, errors.Wrap(entities.ErrInternal, "failed to query summaries")
+		}
+		summaries[title] = summary
+	}
+	if err = rows.Err(); err != nil {
+		logger.Error("Rows iteration failed",
+			slog.String("error", err.Error()))
+		return nil, errors.Wrap(entities.ErrInternal, "failed to query summaries")
+	}
+
+	logger.Info("Summaries retrieved",
+		slog.Int("count", len(summaries)),
+		slog.Duration("duration", time.Since(startTime)))
+	return summaries, nil
+}
@@ -0,0 +1,92 @@
+package postgres
+
+import (
+	"context"
+	"log/slog"
+	"strconv"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/pkg/errors"
+
+	"Cryptoproject/internal/entities"
+)
+
+// exportFetchSize — сколько строк курсора читается за раз; память не зависит от объёма выгрузки.
+const exportFetchSize = 1000
+
+// StreamHistory reads coins_history through a server-side cursor and passes
+// each row to fn in coin and time order. An error from fn stops the export and is returned as is.
+func (s *Storage) StreamHistory(ctx context.Context, query entities.ExportQuery, fn func(entities.HistoryRecord) error) error {
+	const op = "postgres.StreamHistory"
+	logger := s.logger.With(
+		slog.String("op", op),
+		slog.Any("titles", query.Titles),
+		slog.Time("from", query.From),
+		slog.Time("to", query.To),
+	)
+	startTime := time.Now()
+
+	var callbackErr error
+	rowsCount := 0
+	err := pgx.BeginTxFunc(ctx, s.db, pgx.TxOptions{AccessMode: pgx.ReadOnly}, func(tx pgx.Tx) error {
+		if _, err := tx.Exec(ctx, `
+            DECLARE export_cursor NO SCROLL CURSOR FOR
+            SELECT coin_name, bucket, resolution, open, high, low, close, avg, count
+            FROM coins_history
+            WHERE coin_name = ANY($1) AND bucket >= $2 AND bucket < $3
+            ORDER BY coin_name, bucket
+        `, query.Titles, query.From, query.To); err != nil {
+			return errors.Wrap(err, "declare cursor")
+		}
+
+		for {
+			rows, err := tx.Query(ctx, "FETCH FORWARD "+strconv.Itoa(exportFetchSize)+" FROM export_cursor")
+			if err != nil {
+				return errors.Wrap(err, "fetch")
+			}
+
+			fetched := 0
+			for rows.Next() {
+				var record entities.HistoryRecord
+				if err = rows.Scan(&record.CoinName, &record.Time, &record.Resolution, &record.Open,
+					&record.High, &record.Low, &record.Close, &record.Avg, &record.Count); err != nil {
+					rows.Close()
+					return errors.Wrap(err, "scan")
+				}
+				fetched++
+				if callbackErr = fn(record); callbackErr != nil {
+					rows.Close()
+					return callbackErr
+				}
+			}
+			rows.Close()
+			if err = rows.Err(); err != nil {
+				return errors.Wrap(err, "rows")
+			}
+
+			rowsCount += fetched
+			if fetched < exportFetchSize {
+				return nil
+			}
+		}
+	})
+	if callbackErr != nil {
+		logger.Warn("Export stopped by consumer",
+			slog.Int("rows", rowsCount),
+			slog.String("error", callbackErr.Error()))
+		return callbackErr
+	}
+	if err != nil {
+		logger.Error("Export failed",
+			slog.String("error", err.Error()),
+			slog.Int("rows", rowsCount),
+			slog.Duration("duration", time.Since(startTime)))
+		return errors.Wrap(entities.ErrInternal, "failed to export history")
+	}
+
+	logger.Info("History exported",
+		slog.Int("rows", rowsCount),
+		slog.Duration("duration", time.Since(startTime)))
+	return nil
+}
@@ -0,0 +1,145 @@
+package postgres
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/pkg/errors"
+
+	"Cryptoproject/internal/entities"
+)
+
+// ImportCoins stores historical prices. Prices newer than the raw retention
+// watermark are stored as is; older ones are rolled up into hourly or daily
+// buckets, as retention would have done, so they are not pruned as stale raw
+// rows. Already stored prices and buckets are kept, which makes a re-run a no-op.
+func (s *Storage) ImportCoins(ctx context.Context, coins []entities.Coin) (entities.StoreResult, error) {
+	const op = "postgres.ImportCoins"
+	logger := s.logger.With(
+		slog.String("op", op),
+		slog.Int("coins_count", len(coins)),
+	)
+	startTime := time.Now()
+
+	if len(coins) == 0 {
+		return entities.StoreResult{}, nil
+	}
+
+	now := time.Now()
+	var inserted int64
+	err := pgx.BeginFunc(ctx, s.db, func(tx pgx.Tx) error {
+		if _, err := tx.Exec(ctx, `
+            CREATE TEMP TABLE import_staging (
+                coin_name VARCHAR(50),
+                currency VARCHAR(10),
+                source VARCHAR(50),
+                price NUMERIC(24, 10),
+                observed_at TIMESTAMPTZ
+            ) ON COMMIT DROP
+        `); err != nil {
+			return errors.Wrap(err, "create staging table")
+		}
+
+		if _, err := tx.CopyFrom(ctx,
+			pgx.Identifier{"import_staging"},
+			[]string{"coin_name", "currency", "source", "price", "observed_at"},
+			pgx.CopyFromSlice(len(coins), func(i int) ([]any, error) {
+				coin := coins[i]
+				return []any{coin.CoinName, coinCurrency(coin), coinSource(coin), coin.Price, observedAt(coin, now)}, nil
+			}),
+		); err != nil {
+			return errors.Wrap(err, "copy coins")
+		}
+
+		var rawWatermark, dailyWatermark time.Time
+		if err := tx.QueryRow(ctx, `
+            SELECT GREATEST(raw_watermark, 'epoch'), GREATEST(daily_watermark, 'epoch')
+            FROM coins_retention_state
+        `).Scan(&rawWatermark, &dailyWatermark); err != nil {
+			return errors.Wrap(err, "read retention state")
+		}
+
+		tag, err := tx.Exec(ctx, `
+            INSERT INTO coins (coin_name, currency, source, price, observed_at)
+            SELECT coin_name, currency, source, price, observed_at FROM import_staging
+            WHERE observed_at >= $1
+            ON CONFLICT (coin_name, currency, source, observed_at) DO NOTHING
+        `, rawWatermark)
+		if err != nil {
+			return errors.Wrap(err, "insert raw coins")
+		}
+		inserted = tag.RowsAffected()
+
+		// для агрегатов считаются цены, попавшие в новые корзины: count корзины — это число её цен
+		statements := []struct {
+			name string
+			sql  string
+			args []any
+		}{
+			{"insert hourly buckets", `
+                WITH inserted AS (
+                    INSERT INTO coins_hourly (coin_name, bucket, open, high, low, close, avg, count)
+                    SELECT coin_name,
+                           date_trunc('hour', observed_at AT TIME ZONE 'UTC') AT TIME ZONE 'UTC' AS hour,
+                           (array_agg(price ORDER BY observed_at))[1],
+                           MAX(price),
+                           MIN(price),
+                           (array_agg(price ORDER BY observed_at DESC))[1],
+                           AVG(price),
+                           COUNT(*)
+                    FROM import_staging
+                    WHERE observed_at >= $1 AND observed_at < $2
+                    GROUP BY coin_name, hour
+                    ON CONFLICT (coin_name, bucket) DO NOTHING
+                    RETURNING count
+                )
+                SELECT COALESCE(SUM(count), 0) FROM inserted
+            `, []any{dailyWatermark, rawWatermark}},
+			{"insert daily buckets", `
+                WITH inserted AS (
+                    INSERT INTO coins_daily (coin_name, bucket, open, high, low, close, avg, count)
+                    SELECT coin_name,
+                           date_trunc('day', observed_at AT TIME ZONE 'UTC') AT TIME ZONE 'UTC' AS day,
+                           (array_agg(price ORDER BY observed_at))[1],
+                           MAX(price),
+                           MIN(price),
+                           (array_agg(price ORDER BY observed_at DESC))[1],
+                           AVG(price),
+                           COUNT(*)
+                    FROM import_staging
+                    WHERE observed_at < $1
+                    GROUP BY coin_name, day
+                    ON CONFLICT (coin_name, bucket) DO NOTHING
+                    RETURNING count
+                )
+                SELECT COALESCE(SUM(count), 0) FROM inserted
+            `, []any{dailyWatermark}},
+		}
+		for _, statement := range statements {
+			var rolled int64
+			if err := tx.QueryRow(ctx, statement.sql, statement.args...).Scan(&rolled); err != nil {
+				return errors.Wrap(err, statement.name)
+			}
+			inserted += rolled
+		}
+		return nil
+	})
+	if err != nil {
+		logger.Error("Import failed",
+			slog.String("error", err.Error()),
+			slog.Duration("duration", time.Since(startTime)))
+		return entities.StoreResult{}, errors.Wrap(entities.ErrInternal, "failed to import coins")
+	}
+
+	result := entities.StoreResult{
+		Inserted: int(inserted),
+		Skipped:  len(coins) - int(inserted),
+	}
+	logger.Info("Coins imported",
+		slog.Int("inserted", result.Inserted),
+		slog.Int("skipped", result.Skipped),
+		slog.Duration("duration", time.Since(startTime)))
+	return result, nil
+}
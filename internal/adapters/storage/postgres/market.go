@@ -0,0 +1,131 @@
+package postgres
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"Cryptoproject/internal/entities"
+)
+
+// списки движений рынка в выдаче GetMarketMovers
+const (
+	moverGainer   = "gainer"
+	moverLoser    = "loser"
+	moverVolatile = "volatile"
+)
+
+func (s *Storage) GetMarketMovers(ctx context.Context, currency string, since time.Time, limit int) (*entities.MarketOverview, error) {
+	const op = "postgres.GetMarketMovers"
+	logger := s.logger.With(
+		slog.String("op", op),
+		slog.String("currency", currency),
+		slog.Time("since", since),
+		slog.Int("limit", limit),
+	)
+	startTime := time.Now()
+
+	// Ранжируются только монеты из watchlist в валюте котировки: удалённые из
+	// списка и импортированные в других валютах монеты не смешиваются с остальными.
+	// Первая/последняя цена и разброс читаются из
+	// idx_coins_coin_name_observed_at_price_currency только в партициях окна.
+	// Списки ранжируются и обрезаются в базе, счётчики приходят в каждой строке:
+	// при непустом окне список volatile не пуст.
+	rows, err := s.db.Query(ctx, `
+        WITH movers AS MATERIALIZED (
+            SELECT w.coin_name,
+                   first_obs.price AS open_price,
+                   last_obs.price AS last_price,
+                   (last_obs.price - first_obs.price) / first_obs.price * 100 AS change_pct,
+                   COALESCE(stats.volatility, 0) AS volatility
+            FROM watchlist w
+            CROSS JOIN LATERAL (
+                SELECT price FROM coins c
+                WHERE c.coin_name = w.coin_name AND c.currency = $3 AND c.observed_at >= $1
+                ORDER BY c.observed_at
+                LIMIT 1
+            ) first_obs
+            CROSS JOIN LATERAL (
+                SELECT price FROM coins c
+                WHERE c.coin_name = w.coin_name AND c.currency = $3 AND c.observed_at >= $1
+                ORDER BY c.observed_at DESC
+                LIMIT 1
+            ) last_obs
+            CROSS JOIN LATERAL (
+                SELECT stddev_samp(price) / NULLIF(avg(price), 0) * 100 AS volatility
+                FROM coins c
+                WHERE c.coin_name = w.coin_name AND c.currency = $3 AND c.observed_at >= $1
+            ) stats
+        ),
+        totals AS (
+            SELECT COUNT(*) FILTER (WHERE change_pct > 0) AS up,
+                   COUNT(*) FILTER (WHERE change_pct < 0) AS down,
+                   COUNT(*) FILTER (WHERE change_pct = 0) AS unchanged
+            FROM movers
+        ),
+        ranked AS (
+            (SELECT 'gainer' AS list, m.*, ROW_NUMBER() OVER (ORDER BY change_pct DESC, coin_name) AS pos
+             FROM movers m WHERE change_pct > 0
+             ORDER BY change_pct DESC, coin_name LIMIT $2)
+            UNION ALL
+            (SELECT 'loser', m.*, ROW_NUMBER() OVER (ORDER BY change_pct, coin_name)
+             FROM movers m WHERE change_pct < 0
+             ORDER BY change_pct, coin_name LIMIT $2)
+            UNION ALL
+            (SELECT 'volatile', m.*, ROW_NUMBER() OVER (ORDER BY volatility DESC, coin_name)
+             FROM movers m
+             ORDER BY volatility DESC, coin_name LIMIT $2)
+        )
+        SELECT r.list, r.coin_name, r.open_price, r.last_price, r.change_pct, r.volatility,
+               t.up, t.down, t.unchanged
+        FROM ranked r CROSS JOIN totals t
+        ORDER BY r.list, r.pos
+    `, since, limit, currency)
+	if err != nil {
+		logger.Error("Query failed",
+			slog.String("error", err.Error()),
+			slog.Duration("duration", time.Since(startTime)))
+		return nil, errors.Wrap(entities.ErrInternal, "failed to query market movers")
+	}
+	defer rows.Close()
+
+	overview := &entities.MarketOverview{
+		Gainers:      make([]entities.MarketMover, 0, limit),
+		Losers:       make([]entities.MarketMover, 0, limit),
+		MostVolatile: make([]entities.MarketMover, 0, limit),
+	}
+	for rows.Next() {
+		var (
+			list  string
+			mover entities.MarketMover
+		)
+		if err = rows.Scan(&list, &mover.CoinName, &mover.OpenPrice, &mover.LastPrice,
+			&mover.ChangePct, &mover.Volatility, &overview.Up, &overview.Down, &overview.Unchanged); err != nil {
+			logger.Error("Row scan failed",
+				slog.String("error", err.Error()))
+			return nil, errors.Wrap(entities.ErrInternal, "failed to query market movers")
+		}
+		switch list {
+		case moverGainer:
+			overview.Gainers = append(overview.Gainers, mover)
+		case moverLoser:
+			overview.Losers = append(overview.Losers, mover)
+		case moverVolatile:
+			overview.MostVolatile = append(overview.MostVolatile, mover)
+		}
+	}
+	if err = rows.Err(); err != nil {
+		logger.Error("Rows iteration failed",
+			slog.String("error", err.Error()))
+		return nil, errors.Wrap(entities.ErrInternal, "failed to query market movers")
+	}
+
+	logger.Info("Market movers retrieved",
+		slog.Int("up", overview.Up),
+		slog.Int("down", overview.Down),
+		slog.Int("unchanged", overview.Unchanged),
+		slog.Duration("duration", time.Since(startTime)))
+	return overview, nil
+}
@@ -0,0 +1,189 @@
+package postgres
+
+import (
+	"context"
+	"log/slog"
+	"slices"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/pkg/errors"
+
+	"Cryptoproject/internal/entities"
+	"Cryptoproject/pkg/migrations"
+)
+
+const createSchemaMigrations = `
+    CREATE TABLE IF NOT EXISTS schema_migrations (
+        name TEXT PRIMARY KEY,
+        applied_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+    )
+`
+
+// MigrateUp applies the migrations that are not yet recorded in schema_migrations
+// and returns the names of the applied ones.
+func (s *Storage) MigrateUp(ctx context.Context, list []migrations.Migration) ([]string, error) {
+	const op = "postgres.MigrateUp"
+	logger := s.logger.With(slog.String("op", op))
+	startTime := time.Now()
+
+	applied, err := s.appliedMigrations(ctx)
+	if err != nil {
+		logger.Error("Failed to read applied migrations", slog.String("error", err.Error()))
+		return nil, errors.Wrap(entities.ErrInternal, "failed to read applied migrations")
+	}
+
+	var names []string
+	for _, migration := range list {
+		if applied[migration.Name] {
+			continue
+		}
+
+		err := runMigration(ctx, s.db, migration.Up,
+			`INSERT INTO schema_migrations (name) VALUES ($1)`, migration.Name)
+		if err != nil {
+			logger.Error("Migration failed",
+				slog.String("migration", migration.Name),
+				slog.String("error", err.Error()))
+			return names, errors.Wrapf(entities.ErrInternal, "failed to apply migration %s", migration.Name)
+		}
+		logger.Info("Migration applied", slog.String("migration", migration.Name))
+		names = append(names, migration.Name)
+	}
+
+	logger.Info("Migrations applied",
+		slog.Int("applied", len(names)),
+		slog.Duration("duration", time.Since(startTime)))
+	return names, nil
+}
+
+// MigrateDown rolls back the last steps applied migrations and returns their names.
+func (s *Storage) MigrateDown(ctx context.Context, list []migrations.Migration, steps int) ([]string, error) {
+	const op = "postgres.MigrateDown"
+	logger := s.logger.With(
+		slog.String("op", op),
+		slog.Int("steps", steps))
+
+	if steps <= 0 {
+		return nil, errors.Wrap(entities.ErrInvalidParam, "steps must be positive")
+	}
+
+	applied, err := s.appliedMigrations(ctx)
+	if err != nil {
+		logger.Error("Failed to read applied migrations", slog.String("error", err.Error()))
+		return nil, errors.Wrap(entities.ErrInternal, "failed to read applied migrations")
+	}
+
+	var names []string
+	for i := len(list) - 1; i >= 0 && len(names) < steps; i-- {
+		migration := list[i]
+		if !applied[migration.Name] {
+			continue
+		}
+		if migration.Down == "" {
+			return names, errors.Wrapf(entities.ErrInvalidParam, "migration %s cannot be rolled back", migration.Name)
+		}
+
+		err := runMigration(ctx, s.db, migration.Down,
+			`DELETE FROM schema_migrations WHERE name = $1`, migration.Name)
+		if err != nil {
+			logger.Error("Rollback failed",
+				slog.String("migration", migration.Name),
+				slog.String("error", err.Error()))
+			return names, errors.Wrapf(entities.ErrInternal, "failed to roll back migration %s", migration.Name)
+		}
+		logger.Info("Migration rolled back", slog.String("migration", migration.Name))
+		names = append(names, migration.Name)
+	}
+	return names, nil
+}
+
+// MigrateBaseline records the migrations up to and including to as applied
+// without running them and returns the names of the newly recorded ones. It
+// is meant for databases whose schema was created before schema_migrations
+// tracked it.
+func (s *Storage) MigrateBaseline(ctx context.Context, list []migrations.Migration, to string) ([]string, error) {
+	const op = "postgres.MigrateBaseline"
+	logger := s.logger.With(
+		slog.String("op", op),
+		slog.String("to", to))
+
+	last := slices.IndexFunc(list, func(migration migrations.Migration) bool {
+		return migration.Name == to
+	})
+	if last < 0 {
+		return nil, errors.Wrapf(entities.ErrInvalidParam, "unknown migration %q", to)
+	}
+
+	applied, err := s.appliedMigrations(ctx)
+	if err != nil {
+		logger.Error("Failed to read applied migrations", slog.String("error", err.Error()))
+		return nil, errors.Wrap(entities.ErrInternal, "failed to read applied migrations")
+	}
+
+	var names []string
+	for _, migration := range list[:last+1] {
+		if !applied[migration.Name] {
+			names = append(names, migration.Name)
+		}
+	}
+	if len(names) == 0 {
+		return nil, nil
+	}
+
+	err = pgx.BeginFunc(ctx, s.db, func(tx pgx.Tx) error {
+		for _, name := range names {
+			if _, err := tx.Exec(ctx, `INSERT INTO schema_migrations (name) VALUES ($1)`, name); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		logger.Error("Baseline failed", slog.String("error", err.Error()))
+		return nil, errors.Wrap(entities.ErrInternal, "failed to record baseline migrations")
+	}
+
+	logger.Info("Baseline recorded", slog.Int("recorded", len(names)))
+	return names, nil
+}
+
+// txBeginner — часть пула, через которую применяются миграции
+type txBeginner interface {
+	Begin(ctx context.Context) (pgx.Tx, error)
+}
+
+// runMigration runs script and the schema_migrations update in one transaction,
+// so a failed migration leaves neither its changes nor its record behind.
+// Scripts must not control the transaction themselves (see migrations.Postgres).
+func runMigration(ctx context.Context, db txBeginner, script, record, name string) error {
+	// скрипты без аргументов идут через simple protocol, поэтому допускают несколько операторов
+	return pgx.BeginFunc(ctx, db, func(tx pgx.Tx) error {
+		if _, err := tx.Exec(ctx, script); err != nil {
+			return err
+		}
+		_, err := tx.Exec(ctx, record, name)
+		return err
+	})
+}
+
+func (s *Storage) appliedMigrations(ctx context.Context) (map[string]bool, error) {
+	if _, err := s.db.Exec(ctx, createSchemaMigrations); err != nil {
+		return nil, err
+	}
+
+	rows, err := s.db.Query(ctx, `SELECT name FROM schema_migrations`)
+	if err != nil {
+		return nil, err
+	}
+	names, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		return nil, err
+	}
+
+	applied := make(map[string]bool, len(names))
+	for _, name := range names {
+		applied[name] = true
+	}
+	return applied, nil
+}
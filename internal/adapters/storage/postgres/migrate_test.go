@@ -0,0 +1,92 @@
+package postgres
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeDB хранит операторы зафиксированных транзакций
+type fakeDB struct {
+	failOn    string
+	committed []string
+}
+
+func (db *fakeDB) Begin(_ context.Context) (pgx.Tx, error) {
+	return &fakeTx{db: db}, nil
+}
+
+// fakeTx копит операторы до Commit; остальные методы pgx.Tx не нужны
+type fakeTx struct {
+	pgx.Tx
+	db      *fakeDB
+	pending []string
+	closed  bool
+}
+
+func (tx *fakeTx) Exec(_ context.Context, sql string, _ ...any) (pgconn.CommandTag, error) {
+	if tx.db.failOn != "" && strings.Contains(sql, tx.db.failOn) {
+		return pgconn.CommandTag{}, errors.New("syntax error")
+	}
+	tx.pending = append(tx.pending, sql)
+	return pgconn.CommandTag{}, nil
+}
+
+func (tx *fakeTx) Commit(_ context.Context) error {
+	if tx.closed {
+		return pgx.ErrTxClosed
+	}
+	tx.closed = true
+	tx.db.committed = append(tx.db.committed, tx.pending...)
+	return nil
+}
+
+func (tx *fakeTx) Rollback(_ context.Context) error {
+	if tx.closed {
+		return pgx.ErrTxClosed
+	}
+	tx.closed = true
+	return nil
+}
+
+func Test_RunMigration_PartialFailure(t *testing.T) {
+	t.Parallel()
+
+	const (
+		script = "CREATE TABLE a (id INT); CREATE TABLE b (id INT);"
+		record = "INSERT INTO schema_migrations (name) VALUES ($1)"
+	)
+
+	testCases := []struct {
+		name      string
+		failOn    string
+		committed []string
+	}{
+		{name: "applied", committed: []string{script, record}},
+		{name: "script fails", failOn: "CREATE TABLE"},
+		{name: "record fails", failOn: "schema_migrations"},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			db := &fakeDB{failOn: tc.failOn}
+			err := runMigration(context.Background(), db, script, record, "0001_create")
+			if tc.failOn == "" {
+				require.NoError(t, err)
+			} else {
+				require.Error(t, err)
+			}
+			// при ошибке не остаётся ни изменений скрипта, ни записи о миграции
+			assert.Equal(t, tc.committed, db.committed)
+		})
+	}
+}
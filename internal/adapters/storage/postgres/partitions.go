@@ -0,0 +1,179 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/pkg/errors"
+
+	"Cryptoproject/internal/entities"
+)
+
+const (
+	coinsPartitionPrefix = "coins_p"
+	coinsPartitionLayout = "2006_01"
+	// partitionLockKey сериализует DDL партиций между экземплярами приложения
+	partitionLockKey = "coins_partitions"
+)
+
+func coinsPartitionName(month time.Time) string {
+	return coinsPartitionPrefix + month.Format(coinsPartitionLayout)
+}
+
+// coinsPartitionMonth возвращает начало месяца, который покрывает партиция,
+// и false для партиций, созданных не по нашему соглашению об именах (например, coins_default).
+func coinsPartitionMonth(name string) (time.Time, bool) {
+	suffix, ok := strings.CutPrefix(name, coinsPartitionPrefix)
+	if !ok {
+		return time.Time{}, false
+	}
+	month, err := time.Parse(coinsPartitionLayout, suffix)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return month, true
+}
+
+func (s *Storage) EnsurePartitions(ctx context.Context, from, to time.Time) (int, error) {
+	const op = "postgres.EnsurePartitions"
+	logger := s.logger.With(
+		slog.String("op", op),
+		slog.Time("from", from),
+		slog.Time("to", to),
+	)
+
+	from = from.UTC()
+	month := time.Date(from.Year(), from.Month(), 1, 0, 0, 0, 0, time.UTC)
+
+	var created int
+	for ; month.Before(to); month = month.AddDate(0, 1, 0) {
+		ok, err := s.createCoinsPartition(ctx, month)
+		if err != nil {
+			logger.Error("Partition creation failed",
+				slog.String("partition", coinsPartitionName(month)),
+				slog.String("error", err.Error()))
+			return created, errors.Wrap(entities.ErrInternal, "failed to create coins partitions")
+		}
+		if ok {
+			logger.Info("Partition created", slog.String("partition", coinsPartitionName(month)))
+			created++
+		}
+	}
+
+	return created, nil
+}
+
+// createCoinsPartition создаёт партицию отдельной таблицей, переносит в неё
+// попавшие в default строки и только потом подключает: ATTACH упал бы,
+// если бы в default остались строки из её диапазона.
+func (s *Storage) createCoinsPartition(ctx context.Context, month time.Time) (bool, error) {
+	name := coinsPartitionName(month)
+	table := pgx.Identifier{name}.Sanitize()
+	lower, upper := month, month.AddDate(0, 1, 0)
+
+	var created bool
+	err := pgx.BeginFunc(ctx, s.db, func(tx pgx.Tx) error {
+		if _, err := tx.Exec(ctx, `SELECT pg_advisory_xact_lock(hashtext($1))`, partitionLockKey); err != nil {
+			return errors.Wrap(err, "lock partitions")
+		}
+
+		var exists bool
+		if err := tx.QueryRow(ctx, `SELECT to_regclass($1) IS NOT NULL`, name).Scan(&exists); err != nil {
+			return errors.Wrap(err, "check partition")
+		}
+		if exists {
+			return nil
+		}
+
+		if _, err := tx.Exec(ctx, fmt.Sprintf(
+			`CREATE TABLE %s (LIKE coins INCLUDING DEFAULTS INCLUDING CONSTRAINTS)`, table)); err != nil {
+			return errors.Wrap(err, "create partition table")
+		}
+
+		if _, err := tx.Exec(ctx, fmt.Sprintf(`
+            WITH moved AS (
+                DELETE FROM coins_default
+                WHERE observed_at >= $1 AND observed_at < $2
+                RETURNING *
+            )
+            INSERT INTO %s SELECT * FROM moved
+        `, table), lower, upper); err != nil {
+			return errors.Wrap(err, "move rows from default partition")
+		}
+
+		if _, err := tx.Exec(ctx, fmt.Sprintf(
+			`ALTER TABLE coins ATTACH PARTITION %s FOR VALUES FROM ('%s') TO ('%s')`,
+			table, lower.Format(time.RFC3339), upper.Format(time.RFC3339))); err != nil {
+			return errors.Wrap(err, "attach partition")
+		}
+
+		created = true
+		return nil
+	})
+	return created, err
+}
+
+func (s *Storage) DropPartitionsBefore(ctx context.Context, before time.Time) (int, error) {
+	const op = "postgres.DropPartitionsBefore"
+	logger := s.logger.With(
+		slog.String("op", op),
+		slog.Time("before", before),
+	)
+
+	var dropped []string
+	err := pgx.BeginFunc(ctx, s.db, func(tx pgx.Tx) error {
+		if _, err := tx.Exec(ctx, `SELECT pg_advisory_xact_lock(hashtext($1))`, partitionLockKey); err != nil {
+			return errors.Wrap(err, "lock partitions")
+		}
+
+		// строки ниже отметки уже свёрнуты в почасовые бакеты, выше неё партицию трогать нельзя
+		var watermark time.Time
+		if err := tx.QueryRow(ctx, `
+            SELECT GREATEST(raw_watermark, 'epoch') FROM coins_retention_state
+        `).Scan(&watermark); err != nil {
+			return errors.Wrap(err, "read raw watermark")
+		}
+		if watermark.Before(before) {
+			before = watermark
+		}
+
+		rows, err := tx.Query(ctx, `
+            SELECT c.relname
+            FROM pg_inherits i
+            JOIN pg_class c ON c.oid = i.inhrelid
+            WHERE i.inhparent = 'coins'::regclass
+        `)
+		if err != nil {
+			return errors.Wrap(err, "list partitions")
+		}
+		names, err := pgx.CollectRows(rows, pgx.RowTo[string])
+		if err != nil {
+			return errors.Wrap(err, "list partitions")
+		}
+
+		for _, name := range names {
+			month, ok := coinsPartitionMonth(name)
+			if !ok || month.AddDate(0, 1, 0).After(before) {
+				continue
+			}
+			if _, err = tx.Exec(ctx, `DROP TABLE `+pgx.Identifier{name}.Sanitize()); err != nil {
+				return errors.Wrapf(err, "drop partition %s", name)
+			}
+			dropped = append(dropped, name)
+		}
+		return nil
+	})
+	if err != nil {
+		logger.Error("Partition drop failed", slog.String("error", err.Error()))
+		return 0, errors.Wrap(entities.ErrInternal, "failed to drop coins partitions")
+	}
+
+	if len(dropped) > 0 {
+		logger.Info("Partitions dropped", slog.Any("partitions", dropped))
+	}
+	return len(dropped), nil
+}
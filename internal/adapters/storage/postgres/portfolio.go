@@ -0,0 +1,193 @@
+package postgres
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/pkg/errors"
+
+	"Cryptoproject/internal/cases"
+	"Cryptoproject/internal/entities"
+)
+
+var (
+	_ cases.PortfolioStorage = (*Storage)(nil)
+)
+
+func (s *Storage) CreatePortfolio(ctx context.Context, portfolio entities.Portfolio) (*entities.Portfolio, error) {
+	const op = "postgres.CreatePortfolio"
+	logger := s.logger.With(slog.String("op", op))
+
+	err := s.db.QueryRow(ctx, `
+        INSERT INTO portfolios (name, created_at)
+        VALUES ($1, $2)
+        RETURNING id, created_at
+    `, portfolio.Name, portfolio.CreatedAt).Scan(&portfolio.ID, &portfolio.CreatedAt)
+	if err != nil {
+		logger.Error("Insert failed", slog.String("error", err.Error()))
+		return nil, errors.Wrap(entities.ErrInternal, "failed to insert portfolio")
+	}
+
+	logger.Info("Portfolio stored", slog.Int64("portfolio_id", portfolio.ID))
+	return &portfolio, nil
+}
+
+func (s *Storage) GetPortfolio(ctx context.Context, id int64) (*entities.Portfolio, error) {
+	const op = "postgres.GetPortfolio"
+	logger := s.logger.With(
+		slog.String("op", op),
+		slog.Int64("portfolio_id", id),
+	)
+
+	var portfolio entities.Portfolio
+	err := s.db.QueryRow(ctx, `
+        SELECT id, name, created_at FROM portfolios WHERE id = $1
+    `, id).Scan(&portfolio.ID, &portfolio.Name, &portfolio.CreatedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, errors.Wrapf(entities.ErrNotFound, "portfolio %d not found", id)
+	}
+	if err != nil {
+		logger.Error("Query failed", slog.String("error", err.Error()))
+		return nil, errors.Wrap(entities.ErrInternal, "failed to query portfolio")
+	}
+	return &portfolio, nil
+}
+
+func (s *Storage) ListPortfolios(ctx context.Context) ([]entities.Portfolio, error) {
+	const op = "postgres.ListPortfolios"
+	logger := s.logger.With(slog.String("op", op))
+
+	rows, err := s.db.Query(ctx, `SELECT id, name, created_at FROM portfolios ORDER BY id`)
+	if err != nil {
+		logger.Error("Query failed", slog.String("error", err.Error()))
+		return nil, errors.Wrap(entities.ErrInternal, "failed to query portfolios")
+	}
+	defer rows.Close()
+
+	portfolios := make([]entities.Portfolio, 0)
+	for rows.Next() {
+		var portfolio entities.Portfolio
+		if err = rows.Scan(&portfolio.ID, &portfolio.Name, &portfolio.CreatedAt); err != nil {
+			logger.Error("Row scan failed", slog.String("error", err.Error()))
+			return nil, errors.Wrap(entities.ErrInternal, "failed to query portfolios")
+		}
+		portfolios = append(portfolios, portfolio)
+	}
+	if err = rows.Err(); err != nil {
+		logger.Error("Rows iteration failed", slog.String("error", err.Error()))
+		return nil, errors.Wrap(entities.ErrInternal, "failed to query portfolios")
+	}
+	return portfolios, nil
+}
+
+// AddTransaction stores the transaction. Inserts into one portfolio are
+// serialized by locking its row, so check sees every transaction stored
+// before this one and can reject it, e.g. a sell of more than is held.
+// A nil check skips loading the transactions.
+func (s *Storage) AddTransaction(ctx context.Context, transaction entities.Transaction,
+	check func(existing []entities.Transaction) error) (*entities.Transaction, error) {
+	const op = "postgres.AddTransaction"
+	logger := s.logger.With(
+		slog.String("op", op),
+		slog.Int64("portfolio_id", transaction.PortfolioID),
+	)
+
+	var rejected error
+	err := pgx.BeginFunc(ctx, s.db, func(tx pgx.Tx) error {
+		var id int64
+		err := tx.QueryRow(ctx, `
+            SELECT id FROM portfolios WHERE id = $1 FOR UPDATE
+        `, transaction.PortfolioID).Scan(&id)
+		if errors.Is(err, pgx.ErrNoRows) {
+			rejected = errors.Wrapf(entities.ErrNotFound, "portfolio %d not found", transaction.PortfolioID)
+			return rejected
+		}
+		if err != nil {
+			return errors.Wrap(err, "lock portfolio")
+		}
+
+		if check != nil {
+			existing, err := scanTransactions(tx.Query(ctx, `
+                SELECT id, portfolio_id, coin_name, side, quantity, price, fee, executed_at
+                FROM portfolio_transactions
+                WHERE portfolio_id = $1
+                ORDER BY executed_at, id
+            `, transaction.PortfolioID))
+			if err != nil {
+				return errors.Wrap(err, "query transactions")
+			}
+			if rejected = check(existing); rejected != nil {
+				return rejected
+			}
+		}
+
+		return tx.QueryRow(ctx, `
+            INSERT INTO portfolio_transactions
+                (portfolio_id, coin_name, side, quantity, price, fee, executed_at)
+            VALUES ($1, $2, $3, $4, $5, $6, $7)
+            RETURNING id
+        `, transaction.PortfolioID, transaction.CoinName, string(transaction.Side), transaction.Quantity,
+			transaction.Price, transaction.Fee, transaction.ExecutedAt).Scan(&transaction.ID)
+	})
+	if rejected != nil {
+		return nil, rejected
+	}
+	if err != nil {
+		logger.Error("Insert failed", slog.String("error", err.Error()))
+		return nil, errors.Wrap(entities.ErrInternal, "failed to insert transaction")
+	}
+
+	logger.Info("Transaction stored", slog.Int64("transaction_id", transaction.ID))
+	return &transaction, nil
+}
+
+// GetTransactions returns portfolio transactions executed up to until; zero until means all.
+func (s *Storage) GetTransactions(ctx context.Context, portfolioID int64, until time.Time) ([]entities.Transaction, error) {
+	const op = "postgres.GetTransactions"
+	logger := s.logger.With(
+		slog.String("op", op),
+		slog.Int64("portfolio_id", portfolioID),
+	)
+
+	var untilParam *time.Time
+	if !until.IsZero() {
+		untilParam = &until
+	}
+
+	transactions, err := scanTransactions(s.db.Query(ctx, `
+        SELECT id, portfolio_id, coin_name, side, quantity, price, fee, executed_at
+        FROM portfolio_transactions
+        WHERE portfolio_id = $1 AND ($2::timestamptz IS NULL OR executed_at <= $2)
+        ORDER BY executed_at, id
+    `, portfolioID, untilParam))
+	if err != nil {
+		logger.Error("Query failed", slog.String("error", err.Error()))
+		return nil, errors.Wrap(entities.ErrInternal, "failed to query transactions")
+	}
+	return transactions, nil
+}
+
+// scanTransactions читает строки portfolio_transactions и закрывает rows
+func scanTransactions(rows pgx.Rows, err error) ([]entities.Transaction, error) {
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	transactions := make([]entities.Transaction, 0)
+	for rows.Next() {
+		var (
+			tx   entities.Transaction
+			side string
+		)
+		if err = rows.Scan(&tx.ID, &tx.PortfolioID, &tx.CoinName, &side,
+			&tx.Quantity, &tx.Price, &tx.Fee, &tx.ExecutedAt); err != nil {
+			return nil, errors.Wrap(err, "scan transaction")
+		}
+		tx.Side = entities.TransactionSide(side)
+		transactions = append(transactions, tx)
+	}
+	return transactions, rows.Err()
+}
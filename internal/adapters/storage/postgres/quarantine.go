@@ -0,0 +1,92 @@
+package postgres
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/pkg/errors"
+
+	"Cryptoproject/internal/entities"
+)
+
+func (s *Storage) StoreQuarantine(ctx context.Context, quotes []entities.QuarantinedQuote) error {
+	const op = "postgres.StoreQuarantine"
+	logger := s.logger.With(
+		slog.String("op", op),
+		slog.Int("quotes_count", len(quotes)),
+	)
+
+	if len(quotes) == 0 {
+		return nil
+	}
+
+	now := time.Now()
+	if _, err := s.db.CopyFrom(ctx,
+		pgx.Identifier{"coins_quarantine"},
+		[]string{"coin_name", "currency", "source", "price", "observed_at", "reason", "detail", "reference_price"},
+		pgx.CopyFromSlice(len(quotes), func(i int) ([]any, error) {
+			quote := quotes[i]
+			coin := entities.Coin{CoinName: quote.CoinName, Currency: quote.Currency, Source: quote.Source, ObservedAt: quote.ObservedAt}
+			var reference *float64
+			if quote.ReferencePrice > 0 {
+				reference = &quote.ReferencePrice
+			}
+			return []any{quote.CoinName, coinCurrency(coin), coinSource(coin), quote.Price,
+				observedAt(coin, now), string(quote.Reason), quote.Detail, reference}, nil
+		}),
+	); err != nil {
+		logger.Error("Copy failed", slog.String("error", err.Error()))
+		return errors.Wrap(entities.ErrInternal, "failed to store quarantined quotes")
+	}
+
+	logger.Info("Quarantined quotes stored")
+	return nil
+}
+
+func (s *Storage) GetRecentCoins(ctx context.Context, titles []string, since time.Time) ([]entities.Coin, error) {
+	const op = "postgres.GetRecentCoins"
+	logger := s.logger.With(
+		slog.String("op", op),
+		slog.Int("titles_count", len(titles)),
+		slog.Time("since", since),
+	)
+	startTime := time.Now()
+
+	rows, err := s.db.Query(ctx, `
+        SELECT coin_name, currency, source, price, observed_at, ingested_at
+        FROM coins
+        WHERE coin_name = ANY($1) AND observed_at >= $2
+        ORDER BY coin_name, observed_at DESC
+    `, titles, since)
+	if err != nil {
+		logger.Error("Query failed",
+			slog.String("error", err.Error()),
+			slog.Duration("duration", time.Since(startTime)))
+		return nil, errors.Wrap(entities.ErrInternal, "failed to query recent coins")
+	}
+	defer rows.Close()
+
+	var coins []entities.Coin
+	for rows.Next() {
+		var coin entities.Coin
+		if err = rows.Scan(&coin.CoinName, &coin.Currency, &coin.Source, &coin.Price,
+			&coin.ObservedAt, &coin.CreatedAt); err != nil {
+			logger.Error("Row scan failed",
+				slog.String("error", err.Error()))
+			return nil, errors.Wrap(entities.ErrInternal, "failed to query recent coins")
+		}
+		coins = append(coins, coin)
+	}
+	if err = rows.Err(); err != nil {
+		logger.Error("Rows iteration failed",
+			slog.String("error", err.Error()))
+		return nil, errors.Wrap(entities.ErrInternal, "failed to query recent coins")
+	}
+
+	logger.Debug("Recent coins retrieved",
+		slog.Int("count", len(coins)),
+		slog.Duration("duration", time.Since(startTime)))
+	return coins, nil
+}
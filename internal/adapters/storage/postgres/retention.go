@@ -0,0 +1,171 @@
+package postgres
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/pkg/errors"
+
+	"Cryptoproject/internal/cases"
+	"Cryptoproject/internal/entities"
+)
+
+var (
+	_ cases.RetentionStorage = (*Storage)(nil)
+)
+
+func (s *Storage) RollupRaw(ctx context.Context, before time.Time) (int64, error) {
+	const op = "postgres.RollupRaw"
+	logger := s.logger.With(
+		slog.String("op", op),
+		slog.Time("before", before),
+	)
+
+	var rolled int64
+	err := pgx.BeginFunc(ctx, s.db, func(tx pgx.Tx) error {
+		var watermark time.Time
+		// начальная отметка -infinity, а pgx не сканирует бесконечность в time.Time
+		if err := tx.QueryRow(ctx, `
+            SELECT GREATEST(raw_watermark, 'epoch') FROM coins_retention_state FOR UPDATE
+        `).Scan(&watermark); err != nil {
+			return errors.Wrap(err, "lock retention state")
+		}
+
+		tag, err := tx.Exec(ctx, `
+            INSERT INTO coins_hourly (coin_name, bucket, open, high, low, close, avg, count)
+            SELECT coin_name,
+                   date_trunc('hour', observed_at AT TIME ZONE 'UTC') AT TIME ZONE 'UTC' AS bucket,
+                   (array_agg(price ORDER BY observed_at))[1],
+                   MAX(price),
+                   MIN(price),
+                   (array_agg(price ORDER BY observed_at DESC))[1],
+                   AVG(price),
+                   COUNT(*)
+            FROM coins
+            WHERE observed_at >= $1 AND observed_at < $2
+            GROUP BY 1, 2
+            ON CONFLICT (coin_name, bucket) DO NOTHING
+        `, watermark, before)
+		if err != nil {
+			return errors.Wrap(err, "insert hourly buckets")
+		}
+		rolled = tag.RowsAffected()
+
+		if _, err = tx.Exec(ctx, `
+            UPDATE coins_retention_state SET raw_watermark = GREATEST(raw_watermark, $1)
+        `, before); err != nil {
+			return errors.Wrap(err, "update raw watermark")
+		}
+		return nil
+	})
+	if err != nil {
+		logger.Error("Rollup failed", slog.String("error", err.Error()))
+		return 0, errors.Wrap(entities.ErrInternal, "failed to roll up raw coins")
+	}
+
+	logger.Info("Raw coins rolled up", slog.Int64("buckets", rolled))
+	return rolled, nil
+}
+
+func (s *Storage) RollupHourly(ctx context.Context, before time.Time) (int64, error) {
+	const op = "postgres.RollupHourly"
+	logger := s.logger.With(
+		slog.String("op", op),
+		slog.Time("before", before),
+	)
+
+	var rolled int64
+	err := pgx.BeginFunc(ctx, s.db, func(tx pgx.Tx) error {
+		var dailyWatermark, cutoff time.Time
+		// дневные свёртки строятся только из почасовых, поэтому не дальше сырой отметки
+		if err := tx.QueryRow(ctx, `
+            SELECT GREATEST(daily_watermark, 'epoch'),
+                   LEAST($1, GREATEST(date_trunc('day', raw_watermark AT TIME ZONE 'UTC') AT TIME ZONE 'UTC', 'epoch'))
+            FROM coins_retention_state FOR UPDATE
+        `, before).Scan(&dailyWatermark, &cutoff); err != nil {
+			return errors.Wrap(err, "lock retention state")
+		}
+		if !cutoff.After(dailyWatermark) {
+			return nil
+		}
+
+		tag, err := tx.Exec(ctx, `
+            INSERT INTO coins_daily (coin_name, bucket, open, high, low, close, avg, count)
+            SELECT coin_name,
+                   date_trunc('day', bucket AT TIME ZONE 'UTC') AT TIME ZONE 'UTC' AS day,
+                   (array_agg(open ORDER BY bucket))[1],
+                   MAX(high),
+                   MIN(low),
+                   (array_agg(close ORDER BY bucket DESC))[1],
+                   SUM(avg * count) / SUM(count),
+                   SUM(count)
+            FROM coins_hourly
+            WHERE bucket >= $1 AND bucket < $2
+            GROUP BY 1, 2
+            ON CONFLICT (coin_name, bucket) DO NOTHING
+        `, dailyWatermark, cutoff)
+		if err != nil {
+			return errors.Wrap(err, "insert daily buckets")
+		}
+		rolled = tag.RowsAffected()
+
+		if _, err = tx.Exec(ctx, `
+            UPDATE coins_retention_state SET daily_watermark = GREATEST(daily_watermark, $1)
+        `, cutoff); err != nil {
+			return errors.Wrap(err, "update daily watermark")
+		}
+		return nil
+	})
+	if err != nil {
+		logger.Error("Rollup failed", slog.String("error", err.Error()))
+		return 0, errors.Wrap(entities.ErrInternal, "failed to roll up hourly coins")
+	}
+
+	logger.Info("Hourly coins rolled up", slog.Int64("buckets", rolled))
+	return rolled, nil
+}
+
+func (s *Storage) DeleteRawBatch(ctx context.Context, batchSize int) (int64, error) {
+	const op = "postgres.DeleteRawBatch"
+	logger := s.logger.With(slog.String("op", op))
+
+	// ctid уникален только внутри партиции, поэтому строки адресуются первичным ключом
+	tag, err := s.db.Exec(ctx, `
+        DELETE FROM coins
+        WHERE (id, observed_at) IN (
+            SELECT id, observed_at FROM coins
+            WHERE observed_at < (SELECT raw_watermark FROM coins_retention_state)
+            LIMIT $1
+        )
+    `, batchSize)
+	if err != nil {
+		logger.Error("Delete failed", slog.String("error", err.Error()))
+		return 0, errors.Wrap(entities.ErrInternal, "failed to delete raw coins")
+	}
+
+	logger.Debug("Raw batch deleted", slog.Int64("rows", tag.RowsAffected()))
+	return tag.RowsAffected(), nil
+}
+
+func (s *Storage) DeleteHourlyBatch(ctx context.Context, batchSize int) (int64, error) {
+	const op = "postgres.DeleteHourlyBatch"
+	logger := s.logger.With(slog.String("op", op))
+
+	tag, err := s.db.Exec(ctx, `
+        DELETE FROM coins_hourly
+        WHERE (coin_name, bucket) IN (
+            SELECT coin_name, bucket FROM coins_hourly
+            WHERE bucket < (SELECT daily_watermark FROM coins_retention_state)
+            LIMIT $1
+        )
+    `, batchSize)
+	if err != nil {
+		logger.Error("Delete failed", slog.String("error", err.Error()))
+		return 0, errors.Wrap(entities.ErrInternal, "failed to delete hourly coins")
+	}
+
+	logger.Debug("Hourly batch deleted", slog.Int64("rows", tag.RowsAffected()))
+	return tag.RowsAffected(), nil
+}
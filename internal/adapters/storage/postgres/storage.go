@@ -5,9 +5,11 @@ import (
 	"fmt"
 	"log/slog"
 	"math"
+	"sort"
 	"strings"
 	"time"
 
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/pkg/errors"
 
@@ -19,6 +21,14 @@ var (
 	_ cases.Storage = (*Storage)(nil)
 )
 
+const (
+	// actualCoinsLookback ограничивает первый проход GetActualCoins последними партициями.
+	actualCoinsLookback = 7 * 24 * time.Hour
+	// defaultCurrency и defaultSource совпадают со значениями по умолчанию колонок coins.
+	defaultCurrency = "USD"
+	defaultSource   = "cryptocompare"
+)
+
 type Storage struct {
 	db     *pgxpool.Pool
 	logger *slog.Logger
@@ -105,46 +115,94 @@ func extractHost(connStr string) string {
 	return connStr
 }
 
-func (s *Storage) Store(ctx context.Context, coins []entities.Coin) error {
+func (s *Storage) Store(ctx context.Context, coins []entities.Coin) (entities.StoreResult, error) {
 	const op = "postgres.Store"
 	logger := s.logger.With(
 		slog.String("op", op),
 		slog.Int("coins_count", len(coins)),
 	)
 
-	// Простая вставка без проверки уникальности
-	_, err := s.db.Exec(ctx, `
-        INSERT INTO coins (coin_name, price, created_at)
-        SELECT unnest($1::text[]), unnest($2::decimal[]), now()
-    `,
-		getCoinNames(coins),  // []string названий монет
-		getCoinPrices(coins), // []float64 цен
-	)
+	if len(coins) == 0 {
+		return entities.StoreResult{}, nil
+	}
+
+	now := time.Now()
+	var inserted int64
+	// COPY в staging-таблицу, затем перенос с пропуском уже известных наблюдений:
+	// повторный тик крона или параллельный запрос не создают дублей
+	err := pgx.BeginFunc(ctx, s.db, func(tx pgx.Tx) error {
+		if _, err := tx.Exec(ctx, `
+            CREATE TEMP TABLE coins_staging (
+                coin_name VARCHAR(50),
+                currency VARCHAR(10),
+                source VARCHAR(50),
+                price NUMERIC(24, 10),
+                observed_at TIMESTAMPTZ
+            ) ON COMMIT DROP
+        `); err != nil {
+			return errors.Wrap(err, "create staging table")
+		}
 
+		if _, err := tx.CopyFrom(ctx,
+			pgx.Identifier{"coins_staging"},
+			[]string{"coin_name", "currency", "source", "price", "observed_at"},
+			pgx.CopyFromSlice(len(coins), func(i int) ([]any, error) {
+				coin := coins[i]
+				return []any{coin.CoinName, coinCurrency(coin), coinSource(coin), coin.Price, observedAt(coin, now)}, nil
+			}),
+		); err != nil {
+			return errors.Wrap(err, "copy coins")
+		}
+
+		tag, err := tx.Exec(ctx, `
+            INSERT INTO coins (coin_name, currency, source, price, observed_at)
+            SELECT coin_name, currency, source, price, observed_at FROM coins_staging
+            ON CONFLICT (coin_name, currency, source, observed_at) DO NOTHING
+        `)
+		if err != nil {
+			return errors.Wrap(err, "insert coins")
+		}
+		inserted = tag.RowsAffected()
+		return nil
+	})
 	if err != nil {
 		logger.Error("Insert failed", slog.String("error", err.Error()))
-		return errors.Wrap(err, "failed to insert coins")
+		return entities.StoreResult{}, errors.Wrap(entities.ErrInternal, "failed to insert coins")
 	}
 
-	logger.Info("Coins stored successfully")
-	return nil
+	result := entities.StoreResult{
+		Inserted: int(inserted),
+		Skipped:  len(coins) - int(inserted),
+	}
+	logger.Info("Coins stored successfully",
+		slog.Int("inserted", result.Inserted),
+		slog.Int("skipped", result.Skipped))
+	return result, nil
 }
 
 // Вспомогательные функции
-func getCoinNames(coins []entities.Coin) []string {
-	names := make([]string, len(coins))
-	for i, c := range coins {
-		names[i] = c.CoinName
+func coinCurrency(coin entities.Coin) string {
+	if coin.Currency == "" {
+		return defaultCurrency
 	}
-	return names
+	return coin.Currency
 }
 
-func getCoinPrices(coins []entities.Coin) []float64 {
-	prices := make([]float64, len(coins))
-	for i, c := range coins {
-		prices[i] = c.Price
+func coinSource(coin entities.Coin) string {
+	if coin.Source == "" {
+		return defaultSource
 	}
-	return prices
+	return coin.Source
+}
+
+// observedAt берёт время котировки провайдера. Если провайдер его не сообщил,
+// время округляется до минуты, чтобы повторный запрос в пределах минуты
+// считался дублем.
+func observedAt(coin entities.Coin, now time.Time) time.Time {
+	if coin.ObservedAt.IsZero() {
+		return now.UTC().Truncate(time.Minute)
+	}
+	return coin.ObservedAt.UTC()
 }
 
 func (s *Storage) GetCoinsList(ctx context.Context) ([]string, error) {
@@ -154,8 +212,8 @@ func (s *Storage) GetCoinsList(ctx context.Context) ([]string, error) {
 	)
 	startTime := time.Now()
 
-	logger.Debug("Querying distinct coins")
-	rows, err := s.db.Query(ctx, `SELECT DISTINCT coin_name FROM coins`)
+	logger.Debug("Querying watchlist")
+	rows, err := s.db.Query(ctx, `SELECT coin_name FROM watchlist ORDER BY coin_name`)
 	if err != nil {
 		logger.Error("Query failed",
 			slog.String("error", err.Error()),
@@ -200,52 +258,75 @@ func (s *Storage) GetActualCoins(ctx context.Context, titles []string) ([]entiti
 		return []entities.Coin{}, nil
 	}
 
-	placeholders := make([]string, len(titles))
-	params := make([]interface{}, len(titles))
-	for i, title := range titles {
-		placeholders[i] = fmt.Sprintf("$%d", i+1)
-		params[i] = title
-	}
-
-	query := fmt.Sprintf(`
-    SELECT DISTINCT ON (coin_name) coin_name, price, created_at
-    FROM coins
-    WHERE coin_name IN(%s)
-    ORDER BY coin_name, created_at DESC
-`, strings.Join(placeholders, ","))
-
-	logger.Debug("Executing query",
-		slog.String("query", query))
-	rows, err := s.db.Query(ctx, query, params...)
-
+	// Сначала читаем только свежие партиции; полный проход нужен лишь для монет,
+	// которые давно не обновлялись.
+	coins, err := s.queryActualCoins(ctx, titles, startTime.Add(-actualCoinsLookback))
 	if err != nil {
 		logger.Error("Query failed",
 			slog.String("error", err.Error()),
 			slog.Duration("duration", time.Since(startTime)))
 		return nil, errors.Wrap(entities.ErrInternal, "failed to query coins list")
 	}
+
+	if missing := missingTitles(titles, coins); len(missing) > 0 {
+		logger.Debug("Falling back to full history scan",
+			slog.Int("missing_count", len(missing)))
+		older, err := s.queryActualCoins(ctx, missing, time.Time{})
+		if err != nil {
+			logger.Error("Query failed",
+				slog.String("error", err.Error()),
+				slog.Duration("duration", time.Since(startTime)))
+			return nil, errors.Wrap(entities.ErrInternal, "failed to query coins list")
+		}
+		coins = append(coins, older...)
+		sort.Slice(coins, func(i, j int) bool { return coins[i].CoinName < coins[j].CoinName })
+	}
+
+	logger.Info("Actual coins retrieved",
+		slog.Int("count", len(coins)),
+		slog.Duration("duration", time.Since(startTime)))
+	return coins, nil
+}
+
+// queryActualCoins returns the latest price per title among rows observed at or
+// after since; the bound lets the planner prune older partitions.
+func (s *Storage) queryActualCoins(ctx context.Context, titles []string, since time.Time) ([]entities.Coin, error) {
+	rows, err := s.db.Query(ctx, `
+        SELECT DISTINCT ON (coin_name) coin_name, price, currency, source, observed_at, ingested_at
+        FROM coins
+        WHERE coin_name = ANY($1) AND observed_at >= $2
+        ORDER BY coin_name, observed_at DESC
+    `, titles, since)
+	if err != nil {
+		return nil, err
+	}
 	defer rows.Close()
 
 	coins := make([]entities.Coin, 0, len(titles))
 	for rows.Next() {
 		var coin entities.Coin
-		if err = rows.Scan(&coin.CoinName, &coin.Price, &coin.CreatedAt); err != nil {
-			logger.Error("Row scan failed",
-				slog.String("error", err.Error()))
-			return nil, errors.Wrap(entities.ErrInternal, "failed to query coins list")
+		if err = rows.Scan(&coin.CoinName, &coin.Price, &coin.Currency, &coin.Source,
+			&coin.ObservedAt, &coin.CreatedAt); err != nil {
+			return nil, err
 		}
 		coins = append(coins, coin)
 	}
-	if err = rows.Err(); err != nil {
-		logger.Error("Rows iteration failed",
-			slog.String("error", err.Error()))
-		return nil, errors.Wrap(entities.ErrInternal, "failed to query coins list")
+	return coins, rows.Err()
+}
+
+func missingTitles(titles []string, coins []entities.Coin) []string {
+	found := make(map[string]struct{}, len(coins))
+	for _, coin := range coins {
+		found[coin.CoinName] = struct{}{}
 	}
 
-	logger.Info("Actual coins retrieved",
-		slog.Int("count", len(coins)),
-		slog.Duration("duration", time.Since(startTime)))
-	return coins, nil
+	var missing []string
+	for _, title := range titles {
+		if _, ok := found[title]; !ok {
+			missing = append(missing, title)
+		}
+	}
+	return missing
 }
 
 func (s *Storage) GetAggregateCoins(ctx context.Context, titles []string, aggFunc string) ([]entities.Coin, error) {
@@ -262,11 +343,13 @@ func (s *Storage) GetAggregateCoins(ctx context.Context, titles []string, aggFun
 		return []entities.Coin{}, nil
 	}
 
+	// coins_history объединяет сырые строки и свёртки, поэтому агрегаты
+	// считаются по счётчикам бакетов, а не по числу строк
 	query := `
         SELECT 
             coin_name, 
-            %s(price) as price
-        FROM coins
+            %s as price
+        FROM coins_history
         WHERE coin_name = ANY($1)
         GROUP BY coin_name
     `
@@ -274,11 +357,11 @@ func (s *Storage) GetAggregateCoins(ctx context.Context, titles []string, aggFun
 	var aggQuery string
 	switch strings.ToUpper(aggFunc) {
 	case "AVG":
-		aggQuery = fmt.Sprintf(query, "AVG")
+		aggQuery = fmt.Sprintf(query, "SUM(avg * count) / SUM(count)")
 	case "MAX":
-		aggQuery = fmt.Sprintf(query, "MAX")
+		aggQuery = fmt.Sprintf(query, "MAX(high)")
 	case "MIN":
-		aggQuery = fmt.Sprintf(query, "MIN")
+		aggQuery = fmt.Sprintf(query, "MIN(low)")
 	default:
 		err := errors.Wrap(entities.ErrInternal, "unsupported aggregate function: %s (allowed: AVG, MAX, MIN)")
 		logger.Error("Invalid aggregation function",
@@ -319,3 +402,80 @@ func (s *Storage) GetAggregateCoins(ctx context.Context, titles []string, aggFun
 		slog.Duration("duration", time.Since(startTime)))
 	return coins, nil
 }
+
+// GetCoinsAt returns the last known price of every title as of at, without
+// looking at observations made after it.
+func (s *Storage) GetCoinsAt(ctx context.Context, titles []string, at time.Time) ([]entities.Coin, error) {
+	const op = "postgres.GetCoinsAt"
+	logger := s.logger.With(
+		slog.String("op", op),
+		slog.Int("titles_count", len(titles)),
+		slog.Time("at", at),
+	)
+	startTime := time.Now()
+
+	if len(titles) == 0 {
+		logger.Debug("Empty titles list provided")
+		return []entities.Coin{}, nil
+	}
+
+	// close свёртки известен только к концу её интервала, поэтому берётся
+	// последняя строка, закончившаяся не позже at: сырая цена, наблюдённая
+	// до at, или час либо день, целиком лежащий до него
+	rows, err := s.db.Query(ctx, `
+        SELECT DISTINCT ON (coin_name) coin_name, close, bucket_end
+        FROM (
+            SELECT coin_name, close,
+                   bucket + CASE resolution
+                       WHEN 'hour' THEN INTERVAL '1 hour'
+                       WHEN 'day' THEN INTERVAL '1 day'
+                       ELSE INTERVAL '0'
+                   END AS bucket_end
+            FROM coins_history
+            WHERE coin_name = ANY($1) AND bucket <= $2
+        ) h
+        WHERE bucket_end <= $2
+        ORDER BY coin_name, bucket_end DESC
+    `, titles, at)
+	if err != nil {
+		logger.Error("Query failed",
+			slog.String("error", err.Error()),
+			slog.Duration("duration", time.Since(startTime)))
+		return nil, errors.Wrap(entities.ErrInternal, "failed to query coins at time")
+	}
+	defer rows.Close()
+
+	coins := make([]entities.Coin, 0, len(titles))
+	for rows.Next() {
+		var coin entities.Coin
+		if err = rows.Scan(&coin.CoinName, &coin.Price, &coin.ObservedAt); err != nil {
+			logger.Error("Row scan failed",
+				slog.String("error", err.Error()))
+			return nil, errors.Wrap(entities.ErrInternal, "failed to query coins at time")
+		}
+		coins = append(coins, coin)
+	}
+	if err = rows.Err(); err != nil {
+		logger.Error("Rows iteration failed",
+			slog.String("error", err.Error()))
+		return nil, errors.Wrap(entities.ErrInternal, "failed to query coins at time")
+	}
+
+	logger.Info("Coins at time retrieved",
+		slog.Int("count", len(coins)),
+		slog.Duration("duration", time.Since(startTime)))
+	return coins, nil
+}
+
+// Ping checks that the database is reachable.
+func (s *Storage) Ping(ctx context.Context) error {
+	if err := s.db.Ping(ctx); err != nil {
+		return errors.Wrap(err, "failed to ping database")
+	}
+	return nil
+}
+
+// Close releases the connection pool.
+func (s *Storage) Close() {
+	s.db.Close()
+}
@@ -0,0 +1,115 @@
+package postgres
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/pkg/errors"
+
+	"Cryptoproject/internal/entities"
+)
+
+func (s *Storage) StoreTickers(ctx context.Context, tickers []entities.Ticker) error {
+	const op = "postgres.StoreTickers"
+	logger := s.logger.With(
+		slog.String("op", op),
+		slog.Int("tickers_count", len(tickers)),
+	)
+
+	if len(tickers) == 0 {
+		return nil
+	}
+
+	now := time.Now()
+	batch := &pgx.Batch{}
+	for _, ticker := range tickers {
+		currency := ticker.Currency
+		if currency == "" {
+			currency = defaultCurrency
+		}
+		source := ticker.Source
+		if source == "" {
+			source = defaultSource
+		}
+		observed := ticker.ObservedAt
+		if observed.IsZero() {
+			observed = now
+		}
+
+		// более старый снимок не затирает свежий, если ответы пришли не по порядку
+		batch.Queue(`
+            INSERT INTO tickers (coin_name, currency, source, price, volume_24h, high_24h, low_24h,
+                                 change_pct_24h, market_cap, supply, observed_at, updated_at)
+            VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, NOW())
+            ON CONFLICT (coin_name, currency, source) DO UPDATE SET
+                price = EXCLUDED.price,
+                volume_24h = EXCLUDED.volume_24h,
+                high_24h = EXCLUDED.high_24h,
+                low_24h = EXCLUDED.low_24h,
+                change_pct_24h = EXCLUDED.change_pct_24h,
+                market_cap = EXCLUDED.market_cap,
+                supply = EXCLUDED.supply,
+                observed_at = EXCLUDED.observed_at,
+                updated_at = EXCLUDED.updated_at
+            WHERE tickers.observed_at <= EXCLUDED.observed_at
+        `, ticker.CoinName, currency, source, ticker.Price, ticker.Volume24h, ticker.High24h,
+			ticker.Low24h, ticker.Change24hPct, ticker.MarketCap, ticker.Supply, observed)
+	}
+
+	if err := s.db.SendBatch(ctx, batch).Close(); err != nil {
+		logger.Error("Upsert failed", slog.String("error", err.Error()))
+		return errors.Wrap(entities.ErrInternal, "failed to store tickers")
+	}
+
+	logger.Info("Tickers stored successfully")
+	return nil
+}
+
+func (s *Storage) GetTickers(ctx context.Context, titles []string) ([]entities.Ticker, error) {
+	const op = "postgres.GetTickers"
+	logger := s.logger.With(
+		slog.String("op", op),
+		slog.Int("titles_count", len(titles)),
+	)
+	startTime := time.Now()
+
+	rows, err := s.db.Query(ctx, `
+        SELECT coin_name, currency, source, price, volume_24h, high_24h, low_24h,
+               change_pct_24h, market_cap, supply, observed_at, updated_at
+        FROM tickers
+        WHERE COALESCE(cardinality($1::text[]), 0) = 0 OR coin_name = ANY($1)
+        ORDER BY coin_name
+    `, titles)
+	if err != nil {
+		logger.Error("Query failed",
+			slog.String("error", err.Error()),
+			slog.Duration("duration", time.Since(startTime)))
+		return nil, errors.Wrap(entities.ErrInternal, "failed to query tickers")
+	}
+	defer rows.Close()
+
+	tickers := make([]entities.Ticker, 0, len(titles))
+	for rows.Next() {
+		var ticker entities.Ticker
+		if err = rows.Scan(&ticker.CoinName, &ticker.Currency, &ticker.Source, &ticker.Price,
+			&ticker.Volume24h, &ticker.High24h, &ticker.Low24h, &ticker.Change24hPct,
+			&ticker.MarketCap, &ticker.Supply, &ticker.ObservedAt, &ticker.UpdatedAt); err != nil {
+			logger.Error("Row scan failed",
+				slog.String("error", err.Error()))
+			return nil, errors.Wrap(entities.ErrInternal, "failed to query tickers")
+		}
+		tickers = append(tickers, ticker)
+	}
+	if err = rows.Err(); err != nil {
+		logger.Error("Rows iteration failed",
+			slog.String("error", err.Error()))
+		return nil, errors.Wrap(entities.ErrInternal, "failed to query tickers")
+	}
+
+	logger.Info("Tickers retrieved",
+		slog.Int("count", len(tickers)),
+		slog.Duration("duration", time.Since(startTime)))
+	return tickers, nil
+}
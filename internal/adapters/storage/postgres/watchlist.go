@@ -0,0 +1,48 @@
+package postgres
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/pkg/errors"
+
+	"Cryptoproject/internal/entities"
+)
+
+func (s *Storage) AddToWatchlist(ctx context.Context, titles []string) (int, error) {
+	const op = "postgres.AddToWatchlist"
+	logger := s.logger.With(
+		slog.String("op", op),
+		slog.Any("titles", titles),
+	)
+
+	tag, err := s.db.Exec(ctx, `
+        INSERT INTO watchlist (coin_name)
+        SELECT unnest($1::text[])
+        ON CONFLICT (coin_name) DO NOTHING
+    `, titles)
+	if err != nil {
+		logger.Error("Insert failed", slog.String("error", err.Error()))
+		return 0, errors.Wrap(entities.ErrInternal, "failed to add coins to watchlist")
+	}
+
+	logger.Info("Coins added to watchlist", slog.Int64("added", tag.RowsAffected()))
+	return int(tag.RowsAffected()), nil
+}
+
+func (s *Storage) RemoveFromWatchlist(ctx context.Context, titles []string) (int, error) {
+	const op = "postgres.RemoveFromWatchlist"
+	logger := s.logger.With(
+		slog.String("op", op),
+		slog.Any("titles", titles),
+	)
+
+	tag, err := s.db.Exec(ctx, `DELETE FROM watchlist WHERE coin_name = ANY($1)`, titles)
+	if err != nil {
+		logger.Error("Delete failed", slog.String("error", err.Error()))
+		return 0, errors.Wrap(entities.ErrInternal, "failed to remove coins from watchlist")
+	}
+
+	logger.Info("Coins removed from watchlist", slog.Int64("removed", tag.RowsAffected()))
+	return int(tag.RowsAffected()), nil
+}
@@ -0,0 +1,182 @@
+package cases
+
+import (
+	"context"
+	"log/slog"
+	"math"
+	"slices"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"Cryptoproject/internal/entities"
+	"Cryptoproject/pkg/indicators"
+)
+
+const hoursPerYear = 365 * 24
+
+// GetVolatility returns the annualised realised volatility of each coin over
+// window, computed from log returns of interval buckets. Without titles all
+// tracked coins are used.
+func (s *Service) GetVolatility(ctx context.Context, titles []string, window, interval time.Duration) ([]entities.Volatility, error) {
+	const op = "cases.GetVolatility"
+	startTime := time.Now()
+	logger := s.logger.With(
+		slog.String("op", op),
+		slog.Int("titles_count", len(titles)),
+		slog.Duration("window", window),
+		slog.Duration("interval", interval))
+
+	if err := validateAnalyticsWindow(window, interval); err != nil {
+		logger.Warn("Validation failed", slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	if len(titles) == 0 {
+		var err error
+		if titles, err = s.storage.GetCoinsList(ctx); err != nil {
+			logger.Error("Failed to get coins list",
+				slog.String("error", err.Error()))
+			return nil, errors.Wrap(err, "failed to get coins list from storage")
+		}
+	}
+
+	periodsPerYear := float64(hoursPerYear*time.Hour) / float64(interval)
+	result := make([]entities.Volatility, 0, len(titles))
+	for _, title := range titles {
+		returns, err := s.bucketReturns(ctx, title, window, interval)
+		if err != nil {
+			logger.Error("Failed to get returns",
+				slog.String("title", title),
+				slog.String("error", err.Error()))
+			return nil, err
+		}
+
+		values := make([]float64, 0, len(returns))
+		for _, value := range returns {
+			values = append(values, value)
+		}
+		volatility := entities.Volatility{CoinName: title, Returns: len(values)}
+		// для одной доходности разброс не определён — оставляем 0
+		if len(values) >= 2 {
+			if volatility.Volatility, err = indicators.RealizedVolatility(values, periodsPerYear); err != nil {
+				return nil, errors.Wrap(entities.ErrInternal, err.Error())
+			}
+		}
+		result = append(result, volatility)
+	}
+
+	logger.Info("Volatility computed",
+		slog.Int("coins_count", len(result)),
+		slog.Duration("duration", time.Since(startTime)))
+	return result, nil
+}
+
+// GetCorrelation returns the correlation matrix of log returns of titles,
+// aligned on the buckets both coins of a pair have.
+func (s *Service) GetCorrelation(ctx context.Context, titles []string, window, interval time.Duration) (*entities.CorrelationMatrix, error) {
+	const op = "cases.GetCorrelation"
+	startTime := time.Now()
+	logger := s.logger.With(
+		slog.String("op", op),
+		slog.Int("titles_count", len(titles)),
+		slog.Duration("window", window),
+		slog.Duration("interval", interval))
+
+	titles = slices.Compact(slices.Sorted(slices.Values(titles)))
+	if len(titles) < 2 || len(titles) > entities.MaxCorrelationCoins {
+		err := errors.Wrapf(entities.ErrInvalidParam, "correlation needs between 2 and %d distinct titles",
+			entities.MaxCorrelationCoins)
+		logger.Warn("Validation failed", slog.String("error", err.Error()))
+		return nil, err
+	}
+	if err := validateAnalyticsWindow(window, interval); err != nil {
+		logger.Warn("Validation failed", slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	returns := make([]map[time.Time]float64, len(titles))
+	for i, title := range titles {
+		var err error
+		if returns[i], err = s.bucketReturns(ctx, title, window, interval); err != nil {
+			logger.Error("Failed to get returns",
+				slog.String("title", title),
+				slog.String("error", err.Error()))
+			return nil, err
+		}
+	}
+
+	matrix := &entities.CorrelationMatrix{
+		Titles:       titles,
+		Matrix:       make([][]float64, len(titles)),
+		Observations: make([][]int, len(titles)),
+	}
+	for i := range titles {
+		matrix.Matrix[i] = make([]float64, len(titles))
+		matrix.Observations[i] = make([]int, len(titles))
+	}
+	for i := range titles {
+		for j := i; j < len(titles); j++ {
+			a, b := alignReturns(returns[i], returns[j])
+			correlation := math.NaN()
+			if len(a) >= 2 {
+				correlation, _ = indicators.Correlation(a, b)
+			}
+			matrix.Matrix[i][j], matrix.Matrix[j][i] = correlation, correlation
+			matrix.Observations[i][j], matrix.Observations[j][i] = len(a), len(a)
+		}
+	}
+
+	logger.Info("Correlation computed",
+		slog.Int("coins_count", len(titles)),
+		slog.Duration("duration", time.Since(startTime)))
+	return matrix, nil
+}
+
+func validateAnalyticsWindow(window, interval time.Duration) error {
+	if interval <= 0 || window <= 0 {
+		return errors.Wrap(entities.ErrInvalidParam, "window and interval must be greater then 0")
+	}
+	if window < 2*interval {
+		return errors.Wrap(entities.ErrInvalidParam, "window must span at least two intervals")
+	}
+	return nil
+}
+
+// bucketReturns returns log returns of close prices keyed by bucket. A return
+// is only taken between adjacent buckets so gaps in history do not blend
+// several intervals into one return.
+func (s *Service) bucketReturns(ctx context.Context, title string, window, interval time.Duration) (map[time.Time]float64, error) {
+	since := s.now().Add(-window).Truncate(interval)
+	candles, err := s.storage.GetCandles(ctx, title, interval, since)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get candles of %s from storage", title)
+	}
+
+	returns := make(map[time.Time]float64, len(candles))
+	for i := 1; i < len(candles); i++ {
+		prev, next := candles[i-1], candles[i]
+		if next.Bucket.Sub(prev.Bucket) != interval || prev.Close <= 0 || next.Close <= 0 {
+			continue
+		}
+		returns[next.Bucket] = math.Log(next.Close / prev.Close)
+	}
+	return returns, nil
+}
+
+func alignReturns(a, b map[time.Time]float64) ([]float64, []float64) {
+	buckets := make([]time.Time, 0, len(a))
+	for bucket := range a {
+		if _, ok := b[bucket]; ok {
+			buckets = append(buckets, bucket)
+		}
+	}
+	slices.SortFunc(buckets, func(x, y time.Time) int { return x.Compare(y) })
+
+	alignedA := make([]float64, len(buckets))
+	alignedB := make([]float64, len(buckets))
+	for i, bucket := range buckets {
+		alignedA[i], alignedB[i] = a[bucket], b[bucket]
+	}
+	return alignedA, alignedB
+}
@@ -0,0 +1,115 @@
+package cases
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"Cryptoproject/internal/entities"
+)
+
+const (
+	// maxBatchCoins ограничивает число монет в одном пакетном запросе
+	maxBatchCoins = 100
+	// maxSummaryWindow — дальше дневные агрегаты всё равно не хранятся дольше
+	maxSummaryWindow = 5 * 365 * 24 * time.Hour
+	// maxRecentPricesWindow ограничивает выборку сырых цен: это до 1440 строк на монету в сутки
+	maxRecentPricesWindow = 24 * time.Hour
+)
+
+// GetSummaries returns the open, high, low, close and average price of each
+// coin over the last window, keyed by coin. Coins without prices in the
+// window are absent from the result.
+func (s *Service) GetSummaries(ctx context.Context, titles []string, window time.Duration) (map[string]entities.Candle, error) {
+	const op = "cases.GetSummaries"
+	logger := s.logger.With(
+		slog.String("op", op),
+		slog.Int("titles_count", len(titles)),
+		slog.Duration("window", window))
+
+	if err := validateBatch(titles, window, maxSummaryWindow); err != nil {
+		logger.Warn("Validation failed", slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	summaries, err := s.storage.GetSummaries(ctx, titles, s.now().Add(-window))
+	if err != nil {
+		logger.Error("Failed to get summaries", slog.String("error", err.Error()))
+		return nil, errors.Wrap(err, "failed to get summaries from storage")
+	}
+	return summaries, nil
+}
+
+// GetCandlesBatch returns candles of interval over the last window for each
+// coin, keyed by coin.
+func (s *Service) GetCandlesBatch(ctx context.Context, titles []string, interval, window time.Duration) (map[string][]entities.Candle, error) {
+	const op = "cases.GetCandlesBatch"
+	logger := s.logger.With(
+		slog.String("op", op),
+		slog.Int("titles_count", len(titles)),
+		slog.Duration("interval", interval),
+		slog.Duration("window", window))
+
+	if err := validateBatch(titles, window, maxSummaryWindow); err != nil {
+		logger.Warn("Validation failed", slog.String("error", err.Error()))
+		return nil, err
+	}
+	if interval <= 0 {
+		return nil, errors.Wrap(entities.ErrInvalidParam, "interval must be greater then 0")
+	}
+	if window/interval > maxHistoryCandles {
+		return nil, errors.Wrapf(entities.ErrInvalidParam, "window must not exceed %d candles of %s", maxHistoryCandles, interval)
+	}
+
+	candles, err := s.storage.GetCandlesBatch(ctx, titles, interval, s.now().Add(-window).Truncate(interval))
+	if err != nil {
+		logger.Error("Failed to get candles", slog.String("error", err.Error()))
+		return nil, errors.Wrap(err, "failed to get candles from storage")
+	}
+	return candles, nil
+}
+
+// GetRecentPrices returns the stored prices of each coin observed during the
+// last window, newest first, keyed by coin.
+func (s *Service) GetRecentPrices(ctx context.Context, titles []string, window time.Duration) (map[string][]entities.Coin, error) {
+	const op = "cases.GetRecentPrices"
+	logger := s.logger.With(
+		slog.String("op", op),
+		slog.Int("titles_count", len(titles)),
+		slog.Duration("window", window))
+
+	if err := validateBatch(titles, window, maxRecentPricesWindow); err != nil {
+		logger.Warn("Validation failed", slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	coins, err := s.storage.GetRecentCoins(ctx, titles, s.now().Add(-window))
+	if err != nil {
+		logger.Error("Failed to get recent prices", slog.String("error", err.Error()))
+		return nil, errors.Wrap(err, "failed to get recent prices from storage")
+	}
+
+	prices := make(map[string][]entities.Coin, len(titles))
+	for _, coin := range coins {
+		prices[coin.CoinName] = append(prices[coin.CoinName], coin)
+	}
+	return prices, nil
+}
+
+func validateBatch(titles []string, window, maxWindow time.Duration) error {
+	if len(titles) == 0 {
+		return errors.Wrap(entities.ErrInvalidParam, "titles list is empty")
+	}
+	if len(titles) > maxBatchCoins {
+		return errors.Wrapf(entities.ErrInvalidParam, "too many titles: %d (max %d)", len(titles), maxBatchCoins)
+	}
+	if window <= 0 {
+		return errors.Wrap(entities.ErrInvalidParam, "window must be greater then 0")
+	}
+	if window > maxWindow {
+		return errors.Wrapf(entities.ErrInvalidParam, "window must not exceed %s", maxWindow)
+	}
+	return nil
+}
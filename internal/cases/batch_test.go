@@ -0,0 +1,65 @@
+package cases_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"Cryptoproject/internal/cases"
+	"Cryptoproject/internal/cases/testdata"
+	"Cryptoproject/internal/entities"
+)
+
+func Test_GetRecentPrices_GroupsByCoin(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	observedAt := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	mockStorage := testdata.NewMockStorage(ctrl)
+	mockStorage.EXPECT().
+		GetRecentCoins(gomock.Any(), []string{"BTC", "ETH"}, gomock.Any()).
+		Return([]entities.Coin{
+			{CoinName: "BTC", Price: 30100, ObservedAt: observedAt},
+			{CoinName: "BTC", Price: 30000, ObservedAt: observedAt.Add(-time.Minute)},
+			{CoinName: "ETH", Price: 2000, ObservedAt: observedAt},
+		}, nil)
+
+	service, err := cases.NewService(mockStorage, testdata.NewMockCryptoProvider(ctrl), nil)
+	require.NoError(t, err)
+
+	prices, err := service.GetRecentPrices(context.Background(), []string{"BTC", "ETH"}, time.Hour)
+	require.NoError(t, err)
+	assert.Len(t, prices["BTC"], 2)
+	assert.Len(t, prices["ETH"], 1)
+}
+
+func Test_GetSummaries_Validation(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service, err := cases.NewService(testdata.NewMockStorage(ctrl), testdata.NewMockCryptoProvider(ctrl), nil)
+	require.NoError(t, err)
+
+	tooMany := make([]string, 101)
+	for i := range tooMany {
+		tooMany[i] = fmt.Sprintf("COIN%d", i)
+	}
+
+	_, err = service.GetSummaries(context.Background(), nil, time.Hour)
+	assert.ErrorIs(t, err, entities.ErrInvalidParam)
+	_, err = service.GetSummaries(context.Background(), tooMany, time.Hour)
+	assert.ErrorIs(t, err, entities.ErrInvalidParam)
+	_, err = service.GetSummaries(context.Background(), []string{"BTC"}, 0)
+	assert.ErrorIs(t, err, entities.ErrInvalidParam)
+	_, err = service.GetCandlesBatch(context.Background(), []string{"BTC"}, 5*time.Minute, 365*24*time.Hour)
+	assert.ErrorIs(t, err, entities.ErrInvalidParam)
+}
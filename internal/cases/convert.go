@@ -0,0 +1,128 @@
+package cases
+
+import (
+	"context"
+	"log/slog"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"Cryptoproject/internal/entities"
+)
+
+const (
+	defaultQuoteCurrency = "USD"
+	defaultMaxStaleness  = 5 * time.Minute
+)
+
+// WithQuoteCurrency sets the currency stored prices are quoted in.
+func WithQuoteCurrency(currency string) ServiceOption {
+	return func(s *Service) {
+		s.quoteCurrency = strings.ToUpper(currency)
+	}
+}
+
+// WithMaxStaleness sets how old a stored price may be to be used for conversion.
+func WithMaxStaleness(maxStaleness time.Duration) ServiceOption {
+	return func(s *Service) {
+		s.maxStaleness = maxStaleness
+	}
+}
+
+// Convert converts amount of from into to using the latest stored prices.
+// Both legs are quoted in the quote currency, so pairs without the quote
+// currency are triangulated through it.
+func (s *Service) Convert(ctx context.Context, from, to string, amount float64) (*entities.Conversion, error) {
+	const op = "cases.Convert"
+	startTime := time.Now()
+	from, to = strings.ToUpper(from), strings.ToUpper(to)
+	logger := s.logger.With(
+		slog.String("op", op),
+		slog.String("from", from),
+		slog.String("to", to))
+
+	if from == "" || to == "" {
+		err := errors.Wrap(entities.ErrInvalidParam, "from and to are required")
+		logger.Warn("Validation failed", slog.String("error", err.Error()))
+		return nil, err
+	}
+	if math.IsNaN(amount) || math.IsInf(amount, 0) || amount <= 0 {
+		err := errors.Wrap(entities.ErrInvalidParam, "amount must be a finite number greater then 0")
+		logger.Warn("Validation failed", slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	titles := make([]string, 0, 2)
+	for _, title := range []string{from, to} {
+		if title != s.quoteCurrency {
+			titles = append(titles, title)
+		}
+	}
+
+	legs := make(map[string]*entities.ConversionLeg, len(titles))
+	if len(titles) > 0 {
+		coins, err := s.storage.GetActualCoins(ctx, titles)
+		if err != nil {
+			logger.Error("Failed to get actual coins",
+				slog.String("error", err.Error()))
+			return nil, errors.Wrap(err, "failed to get actual coins from storage")
+		}
+		for _, coin := range coins {
+			legs[coin.CoinName] = &entities.ConversionLeg{
+				CoinName:   coin.CoinName,
+				Price:      coin.Price,
+				CreatedAt:  coin.CreatedAt,
+				ObservedAt: coin.ObservedAt,
+			}
+		}
+	}
+
+	now := s.now()
+	for _, title := range titles {
+		leg, ok := legs[title]
+		if !ok || leg.Price <= 0 {
+			err := errors.Wrapf(entities.ErrNotFound, "no stored price for %s", title)
+			logger.Warn("Missing leg", slog.String("error", err.Error()))
+			return nil, err
+		}
+		// поздно записанная старая котировка не должна выглядеть свежей
+		age := now.Sub(leg.PriceAt())
+		if age > s.maxStaleness {
+			err := errors.Wrapf(entities.ErrStaleData, "price of %s is %s old, limit is %s",
+				title, age.Truncate(time.Second), s.maxStaleness)
+			logger.Warn("Stale leg", slog.String("error", err.Error()))
+			return nil, err
+		}
+	}
+
+	conversion := &entities.Conversion{
+		From:    from,
+		To:      to,
+		Amount:  amount,
+		Rate:    1,
+		FromLeg: legs[from],
+		ToLeg:   legs[to],
+	}
+	if conversion.FromLeg != nil {
+		conversion.Rate *= conversion.FromLeg.Price
+	}
+	if conversion.ToLeg != nil {
+		conversion.Rate /= conversion.ToLeg.Price
+	}
+	if conversion.FromLeg != nil && conversion.ToLeg != nil {
+		conversion.Via = s.quoteCurrency
+	}
+	conversion.Result = amount * conversion.Rate
+	if math.IsInf(conversion.Result, 0) {
+		err := errors.Wrap(entities.ErrInvalidParam, "amount is too large to convert")
+		logger.Warn("Validation failed", slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	logger.Info("Conversion completed",
+		slog.Float64("rate", conversion.Rate),
+		slog.Duration("duration", time.Since(startTime)))
+	return conversion, nil
+}
@@ -2,6 +2,7 @@ package cases
 
 import (
 	"context"
+	"time"
 
 	"Cryptoproject/internal/entities"
 )
@@ -9,4 +10,7 @@ import (
 //go:generate mockgen -source=crypto_provider.go -destination=./testdata/crypto_provider.go -package=testdata
 type CryptoProvider interface {
 	GetActualRates(ctx context.Context, titles []string) ([]entities.Coin, error)
+	GetTickers(ctx context.Context, titles []string) ([]entities.Ticker, error)
+	// GetHistory returns hourly candles of a coin between from and to.
+	GetHistory(ctx context.Context, title string, from, to time.Time) ([]entities.Candle, error)
 }
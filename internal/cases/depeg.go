@@ -0,0 +1,267 @@
+package cases
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"Cryptoproject/internal/entities"
+)
+
+const (
+	defaultAlertsLimit = 100
+	maxAlertsLimit     = 1000
+)
+
+type DepegConfig struct {
+	// Stablecoins are the monitored coins with their pegs.
+	Stablecoins []entities.Stablecoin
+	// ThresholdBps is the absolute deviation from the peg, in basis points, that counts as a breach.
+	ThresholdBps float64
+	// SustainFor is how long a breach must last before a depeg alert is raised.
+	SustainFor time.Duration
+}
+
+// DepegMonitor tracks stablecoin prices against their pegs. The state is
+// derived from stored prices and alert events, so restarts do not lose or
+// duplicate alerts.
+type DepegMonitor struct {
+	storage DepegStorage
+	cfg     DepegConfig
+	logger  *slog.Logger
+	now     func() time.Time
+}
+
+func NewDepegMonitor(storage DepegStorage, cfg DepegConfig, logger *slog.Logger) (*DepegMonitor, error) {
+	const op = "cases.NewDepegMonitor"
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	if storage == nil {
+		err := errors.Wrap(entities.ErrInvalidParam, "depeg storage not set")
+		logger.Error(op, slog.String("error", err.Error()))
+		return nil, err
+	}
+	if len(cfg.Stablecoins) == 0 {
+		err := errors.Wrap(entities.ErrInvalidParam, "no stablecoins configured")
+		logger.Error(op, slog.String("error", err.Error()))
+		return nil, err
+	}
+	if cfg.ThresholdBps <= 0 || cfg.SustainFor <= 0 {
+		err := errors.Wrap(entities.ErrInvalidParam, "depeg threshold and sustain duration must be greater then 0")
+		logger.Error(op, slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	return &DepegMonitor{
+		storage: storage,
+		cfg:     cfg,
+		logger:  logger,
+		now:     time.Now,
+	}, nil
+}
+
+// CheckPegs raises a depeg alert for every stablecoin whose deviation stayed
+// beyond the threshold for SustainFor, and a recovery alert when a depegged
+// coin returns within the threshold.
+func (m *DepegMonitor) CheckPegs(ctx context.Context) error {
+	const op = "cases.CheckPegs"
+	startTime := time.Now()
+	logger := m.logger.With(slog.String("op", op))
+
+	statuses, err := m.GetDepegStatuses(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to get depeg statuses")
+	}
+
+	var raised int
+	for _, status := range statuses {
+		var event *entities.AlertEvent
+		switch {
+		case !status.Depegged && m.sustained(status):
+			event = &entities.AlertEvent{
+				Kind: entities.AlertDepegStarted,
+				Message: fmt.Sprintf("%s deviates %.1f bps from peg %g since %s",
+					status.CoinName, status.DeviationBps, status.Peg, status.BreachedSince.Format(time.RFC3339)),
+			}
+		case status.Depegged && status.BreachedSince == nil:
+			event = &entities.AlertEvent{
+				Kind: entities.AlertDepegRecovered,
+				Message: fmt.Sprintf("%s is back within %.1f bps of peg %g",
+					status.CoinName, m.cfg.ThresholdBps, status.Peg),
+			}
+		default:
+			continue
+		}
+
+		event.CoinName = status.CoinName
+		event.Price = status.Price
+		event.Value = status.DeviationBps
+		event.Threshold = m.cfg.ThresholdBps
+		if _, err = m.storage.StoreAlertEvent(ctx, *event); err != nil {
+			logger.Error("Failed to store alert event",
+				slog.String("coin", status.CoinName),
+				slog.String("error", err.Error()))
+			return errors.Wrap(err, "failed to store alert event")
+		}
+		raised++
+		logger.Warn("Alert raised",
+			slog.String("kind", string(event.Kind)),
+			slog.String("coin", status.CoinName),
+			slog.Float64("deviation_bps", status.DeviationBps))
+	}
+
+	logger.Info("Pegs checked",
+		slog.Int("stablecoins_count", len(statuses)),
+		slog.Int("alerts_raised", raised),
+		slog.Duration("duration", time.Since(startTime)))
+	return nil
+}
+
+func (m *DepegMonitor) sustained(status entities.DepegStatus) bool {
+	return status.BreachedSince != nil && status.ObservedAt.Sub(*status.BreachedSince) >= m.cfg.SustainFor
+}
+
+// GetDepegStatuses returns the current deviation of every monitored
+// stablecoin that has recent prices.
+func (m *DepegMonitor) GetDepegStatuses(ctx context.Context) ([]entities.DepegStatus, error) {
+	const op = "cases.GetDepegStatuses"
+	logger := m.logger.With(slog.String("op", op))
+
+	titles := make([]string, 0, len(m.cfg.Stablecoins))
+	for _, stablecoin := range m.cfg.Stablecoins {
+		titles = append(titles, stablecoin.CoinName)
+	}
+
+	// окно вдвое длиннее SustainFor, чтобы найти начало уже длящегося выхода за порог
+	since := m.now().Add(-2 * m.cfg.SustainFor)
+	recent, err := m.storage.GetRecentCoins(ctx, titles, since)
+	if err != nil {
+		logger.Error("Failed to get recent prices",
+			slog.String("error", err.Error()))
+		return nil, errors.Wrap(err, "failed to get recent prices from storage")
+	}
+	history := make(map[string][]entities.Coin, len(titles))
+	for _, coin := range recent {
+		history[coin.CoinName] = append(history[coin.CoinName], coin)
+	}
+
+	statuses := make([]entities.DepegStatus, 0, len(titles))
+	for _, stablecoin := range m.cfg.Stablecoins {
+		prices := history[stablecoin.CoinName]
+		if len(prices) == 0 {
+			logger.Warn("No recent prices for stablecoin",
+				slog.String("coin", stablecoin.CoinName))
+			continue
+		}
+
+		status := m.status(stablecoin, prices)
+		if status.Depegged, err = m.depegged(ctx, stablecoin.CoinName); err != nil {
+			logger.Error("Failed to get last alert",
+				slog.String("coin", stablecoin.CoinName),
+				slog.String("error", err.Error()))
+			return nil, errors.Wrap(err, "failed to get alert events from storage")
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses, nil
+}
+
+// status computes the deviation of the latest price and the start of the
+// current breach from prices ordered newest first.
+func (m *DepegMonitor) status(stablecoin entities.Stablecoin, prices []entities.Coin) entities.DepegStatus {
+	status := entities.DepegStatus{
+		CoinName:     stablecoin.CoinName,
+		Peg:          stablecoin.Peg,
+		Price:        prices[0].Price,
+		DeviationBps: entities.DeviationBps(prices[0].Price, stablecoin.Peg),
+		ObservedAt:   prices[0].ObservedAt,
+	}
+
+	for _, price := range prices {
+		if math.Abs(entities.DeviationBps(price.Price, stablecoin.Peg)) < m.cfg.ThresholdBps {
+			break
+		}
+		breachedSince := price.ObservedAt
+		status.BreachedSince = &breachedSince
+	}
+	return status
+}
+
+func (m *DepegMonitor) depegged(ctx context.Context, title string) (bool, error) {
+	events, err := m.storage.GetAlertEvents(ctx, entities.AlertFilter{
+		CoinName: title,
+		Kinds:    []entities.AlertKind{entities.AlertDepegStarted, entities.AlertDepegRecovered},
+		Limit:    1,
+	})
+	if err != nil {
+		return false, err
+	}
+	return len(events) > 0 && events[0].Kind == entities.AlertDepegStarted, nil
+}
+
+// GetDeviationHistory returns the peg deviation of a monitored stablecoin
+// at the close of every interval bucket over window.
+func (m *DepegMonitor) GetDeviationHistory(ctx context.Context, title string, window, interval time.Duration) ([]entities.DeviationPoint, error) {
+	const op = "cases.GetDeviationHistory"
+	logger := m.logger.With(
+		slog.String("op", op),
+		slog.String("title", title))
+
+	var stablecoin *entities.Stablecoin
+	for i := range m.cfg.Stablecoins {
+		if strings.EqualFold(m.cfg.Stablecoins[i].CoinName, title) {
+			stablecoin = &m.cfg.Stablecoins[i]
+		}
+	}
+	if stablecoin == nil {
+		return nil, errors.Wrapf(entities.ErrNotFound, "%s is not a monitored stablecoin", title)
+	}
+	if err := validateAnalyticsWindow(window, interval); err != nil {
+		return nil, err
+	}
+
+	candles, err := m.storage.GetCandles(ctx, stablecoin.CoinName, interval, m.now().Add(-window).Truncate(interval))
+	if err != nil {
+		logger.Error("Failed to get candles",
+			slog.String("error", err.Error()))
+		return nil, errors.Wrap(err, "failed to get candles from storage")
+	}
+
+	points := make([]entities.DeviationPoint, 0, len(candles))
+	for _, candle := range candles {
+		points = append(points, entities.DeviationPoint{
+			Time:         candle.Bucket,
+			Price:        candle.Close,
+			DeviationBps: entities.DeviationBps(candle.Close, stablecoin.Peg),
+		})
+	}
+	return points, nil
+}
+
+// GetAlerts returns alert events matching filter, newest first.
+func (m *DepegMonitor) GetAlerts(ctx context.Context, filter entities.AlertFilter) ([]entities.AlertEvent, error) {
+	const op = "cases.GetAlerts"
+	logger := m.logger.With(slog.String("op", op))
+
+	if filter.Limit == 0 {
+		filter.Limit = defaultAlertsLimit
+	}
+	if filter.Limit < 0 || filter.Limit > maxAlertsLimit {
+		return nil, errors.Wrapf(entities.ErrInvalidParam, "limit must be between 1 and %d", maxAlertsLimit)
+	}
+
+	events, err := m.storage.GetAlertEvents(ctx, filter)
+	if err != nil {
+		logger.Error("Failed to get alert events",
+			slog.String("error", err.Error()))
+		return nil, errors.Wrap(err, "failed to get alert events from storage")
+	}
+	return events, nil
+}
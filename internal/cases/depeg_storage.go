@@ -0,0 +1,20 @@
+package cases
+
+import (
+	"context"
+	"time"
+
+	"Cryptoproject/internal/entities"
+)
+
+//go:generate mockgen -source=depeg_storage.go -destination=./testdata/depeg_storage.go -package=testdata
+type DepegStorage interface {
+	// GetRecentCoins returns the prices of titles observed since the given time, newest first per coin.
+	GetRecentCoins(ctx context.Context, titles []string, since time.Time) ([]entities.Coin, error)
+	// GetCandles buckets the price history of a coin since the given time into candles of interval.
+	GetCandles(ctx context.Context, title string, interval time.Duration, since time.Time) ([]entities.Candle, error)
+	// StoreAlertEvent records an alert event and returns it with its ID and creation time.
+	StoreAlertEvent(ctx context.Context, event entities.AlertEvent) (*entities.AlertEvent, error)
+	// GetAlertEvents returns alert events matching filter, newest first.
+	GetAlertEvents(ctx context.Context, filter entities.AlertFilter) ([]entities.AlertEvent, error)
+}
@@ -0,0 +1,97 @@
+package cases_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"Cryptoproject/internal/cases"
+	"Cryptoproject/internal/cases/testdata"
+	"Cryptoproject/internal/entities"
+)
+
+var depegConfig = cases.DepegConfig{
+	Stablecoins:  []entities.Stablecoin{{CoinName: "USDC", Peg: 1}, {CoinName: "USDT", Peg: 1}},
+	ThresholdBps: 50,
+	SustainFor:   10 * time.Minute,
+}
+
+func Test_CheckPegs_RaisesSustainedDepeg(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	now := time.Now()
+	mockStorage := testdata.NewMockDepegStorage(ctrl)
+	mockStorage.EXPECT().
+		GetRecentCoins(gomock.Any(), []string{"USDC", "USDT"}, gomock.Any()).
+		Return([]entities.Coin{
+			// USDC вне порога 12 минут подряд
+			{CoinName: "USDC", Price: 0.97, ObservedAt: now},
+			{CoinName: "USDC", Price: 0.98, ObservedAt: now.Add(-6 * time.Minute)},
+			{CoinName: "USDC", Price: 0.99, ObservedAt: now.Add(-12 * time.Minute)},
+			{CoinName: "USDC", Price: 1, ObservedAt: now.Add(-18 * time.Minute)},
+			// USDT вышел за порог только что
+			{CoinName: "USDT", Price: 0.99, ObservedAt: now},
+			{CoinName: "USDT", Price: 0.999, ObservedAt: now.Add(-6 * time.Minute)},
+		}, nil)
+	mockStorage.EXPECT().GetAlertEvents(gomock.Any(), gomock.Any()).Return(nil, nil).Times(2)
+	mockStorage.EXPECT().
+		StoreAlertEvent(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, event entities.AlertEvent) (*entities.AlertEvent, error) {
+			assert.Equal(t, entities.AlertDepegStarted, event.Kind)
+			assert.Equal(t, "USDC", event.CoinName)
+			assert.InDelta(t, -300, event.Value, 1e-6)
+			assert.Equal(t, 50.0, event.Threshold)
+			event.ID = 1
+			return &event, nil
+		})
+
+	monitor, err := cases.NewDepegMonitor(mockStorage, depegConfig, nil)
+	require.NoError(t, err)
+	require.NoError(t, monitor.CheckPegs(context.Background()))
+}
+
+func Test_CheckPegs_RaisesRecovery(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	now := time.Now()
+	mockStorage := testdata.NewMockDepegStorage(ctrl)
+	mockStorage.EXPECT().
+		GetRecentCoins(gomock.Any(), gomock.Any(), gomock.Any()).
+		Return([]entities.Coin{
+			{CoinName: "USDC", Price: 0.998, ObservedAt: now},
+			{CoinName: "USDC", Price: 0.97, ObservedAt: now.Add(-6 * time.Minute)},
+		}, nil)
+	mockStorage.EXPECT().
+		GetAlertEvents(gomock.Any(), gomock.Any()).
+		Return([]entities.AlertEvent{{Kind: entities.AlertDepegStarted, CoinName: "USDC"}}, nil)
+	mockStorage.EXPECT().
+		StoreAlertEvent(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, event entities.AlertEvent) (*entities.AlertEvent, error) {
+			assert.Equal(t, entities.AlertDepegRecovered, event.Kind)
+			return &event, nil
+		})
+
+	monitor, err := cases.NewDepegMonitor(mockStorage, depegConfig, nil)
+	require.NoError(t, err)
+	require.NoError(t, monitor.CheckPegs(context.Background()))
+}
+
+func Test_NewDepegMonitor_Validation(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	_, err := cases.NewDepegMonitor(testdata.NewMockDepegStorage(ctrl), cases.DepegConfig{ThresholdBps: 50, SustainFor: time.Minute}, nil)
+	assert.ErrorIs(t, err, entities.ErrInvalidParam)
+}
@@ -0,0 +1,58 @@
+package cases
+
+import (
+	"context"
+	"log/slog"
+	"slices"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"Cryptoproject/internal/entities"
+)
+
+// Export validates query and streams the matching price history to fn.
+// Without titles all tracked coins are exported.
+func (s *Service) Export(ctx context.Context, query entities.ExportQuery, fn func(entities.HistoryRecord) error) error {
+	const op = "cases.Export"
+	startTime := time.Now()
+	logger := s.logger.With(
+		slog.String("op", op),
+		slog.Any("titles", query.Titles),
+		slog.Time("from", query.From),
+		slog.Time("to", query.To))
+
+	if query.From.IsZero() || !query.From.Before(query.To) {
+		err := errors.Wrap(entities.ErrInvalidParam, "from must be set and before to")
+		logger.Warn("Validation failed", slog.String("error", err.Error()))
+		return err
+	}
+	if len(query.Titles) > entities.MaxExportCoins {
+		err := errors.Wrapf(entities.ErrInvalidParam, "at most %d coins can be exported at once", entities.MaxExportCoins)
+		logger.Warn("Validation failed", slog.String("error", err.Error()))
+		return err
+	}
+
+	if len(query.Titles) == 0 {
+		titles, err := s.storage.GetCoinsList(ctx)
+		if err != nil {
+			logger.Error("Failed to get coins list", slog.String("error", err.Error()))
+			return errors.Wrap(err, "failed to get coins list from storage")
+		}
+		query.Titles = titles
+	} else {
+		query.Titles = slices.Clone(query.Titles)
+		slices.Sort(query.Titles)
+		query.Titles = slices.Compact(query.Titles)
+	}
+
+	if err := s.storage.StreamHistory(ctx, query, fn); err != nil {
+		logger.Error("Export failed",
+			slog.String("error", err.Error()),
+			slog.Duration("duration", time.Since(startTime)))
+		return errors.Wrap(err, "failed to export history")
+	}
+
+	logger.Info("Export completed", slog.Duration("duration", time.Since(startTime)))
+	return nil
+}
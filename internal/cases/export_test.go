@@ -0,0 +1,50 @@
+package cases_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"Cryptoproject/internal/cases"
+	"Cryptoproject/internal/cases/testdata"
+	"Cryptoproject/internal/entities"
+)
+
+func Test_Export(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStorage := testdata.NewMockStorage(ctrl)
+	mockCryptoProvider := testdata.NewMockCryptoProvider(ctrl)
+
+	from := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := from.Add(24 * time.Hour)
+	record := entities.HistoryRecord{CoinName: "BTC", Time: from, Resolution: "raw", Close: 94000, Count: 1}
+
+	mockStorage.EXPECT().GetCoinsList(gomock.Any()).Return([]string{"BTC", "ETH"}, nil)
+	mockStorage.EXPECT().
+		StreamHistory(gomock.Any(), entities.ExportQuery{Titles: []string{"BTC", "ETH"}, From: from, To: to}, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ entities.ExportQuery, fn func(entities.HistoryRecord) error) error {
+			return fn(record)
+		})
+
+	service, err := cases.NewService(mockStorage, mockCryptoProvider, nil)
+	require.NoError(t, err)
+
+	var exported []entities.HistoryRecord
+	err = service.Export(context.Background(), entities.ExportQuery{From: from, To: to}, func(record entities.HistoryRecord) error {
+		exported = append(exported, record)
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []entities.HistoryRecord{record}, exported)
+
+	err = service.Export(context.Background(), entities.ExportQuery{From: to, To: from}, nil)
+	assert.ErrorIs(t, err, entities.ErrInvalidParam)
+}
@@ -0,0 +1,91 @@
+package cases
+
+import (
+	"sort"
+
+	"github.com/pkg/errors"
+
+	"Cryptoproject/internal/entities"
+)
+
+// quantityEpsilon absorbs float rounding when a sell closes a lot exactly.
+const quantityEpsilon = 1e-12
+
+type lot struct {
+	quantity float64
+	unitCost float64 // цена покупки с учётом комиссии на единицу
+}
+
+type holding struct {
+	lots     []lot
+	realized float64
+}
+
+func (h *holding) quantity() float64 {
+	var total float64
+	for _, l := range h.lots {
+		total += l.quantity
+	}
+	return total
+}
+
+func (h *holding) costBasis() float64 {
+	var total float64
+	for _, l := range h.lots {
+		total += l.quantity * l.unitCost
+	}
+	return total
+}
+
+// buildHoldings replays transactions in execution order and matches sells
+// against the oldest open lots (FIFO). Buy fees are capitalised into the
+// lot cost, sell fees reduce the proceeds.
+func buildHoldings(transactions []entities.Transaction) (map[string]*holding, error) {
+	ordered := make([]entities.Transaction, len(transactions))
+	copy(ordered, transactions)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		if ordered[i].ExecutedAt.Equal(ordered[j].ExecutedAt) {
+			return ordered[i].ID < ordered[j].ID
+		}
+		return ordered[i].ExecutedAt.Before(ordered[j].ExecutedAt)
+	})
+
+	holdings := make(map[string]*holding)
+	for _, tx := range ordered {
+		h, ok := holdings[tx.CoinName]
+		if !ok {
+			h = &holding{}
+			holdings[tx.CoinName] = h
+		}
+
+		switch tx.Side {
+		case entities.SideBuy:
+			h.lots = append(h.lots, lot{
+				quantity: tx.Quantity,
+				unitCost: (tx.Quantity*tx.Price + tx.Fee) / tx.Quantity,
+			})
+		case entities.SideSell:
+			if tx.Quantity > h.quantity()+quantityEpsilon {
+				return nil, errors.Wrapf(entities.ErrInvalidParam,
+					"insufficient %s quantity to sell %v at %s",
+					tx.CoinName, tx.Quantity, tx.ExecutedAt.Format("2006-01-02T15:04:05Z07:00"))
+			}
+
+			remaining := tx.Quantity
+			var cost float64
+			for remaining > quantityEpsilon && len(h.lots) > 0 {
+				matched := min(remaining, h.lots[0].quantity)
+				cost += matched * h.lots[0].unitCost
+				remaining -= matched
+				h.lots[0].quantity -= matched
+				if h.lots[0].quantity <= quantityEpsilon {
+					h.lots = h.lots[1:]
+				}
+			}
+			h.realized += tx.Quantity*tx.Price - tx.Fee - cost
+		default:
+			return nil, errors.Wrapf(entities.ErrInvalidParam, "unknown side: %s", tx.Side)
+		}
+	}
+	return holdings, nil
+}
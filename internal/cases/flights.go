@@ -0,0 +1,76 @@
+package cases
+
+import (
+	"sync"
+
+	"Cryptoproject/internal/entities"
+)
+
+// titleFlights coalesces concurrent provider refreshes per title: a refresh
+// waits for the ones already in flight for some of its titles and fetches
+// only the rest, so overlapping requests such as BTC,ETH and BTC ask the
+// provider for BTC once.
+type titleFlights struct {
+	mu      sync.Mutex
+	flights map[string]*flight
+}
+
+// flight — одно обновление у провайдера; результат читается после закрытия done
+type flight struct {
+	done   chan struct{}
+	result refreshResult
+	err    error
+}
+
+// refreshResult — цены, полученные от провайдера и прочитанные из хранилища,
+// и котировки, отклонённые проверкой
+type refreshResult struct {
+	coins    []entities.Coin
+	rejected []entities.QuarantinedQuote
+}
+
+func newTitleFlights() *titleFlights {
+	return &titleFlights{flights: make(map[string]*flight)}
+}
+
+// join returns the flights already refreshing some of titles and registers a
+// new flight for the rest. own is nil when every title is already in flight.
+// A flight only waits for flights registered before it, so they cannot wait
+// for each other.
+func (f *titleFlights) join(titles []string) (waits []*flight, own *flight, rest []string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	seen := make(map[*flight]bool)
+	for _, title := range titles {
+		if fl, ok := f.flights[title]; ok {
+			if !seen[fl] {
+				seen[fl] = true
+				waits = append(waits, fl)
+			}
+			continue
+		}
+		rest = append(rest, title)
+	}
+	if len(rest) == 0 {
+		return waits, nil, nil
+	}
+
+	own = &flight{done: make(chan struct{})}
+	for _, title := range rest {
+		f.flights[title] = own
+	}
+	return waits, own, rest
+}
+
+// finish publishes the result of fl, which refreshed titles.
+func (f *titleFlights) finish(fl *flight, titles []string, result refreshResult, err error) {
+	f.mu.Lock()
+	for _, title := range titles {
+		delete(f.flights, title)
+	}
+	f.mu.Unlock()
+
+	fl.result, fl.err = result, err
+	close(fl.done)
+}
@@ -0,0 +1,73 @@
+package cases
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"Cryptoproject/internal/entities"
+)
+
+// maxHistoryCandles ограничивает ответ, чтобы мелкий интервал на длинном диапазоне не выбирал всю таблицу.
+const maxHistoryCandles = 5000
+
+// GetHistory returns candles of the coin bucketed by interval in [from, to).
+// A zero to means now.
+func (s *Service) GetHistory(ctx context.Context, title string, interval time.Duration, from, to time.Time) ([]entities.Candle, error) {
+	const op = "cases.GetHistory"
+	startTime := time.Now()
+	logger := s.logger.With(
+		slog.String("op", op),
+		slog.String("title", title),
+		slog.Duration("interval", interval),
+		slog.Time("from", from),
+		slog.Time("to", to))
+
+	if to.IsZero() {
+		to = s.now()
+	}
+	if err := validateHistoryQuery(title, interval, from, to); err != nil {
+		logger.Warn("Validation failed", slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	candles, err := s.storage.GetCandles(ctx, title, interval, from.Truncate(interval))
+	if err != nil {
+		logger.Error("Failed to get candles",
+			slog.String("error", err.Error()),
+			slog.Duration("duration", time.Since(startTime)))
+		return nil, errors.Wrap(err, "failed to get candles from storage")
+	}
+	for i, candle := range candles {
+		if !candle.Bucket.Before(to) {
+			candles = candles[:i]
+			break
+		}
+	}
+	if len(candles) == 0 {
+		return nil, errors.Wrapf(entities.ErrNotFound, "no price history for %s", title)
+	}
+
+	logger.Info("History loaded",
+		slog.Int("candles_count", len(candles)),
+		slog.Duration("duration", time.Since(startTime)))
+	return candles, nil
+}
+
+func validateHistoryQuery(title string, interval time.Duration, from, to time.Time) error {
+	if title == "" {
+		return errors.Wrap(entities.ErrInvalidParam, "title is required")
+	}
+	if interval <= 0 {
+		return errors.Wrap(entities.ErrInvalidParam, "interval must be greater then 0")
+	}
+	if !from.Before(to) {
+		return errors.Wrap(entities.ErrInvalidParam, "from must be before to")
+	}
+	if to.Sub(from)/interval > maxHistoryCandles {
+		return errors.Wrapf(entities.ErrInvalidParam, "range must not exceed %d candles of %s", maxHistoryCandles, interval)
+	}
+	return nil
+}
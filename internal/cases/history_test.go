@@ -0,0 +1,70 @@
+package cases_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"Cryptoproject/internal/cases"
+	"Cryptoproject/internal/cases/testdata"
+	"Cryptoproject/internal/entities"
+)
+
+func Test_GetHistory(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	from := time.Date(2024, 5, 1, 0, 30, 0, 0, time.UTC)
+	to := from.Add(3 * time.Hour)
+	candles := make([]entities.Candle, 0, 5)
+	for i := range 5 {
+		candles = append(candles, entities.Candle{Bucket: from.Truncate(time.Hour).Add(time.Duration(i) * time.Hour)})
+	}
+
+	mockStorage := testdata.NewMockStorage(ctrl)
+	mockStorage.EXPECT().
+		GetCandles(gomock.Any(), "BTC", time.Hour, from.Truncate(time.Hour)).
+		Return(candles, nil)
+
+	service, err := cases.NewService(mockStorage, testdata.NewMockCryptoProvider(ctrl), nil)
+	require.NoError(t, err)
+
+	history, err := service.GetHistory(context.Background(), "BTC", time.Hour, from, to)
+	require.NoError(t, err)
+	assert.Equal(t, candles[:4], history)
+}
+
+func Test_GetHistory_Validation(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service, err := cases.NewService(testdata.NewMockStorage(ctrl), testdata.NewMockCryptoProvider(ctrl), nil)
+	require.NoError(t, err)
+
+	from := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name     string
+		title    string
+		interval time.Duration
+		to       time.Time
+	}{
+		{"empty title", "", time.Hour, from.Add(time.Hour)},
+		{"zero interval", "BTC", 0, from.Add(time.Hour)},
+		{"to before from", "BTC", time.Hour, from.Add(-time.Hour)},
+		{"too many candles", "BTC", 5 * time.Minute, from.Add(365 * 24 * time.Hour)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := service.GetHistory(context.Background(), tt.title, tt.interval, from, tt.to)
+			assert.ErrorIs(t, err, entities.ErrInvalidParam)
+		})
+	}
+}
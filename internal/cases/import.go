@@ -0,0 +1,160 @@
+package cases
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"math"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/pkg/errors"
+
+	"Cryptoproject/internal/entities"
+)
+
+const (
+	importBatchSize = 5000
+	// importSource помечает цены без источника, чтобы отличать их от котировок провайдера
+	importSource = "import"
+	// importMaxSkew допускает небольшое расхождение часов источника
+	importMaxSkew = time.Minute
+)
+
+// Import validates the coins read from source and stores them in batches.
+// Invalid lines are reported and skipped; an error is returned only when the
+// source itself cannot be read or storage fails, together with the report so far.
+//
+// Prices older than the retention horizon are stored as hourly or daily
+// aggregates. Batches are cut at day boundaries, so input sorted by coin and
+// time keeps every bucket in one batch; otherwise the part of a bucket imported
+// later is skipped as already stored.
+func (s *Service) Import(ctx context.Context, source entities.CoinReader) (*entities.ImportReport, error) {
+	const op = "cases.Import"
+	startTime := time.Now()
+	logger := s.logger.With(slog.String("op", op))
+
+	report := &entities.ImportReport{}
+	batch := make([]entities.Coin, 0, importBatchSize)
+	flush := func(coins []entities.Coin) error {
+		stored, err := s.storage.ImportCoins(ctx, coins)
+		if err != nil {
+			return errors.Wrap(err, "failed to import coins")
+		}
+		report.Imported += stored.Inserted
+		report.Skipped += stored.Skipped
+		return nil
+	}
+
+	for {
+		coin, err := source.Next()
+		if err == io.EOF {
+			break
+		}
+		var lineErr *entities.LineError
+		if errors.As(err, &lineErr) {
+			report.Lines++
+			report.Reject(*lineErr)
+			continue
+		}
+		if err != nil {
+			logger.Error("Failed to read import source",
+				slog.Int("line", source.Line()),
+				slog.String("error", err.Error()))
+			return report, errors.Wrap(err, "failed to read import source")
+		}
+
+		report.Lines++
+		if coin, err = s.validateImported(coin); err != nil {
+			report.Reject(entities.LineError{Line: source.Line(), Err: err})
+			continue
+		}
+		if coin.Source == "" {
+			coin.Source = importSource
+		}
+		batch = append(batch, coin)
+
+		if len(batch) >= importBatchSize {
+			cut := dayBoundary(batch)
+			if err = flush(batch[:cut]); err != nil {
+				logger.Error("Failed to store batch", slog.String("error", err.Error()))
+				return report, err
+			}
+			batch = append(batch[:0], batch[cut:]...)
+		}
+	}
+	if len(batch) > 0 {
+		if err := flush(batch); err != nil {
+			logger.Error("Failed to store batch", slog.String("error", err.Error()))
+			return report, err
+		}
+	}
+
+	logger.Info("Import completed",
+		slog.Int("lines", report.Lines),
+		slog.Int("imported", report.Imported),
+		slog.Int("skipped", report.Skipped),
+		slog.Int("rejected", report.Rejected),
+		slog.Duration("duration", time.Since(startTime)))
+	return report, nil
+}
+
+// validateImported checks a line against the coins table limits, so one bad
+// line is rejected instead of failing the whole batch in storage, and
+// normalizes the coin symbol and currency. Only prices in the quote currency
+// are accepted, since reads do not tell currencies apart.
+func (s *Service) validateImported(coin entities.Coin) (entities.Coin, error) {
+	title, err := entities.NormalizeTitle(coin.CoinName)
+	if err != nil {
+		return coin, err
+	}
+	coin.CoinName = title
+	if math.IsNaN(coin.Price) || math.IsInf(coin.Price, 0) {
+		return coin, errors.Wrap(entities.ErrInvalidParam, "price must be a finite number")
+	}
+	if _, err = entities.NewCoin(coin.CoinName, coin.Price, coin.ObservedAt); err != nil {
+		return coin, err
+	}
+	if coin.Price < entities.MinPrice || coin.Price >= entities.MaxPrice {
+		return coin, errors.Wrapf(entities.ErrInvalidParam, "price %g is outside the stored range [%g, %g)",
+			coin.Price, entities.MinPrice, entities.MaxPrice)
+	}
+	if coin.ObservedAt.After(s.now().Add(importMaxSkew)) {
+		return coin, errors.Wrap(entities.ErrInvalidParam, "observation time is in the future")
+	}
+	// цены читаются без учёта валюты, поэтому принимаются только цены в валюте котировок
+	currency := strings.ToUpper(strings.TrimSpace(coin.Currency))
+	if currency == "" {
+		currency = s.quoteCurrency
+	}
+	if currency != s.quoteCurrency {
+		return coin, errors.Wrapf(entities.ErrInvalidParam, "currency %q differs from the quote currency %s",
+			coin.Currency, s.quoteCurrency)
+	}
+	coin.Currency = currency
+	if utf8.RuneCountInString(coin.Source) > entities.MaxSourceLength {
+		return coin, errors.Wrapf(entities.ErrInvalidParam, "source is longer than %d characters", entities.MaxSourceLength)
+	}
+	return coin, nil
+}
+
+// dayBoundary возвращает индекс, с которого начинаются цены последнего дня последней монеты
+// партии: они переносятся в следующую партию, чтобы дневная корзина не делилась между партиями.
+func dayBoundary(batch []entities.Coin) int {
+	last := batch[len(batch)-1]
+	lastDay := last.ObservedAt.UTC().Truncate(24 * time.Hour)
+	cut := len(batch)
+	for cut > 0 {
+		coin := batch[cut-1]
+		if coin.CoinName != last.CoinName || !coin.ObservedAt.UTC().Truncate(24*time.Hour).Equal(lastDay) {
+			break
+		}
+		cut--
+	}
+	if cut == 0 {
+		// вся партия — один день одной монеты, делить её негде
+		return len(batch)
+	}
+	return cut
+}
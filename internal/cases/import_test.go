@@ -0,0 +1,135 @@
+package cases_test
+
+import (
+	"context"
+	"io"
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"Cryptoproject/internal/cases"
+	"Cryptoproject/internal/cases/testdata"
+	"Cryptoproject/internal/entities"
+)
+
+type sliceSource struct {
+	items []any // entities.Coin или error
+	line  int
+}
+
+func (s *sliceSource) Next() (entities.Coin, error) {
+	if s.line >= len(s.items) {
+		return entities.Coin{}, io.EOF
+	}
+	item := s.items[s.line]
+	s.line++
+	if err, ok := item.(error); ok {
+		return entities.Coin{}, err
+	}
+	return item.(entities.Coin), nil
+}
+
+func (s *sliceSource) Line() int {
+	return s.line
+}
+
+func Test_Import_ReportsLineErrors(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStorage := testdata.NewMockStorage(ctrl)
+	mockCryptoProvider := testdata.NewMockCryptoProvider(ctrl)
+
+	observedAt := time.Date(2020, 1, 1, 12, 0, 0, 0, time.UTC)
+	source := &sliceSource{items: []any{
+		entities.Coin{CoinName: "BTC", Price: 7200, ObservedAt: observedAt},
+		&entities.LineError{Line: 2, Err: errors.New("invalid price \"abc\"")},
+		entities.Coin{CoinName: "BTC", Price: -1, ObservedAt: observedAt},
+		entities.Coin{CoinName: "BTC", Price: 1, ObservedAt: time.Now().Add(time.Hour)},
+		entities.Coin{CoinName: "eth", Price: 130, Source: "legacy", ObservedAt: observedAt},
+		// строки, которые не поместились бы в coins, отклоняются до записи
+		entities.Coin{CoinName: "BTC", Price: math.NaN(), ObservedAt: observedAt},
+		entities.Coin{CoinName: "BTC", Price: math.Inf(1), ObservedAt: observedAt},
+		entities.Coin{CoinName: "BTC/USD", Price: 7200, ObservedAt: observedAt},
+		entities.Coin{CoinName: "BTC", Price: 7200, Currency: "EUR", ObservedAt: observedAt},
+		entities.Coin{CoinName: "BTC", Price: 7200, Source: strings.Repeat("x", 51), ObservedAt: observedAt},
+		entities.Coin{CoinName: "BTC", Price: 1e14, ObservedAt: observedAt},
+		entities.Coin{CoinName: "BTC", Price: 1e-11, ObservedAt: observedAt},
+		entities.Coin{CoinName: "ETH", Price: 131, Currency: "usd", ObservedAt: observedAt.Add(time.Hour)},
+	}}
+
+	mockStorage.EXPECT().
+		ImportCoins(gomock.Any(), []entities.Coin{
+			{CoinName: "BTC", Price: 7200, Currency: "USD", Source: "import", ObservedAt: observedAt},
+			{CoinName: "ETH", Price: 130, Currency: "USD", Source: "legacy", ObservedAt: observedAt},
+			{CoinName: "ETH", Price: 131, Currency: "USD", Source: "import", ObservedAt: observedAt.Add(time.Hour)},
+		}).
+		Return(entities.StoreResult{Inserted: 2, Skipped: 1}, nil)
+
+	service, err := cases.NewService(mockStorage, mockCryptoProvider, nil)
+	require.NoError(t, err)
+
+	report, err := service.Import(context.Background(), source)
+	require.NoError(t, err)
+	assert.Equal(t, 13, report.Lines)
+	assert.Equal(t, 2, report.Imported)
+	assert.Equal(t, 1, report.Skipped)
+	assert.Equal(t, 10, report.Rejected)
+	require.Len(t, report.Errors, 10)
+	assert.Equal(t, 2, report.Errors[0].Line)
+	assert.Equal(t, 3, report.Errors[1].Line)
+	assert.ErrorIs(t, report.Errors[1].Err, entities.ErrInvalidParam)
+	assert.Contains(t, report.Errors[2].Error(), "line 4: observation time is in the future")
+	assert.Contains(t, report.Errors[3].Error(), "line 6: price must be a finite number")
+	assert.Contains(t, report.Errors[4].Error(), "line 7: price must be a finite number")
+	assert.Contains(t, report.Errors[5].Error(), `line 8: invalid title: "BTC/USD"`)
+	assert.Contains(t, report.Errors[6].Error(), `line 9: currency "EUR" differs from the quote currency USD`)
+	assert.Contains(t, report.Errors[7].Error(), "line 10: source is longer than 50 characters")
+	assert.Contains(t, report.Errors[8].Error(), "line 11: price 1e+14 is outside the stored range")
+	assert.Contains(t, report.Errors[9].Error(), "line 12: price 1e-11 is outside the stored range")
+}
+
+func Test_Import_BatchesAtDayBoundary(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStorage := testdata.NewMockStorage(ctrl)
+	mockCryptoProvider := testdata.NewMockCryptoProvider(ctrl)
+
+	start := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	source := &sliceSource{}
+	for i := 0; i < 5003; i++ {
+		source.items = append(source.items, entities.Coin{CoinName: "BTC", Price: 7200, ObservedAt: start.Add(time.Duration(i) * time.Minute)})
+	}
+
+	var batches [][2]time.Time
+	mockStorage.EXPECT().
+		ImportCoins(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, coins []entities.Coin) (entities.StoreResult, error) {
+			batches = append(batches, [2]time.Time{coins[0].ObservedAt, coins[len(coins)-1].ObservedAt})
+			return entities.StoreResult{Inserted: len(coins)}, nil
+		}).
+		Times(2)
+
+	service, err := cases.NewService(mockStorage, mockCryptoProvider, nil)
+	require.NoError(t, err)
+
+	report, err := service.Import(context.Background(), source)
+	require.NoError(t, err)
+	assert.Equal(t, 5003, report.Imported)
+
+	// 5000 цен — это почти 3,5 дня; неполный четвёртый день уходит во вторую партию
+	dayFour := start.Add(3 * 24 * time.Hour)
+	assert.Equal(t, [2]time.Time{start, dayFour.Add(-time.Minute)}, batches[0])
+	assert.Equal(t, [2]time.Time{dayFour, start.Add(5002 * time.Minute)}, batches[1])
+}
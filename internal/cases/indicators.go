@@ -0,0 +1,157 @@
+package cases
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"Cryptoproject/internal/entities"
+	"Cryptoproject/pkg/indicators"
+)
+
+// GetIndicator computes a technical indicator over the close prices of the
+// coin bucketed by query.Interval and returns up to query.Limit latest points.
+func (s *Service) GetIndicator(ctx context.Context, query entities.IndicatorQuery) (*entities.IndicatorSeries, error) {
+	const op = "cases.GetIndicator"
+	startTime := time.Now()
+	logger := s.logger.With(
+		slog.String("op", op),
+		slog.String("title", query.CoinName),
+		slog.String("type", string(query.Type)),
+		slog.Int("period", query.Period),
+		slog.Duration("interval", query.Interval))
+
+	if query.Period == 0 || query.Type == entities.IndicatorMACD {
+		query.Period = query.Type.DefaultPeriod()
+	}
+	if query.Limit == 0 {
+		query.Limit = entities.DefaultIndicatorPoints
+	}
+	if err := validateIndicatorQuery(query); err != nil {
+		logger.Warn("Validation failed", slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	// запрашиваем с запасом на разогрев индикатора
+	buckets := query.Limit + query.Type.Warmup(query.Period)
+	since := s.now().Add(-time.Duration(buckets) * query.Interval).Truncate(query.Interval)
+	candles, err := s.storage.GetCandles(ctx, query.CoinName, query.Interval, since)
+	if err != nil {
+		logger.Error("Failed to get candles",
+			slog.String("error", err.Error()),
+			slog.Duration("duration", time.Since(startTime)))
+		return nil, errors.Wrap(err, "failed to get candles from storage")
+	}
+	if len(candles) == 0 {
+		return nil, errors.Wrapf(entities.ErrNotFound, "no price history for %s", query.CoinName)
+	}
+
+	points, err := computeIndicator(query, candles)
+	if err != nil {
+		logger.Warn("Failed to compute indicator",
+			slog.Int("candles_count", len(candles)),
+			slog.String("error", err.Error()))
+		return nil, err
+	}
+	if len(points) > query.Limit {
+		points = points[len(points)-query.Limit:]
+	}
+
+	logger.Info("Indicator computed",
+		slog.Int("candles_count", len(candles)),
+		slog.Int("points_count", len(points)),
+		slog.Duration("duration", time.Since(startTime)))
+	return &entities.IndicatorSeries{
+		CoinName: query.CoinName,
+		Type:     query.Type,
+		Period:   query.Period,
+		Interval: query.Interval,
+		Points:   points,
+	}, nil
+}
+
+func validateIndicatorQuery(query entities.IndicatorQuery) error {
+	if query.CoinName == "" {
+		return errors.Wrap(entities.ErrInvalidParam, "title is required")
+	}
+	if query.Interval <= 0 {
+		return errors.Wrap(entities.ErrInvalidParam, "interval must be greater then 0")
+	}
+	if query.Period < 1 || query.Period > entities.MaxIndicatorPeriod {
+		return errors.Wrapf(entities.ErrInvalidParam, "period must be between 1 and %d", entities.MaxIndicatorPeriod)
+	}
+	if query.Limit < 1 || query.Limit > entities.MaxIndicatorPoints {
+		return errors.Wrapf(entities.ErrInvalidParam, "limit must be between 1 and %d", entities.MaxIndicatorPoints)
+	}
+	return nil
+}
+
+func computeIndicator(query entities.IndicatorQuery, candles []entities.Candle) ([]entities.IndicatorPoint, error) {
+	closes := make([]float64, len(candles))
+	for i, candle := range candles {
+		closes[i] = candle.Close
+	}
+
+	var (
+		lines []map[string]float64
+		err   error
+	)
+	switch query.Type {
+	case entities.IndicatorSMA, entities.IndicatorEMA, entities.IndicatorRSI:
+		var values []float64
+		switch query.Type {
+		case entities.IndicatorSMA:
+			values, err = indicators.SMA(closes, query.Period)
+		case entities.IndicatorEMA:
+			values, err = indicators.EMA(closes, query.Period)
+		default:
+			values, err = indicators.RSI(closes, query.Period)
+		}
+		for _, value := range values {
+			lines = append(lines, map[string]float64{"value": value})
+		}
+	case entities.IndicatorBollinger:
+		var bands []indicators.Band
+		bands, err = indicators.Bollinger(closes, query.Period, entities.BollingerDeviations)
+		for _, band := range bands {
+			lines = append(lines, map[string]float64{
+				"upper":  band.Upper,
+				"middle": band.Middle,
+				"lower":  band.Lower,
+			})
+		}
+	case entities.IndicatorMACD:
+		var macd []indicators.MACDPoint
+		macd, err = indicators.MACD(closes, entities.MACDFastPeriod, entities.MACDSlowPeriod, entities.MACDSignalPeriod)
+		for _, point := range macd {
+			lines = append(lines, map[string]float64{
+				"macd":      point.MACD,
+				"signal":    point.Signal,
+				"histogram": point.Histogram,
+			})
+		}
+	default:
+		return nil, errors.Wrapf(entities.ErrInvalidParam, "unsupported indicator: %q", query.Type)
+	}
+	if errors.Is(err, indicators.ErrNotEnoughData) {
+		return nil, errors.Wrapf(entities.ErrNotFound, "not enough price history for %s: %v", query.CoinName, err)
+	}
+	if err != nil {
+		return nil, errors.Wrap(entities.ErrInvalidParam, err.Error())
+	}
+
+	// значения индикатора выровнены по последним свечам
+	offset := len(candles) - len(lines)
+	points := make([]entities.IndicatorPoint, len(lines))
+	for i, values := range lines {
+		candle := candles[offset+i]
+		points[i] = entities.IndicatorPoint{
+			Time:   candle.Bucket,
+			Close:  candle.Close,
+			Values: values,
+		}
+	}
+	return points, nil
+}
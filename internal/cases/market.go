@@ -0,0 +1,53 @@
+package cases
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"Cryptoproject/internal/entities"
+)
+
+const maxMoversLimit = 100
+
+// GetMarketMovers ranks watchlist coins by their price change over window and
+// returns the top gainers, losers and most volatile coins, limit of each.
+func (s *Service) GetMarketMovers(ctx context.Context, window time.Duration, limit int) (*entities.MarketOverview, error) {
+	const op = "cases.GetMarketMovers"
+	startTime := time.Now()
+	logger := s.logger.With(
+		slog.String("op", op),
+		slog.Duration("window", window),
+		slog.Int("limit", limit))
+
+	if window <= 0 {
+		err := errors.Wrap(entities.ErrInvalidParam, "window must be greater then 0")
+		logger.Warn("Validation failed", slog.String("error", err.Error()))
+		return nil, err
+	}
+	if limit <= 0 || limit > maxMoversLimit {
+		err := errors.Wrapf(entities.ErrInvalidParam, "limit must be between 1 and %d", maxMoversLimit)
+		logger.Warn("Validation failed", slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	since := s.now().Add(-window)
+	overview, err := s.storage.GetMarketMovers(ctx, s.quoteCurrency, since, limit)
+	if err != nil {
+		logger.Error("Failed to get market movers",
+			slog.String("error", err.Error()),
+			slog.Duration("duration", time.Since(startTime)))
+		return nil, errors.Wrap(err, "failed to get market movers from storage")
+	}
+	overview.Window = window
+	overview.Since = since
+
+	logger.Info("Market movers computed",
+		slog.Int("up", overview.Up),
+		slog.Int("down", overview.Down),
+		slog.Int("unchanged", overview.Unchanged),
+		slog.Duration("duration", time.Since(startTime)))
+	return overview, nil
+}
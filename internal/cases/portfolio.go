@@ -0,0 +1,242 @@
+package cases
+
+import (
+	"context"
+	"log/slog"
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"Cryptoproject/internal/entities"
+)
+
+type PortfolioService struct {
+	portfolios PortfolioStorage
+	prices     Storage
+	logger     *slog.Logger
+	now        func() time.Time
+}
+
+func NewPortfolioService(portfolios PortfolioStorage, prices Storage, logger *slog.Logger) (*PortfolioService, error) {
+	const op = "cases.NewPortfolioService"
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	if portfolios == nil {
+		err := errors.Wrap(entities.ErrInvalidParam, "portfolio storage not set")
+		logger.Error(op, slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	if prices == nil {
+		err := errors.Wrap(entities.ErrInvalidParam, "price storage not set")
+		logger.Error(op, slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	logger.Info("Portfolio service initialized Successfully")
+	return &PortfolioService{
+		portfolios: portfolios,
+		prices:     prices,
+		logger:     logger,
+		now:        time.Now,
+	}, nil
+}
+
+func (s *PortfolioService) CreatePortfolio(ctx context.Context, name string) (*entities.Portfolio, error) {
+	const op = "cases.CreatePortfolio"
+	logger := s.logger.With(slog.String("op", op))
+
+	portfolio, err := entities.NewPortfolio(name)
+	if err != nil {
+		logger.Warn("Validation failed", slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	created, err := s.portfolios.CreatePortfolio(ctx, *portfolio)
+	if err != nil {
+		logger.Error("Failed to create portfolio", slog.String("error", err.Error()))
+		return nil, errors.Wrap(err, "failed to create portfolio")
+	}
+
+	logger.Info("Portfolio created", slog.Int64("portfolio_id", created.ID))
+	return created, nil
+}
+
+func (s *PortfolioService) GetPortfolio(ctx context.Context, id int64) (*entities.Portfolio, error) {
+	portfolio, err := s.portfolios.GetPortfolio(ctx, id)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get portfolio")
+	}
+	return portfolio, nil
+}
+
+func (s *PortfolioService) ListPortfolios(ctx context.Context) ([]entities.Portfolio, error) {
+	portfolios, err := s.portfolios.ListPortfolios(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list portfolios")
+	}
+	return portfolios, nil
+}
+
+// AddTransaction records a buy or sell. Sells are rejected when the
+// portfolio would hold a negative quantity at any point in time.
+func (s *PortfolioService) AddTransaction(ctx context.Context, tx entities.Transaction) (*entities.Transaction, error) {
+	const op = "cases.AddTransaction"
+	logger := s.logger.With(
+		slog.String("op", op),
+		slog.Int64("portfolio_id", tx.PortfolioID))
+
+	transaction, err := entities.NewTransaction(tx.PortfolioID, tx.CoinName, tx.Side,
+		tx.Quantity, tx.Price, tx.Fee, tx.ExecutedAt)
+	if err != nil {
+		logger.Warn("Validation failed", slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	// проверка остатка выполняется в транзакции вставки: иначе две
+	// одновременные продажи могли бы пройти её обе
+	var check func([]entities.Transaction) error
+	if transaction.Side == entities.SideSell {
+		check = func(existing []entities.Transaction) error {
+			_, err := buildHoldings(append(existing, *transaction))
+			return err
+		}
+	}
+
+	created, err := s.portfolios.AddTransaction(ctx, *transaction, check)
+	if err != nil {
+		if errors.Is(err, entities.ErrInvalidParam) || errors.Is(err, entities.ErrNotFound) {
+			logger.Warn("Transaction rejected", slog.String("error", err.Error()))
+			return nil, err
+		}
+		logger.Error("Failed to add transaction", slog.String("error", err.Error()))
+		return nil, errors.Wrap(err, "failed to add transaction")
+	}
+
+	logger.Info("Transaction recorded",
+		slog.Int64("transaction_id", created.ID),
+		slog.String("coin", created.CoinName),
+		slog.String("side", string(created.Side)))
+	return created, nil
+}
+
+func (s *PortfolioService) GetTransactions(ctx context.Context, portfolioID int64) ([]entities.Transaction, error) {
+	if _, err := s.portfolios.GetPortfolio(ctx, portfolioID); err != nil {
+		return nil, errors.Wrap(err, "failed to get portfolio")
+	}
+
+	transactions, err := s.portfolios.GetTransactions(ctx, portfolioID, time.Time{})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get transactions")
+	}
+	return transactions, nil
+}
+
+// Valuate values the portfolio at the given moment using transactions executed
+// up to it and the latest stored prices at that moment. Zero at means now.
+func (s *PortfolioService) Valuate(ctx context.Context, portfolioID int64, at time.Time) (*entities.Valuation, error) {
+	const op = "cases.Valuate"
+	startTime := time.Now()
+	logger := s.logger.With(
+		slog.String("op", op),
+		slog.Int64("portfolio_id", portfolioID))
+
+	historical := !at.IsZero()
+	if !historical {
+		at = s.now()
+	}
+
+	if _, err := s.portfolios.GetPortfolio(ctx, portfolioID); err != nil {
+		logger.Warn("Portfolio lookup failed", slog.String("error", err.Error()))
+		return nil, errors.Wrap(err, "failed to get portfolio")
+	}
+
+	transactions, err := s.portfolios.GetTransactions(ctx, portfolioID, at)
+	if err != nil {
+		logger.Error("Failed to get transactions", slog.String("error", err.Error()))
+		return nil, errors.Wrap(err, "failed to get transactions")
+	}
+
+	holdings, err := buildHoldings(transactions)
+	if err != nil {
+		logger.Error("Failed to build holdings", slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	titles := make([]string, 0, len(holdings))
+	for title, h := range holdings {
+		if h.quantity() > quantityEpsilon {
+			titles = append(titles, title)
+		}
+	}
+	sort.Strings(titles)
+
+	prices := make(map[string]entities.Coin, len(titles))
+	if len(titles) > 0 {
+		var coins []entities.Coin
+		if historical {
+			coins, err = s.prices.GetCoinsAt(ctx, titles, at)
+		} else {
+			coins, err = s.prices.GetActualCoins(ctx, titles)
+		}
+		if err != nil {
+			logger.Error("Failed to get prices", slog.String("error", err.Error()))
+			return nil, errors.Wrap(err, "failed to get prices from storage")
+		}
+		for _, coin := range coins {
+			prices[coin.CoinName] = coin
+		}
+	}
+
+	valuation := &entities.Valuation{
+		PortfolioID: portfolioID,
+		At:          at,
+		Positions:   make([]entities.Position, 0, len(holdings)),
+	}
+
+	names := make([]string, 0, len(holdings))
+	for title := range holdings {
+		names = append(names, title)
+	}
+	sort.Strings(names)
+
+	for _, title := range names {
+		h := holdings[title]
+		position := entities.Position{
+			CoinName:    title,
+			Quantity:    h.quantity(),
+			CostBasis:   h.costBasis(),
+			RealizedPnL: h.realized,
+		}
+
+		if position.Quantity > quantityEpsilon {
+			coin, ok := prices[title]
+			if !ok {
+				err = errors.Wrapf(entities.ErrNotFound, "no stored price for %s at %s", title, at.Format(time.RFC3339))
+				logger.Warn("Missing price", slog.String("error", err.Error()))
+				return nil, err
+			}
+			position.Price = coin.Price
+			position.PriceAt = coin.ObservedAt
+			position.MarketValue = position.Quantity * coin.Price
+			position.UnrealizedPnL = position.MarketValue - position.CostBasis
+		} else {
+			position.Quantity = 0
+			position.CostBasis = 0
+		}
+
+		valuation.CostBasis += position.CostBasis
+		valuation.MarketValue += position.MarketValue
+		valuation.RealizedPnL += position.RealizedPnL
+		valuation.UnrealizedPnL += position.UnrealizedPnL
+		valuation.Positions = append(valuation.Positions, position)
+	}
+
+	logger.Info("Portfolio valuated",
+		slog.Int("positions", len(valuation.Positions)),
+		slog.Duration("duration", time.Since(startTime)))
+	return valuation, nil
+}
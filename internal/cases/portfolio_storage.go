@@ -0,0 +1,21 @@
+package cases
+
+import (
+	"context"
+	"time"
+
+	"Cryptoproject/internal/entities"
+)
+
+//go:generate mockgen -source=portfolio_storage.go -destination=./testdata/portfolio_storage.go -package=testdata
+type PortfolioStorage interface {
+	CreatePortfolio(ctx context.Context, portfolio entities.Portfolio) (*entities.Portfolio, error)
+	GetPortfolio(ctx context.Context, id int64) (*entities.Portfolio, error)
+	ListPortfolios(ctx context.Context) ([]entities.Portfolio, error)
+	// AddTransaction stores the transaction unless check, called in the same
+	// transaction with every transaction of the portfolio stored before it,
+	// returns an error. Concurrent inserts into one portfolio are serialized.
+	AddTransaction(ctx context.Context, transaction entities.Transaction,
+		check func(existing []entities.Transaction) error) (*entities.Transaction, error)
+	GetTransactions(ctx context.Context, portfolioID int64, until time.Time) ([]entities.Transaction, error)
+}
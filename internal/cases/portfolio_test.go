@@ -0,0 +1,129 @@
+package cases_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"Cryptoproject/internal/cases"
+	"Cryptoproject/internal/cases/testdata"
+	"Cryptoproject/internal/entities"
+)
+
+func Test_Valuate_FIFO(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockPortfolios := testdata.NewMockPortfolioStorage(ctrl)
+	mockStorage := testdata.NewMockStorage(ctrl)
+
+	day := func(d int) time.Time { return time.Date(2025, 1, d, 0, 0, 0, 0, time.UTC) }
+	transactions := []entities.Transaction{
+		{ID: 1, CoinName: "BTC", Side: entities.SideBuy, Quantity: 1, Price: 100, Fee: 2, ExecutedAt: day(1)},
+		{ID: 2, CoinName: "BTC", Side: entities.SideBuy, Quantity: 1, Price: 200, ExecutedAt: day(2)},
+		// продаём 1.5: первый лот целиком (102) и половину второго (100)
+		{ID: 3, CoinName: "BTC", Side: entities.SideSell, Quantity: 1.5, Price: 300, Fee: 1, ExecutedAt: day(3)},
+		{ID: 4, CoinName: "ETH", Side: entities.SideBuy, Quantity: 2, Price: 10, ExecutedAt: day(3)},
+		{ID: 5, CoinName: "ETH", Side: entities.SideSell, Quantity: 2, Price: 15, ExecutedAt: day(4)},
+	}
+	at := day(5)
+
+	mockPortfolios.EXPECT().
+		GetPortfolio(gomock.Any(), int64(1)).
+		Return(&entities.Portfolio{ID: 1, Name: "main"}, nil)
+	mockPortfolios.EXPECT().
+		GetTransactions(gomock.Any(), int64(1), at).
+		Return(transactions, nil)
+	mockStorage.EXPECT().
+		GetCoinsAt(gomock.Any(), []string{"BTC"}, at).
+		Return([]entities.Coin{{CoinName: "BTC", Price: 400, ObservedAt: day(4)}}, nil)
+
+	service, err := cases.NewPortfolioService(mockPortfolios, mockStorage, nil)
+	require.NoError(t, err)
+
+	valuation, err := service.Valuate(context.Background(), 1, at)
+	require.NoError(t, err)
+	require.Len(t, valuation.Positions, 2)
+
+	btc := valuation.Positions[0]
+	assert.Equal(t, "BTC", btc.CoinName)
+	assert.InDelta(t, 0.5, btc.Quantity, 1e-9)
+	assert.InDelta(t, 100, btc.CostBasis, 1e-9)
+	assert.InDelta(t, 200, btc.MarketValue, 1e-9)
+	assert.InDelta(t, 100, btc.UnrealizedPnL, 1e-9)
+	assert.InDelta(t, 450-1-202, btc.RealizedPnL, 1e-9)
+	assert.Equal(t, day(4), btc.PriceAt)
+
+	eth := valuation.Positions[1]
+	assert.Equal(t, "ETH", eth.CoinName)
+	assert.Zero(t, eth.Quantity)
+	assert.InDelta(t, 10, eth.RealizedPnL, 1e-9)
+
+	assert.InDelta(t, 257, valuation.RealizedPnL, 1e-9)
+	assert.InDelta(t, 100, valuation.UnrealizedPnL, 1e-9)
+	assert.InDelta(t, 200, valuation.MarketValue, 1e-9)
+}
+
+func Test_AddTransaction_Oversell(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockPortfolios := testdata.NewMockPortfolioStorage(ctrl)
+	mockStorage := testdata.NewMockStorage(ctrl)
+
+	// хранилище вызывает проверку внутри транзакции вставки
+	mockPortfolios.EXPECT().
+		AddTransaction(gomock.Any(), gomock.Any(), gomock.Not(gomock.Nil())).
+		DoAndReturn(func(_ context.Context, _ entities.Transaction,
+			check func([]entities.Transaction) error) (*entities.Transaction, error) {
+			if err := check([]entities.Transaction{
+				{ID: 1, CoinName: "BTC", Side: entities.SideBuy, Quantity: 1, Price: 100, ExecutedAt: time.Now().Add(-time.Hour)},
+			}); err != nil {
+				return nil, err
+			}
+			return nil, errors.New("unexpected insert")
+		})
+
+	service, err := cases.NewPortfolioService(mockPortfolios, mockStorage, nil)
+	require.NoError(t, err)
+
+	transaction, err := service.AddTransaction(context.Background(), entities.Transaction{
+		PortfolioID: 1,
+		CoinName:    "btc",
+		Side:        entities.SideSell,
+		Quantity:    2,
+		Price:       150,
+	})
+	assert.Nil(t, transaction)
+	assert.ErrorIs(t, err, entities.ErrInvalidParam)
+	assert.Contains(t, err.Error(), "insufficient BTC quantity")
+}
+
+func Test_AddTransaction_ValidateError(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service, err := cases.NewPortfolioService(testdata.NewMockPortfolioStorage(ctrl), testdata.NewMockStorage(ctrl), nil)
+	require.NoError(t, err)
+
+	transaction, err := service.AddTransaction(context.Background(), entities.Transaction{
+		PortfolioID: 1,
+		CoinName:    "BTC",
+		Side:        "hold",
+		Quantity:    1,
+		Price:       1,
+	})
+	assert.Nil(t, transaction)
+	assert.ErrorIs(t, err, entities.ErrInvalidParam)
+}
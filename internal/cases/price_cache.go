@@ -0,0 +1,66 @@
+package cases
+
+import (
+	"sync"
+	"time"
+
+	"Cryptoproject/internal/entities"
+)
+
+// priceCache keeps the latest known price per title in memory.
+type priceCache struct {
+	mu    sync.RWMutex
+	coins map[string]cachedCoin
+}
+
+// cachedCoin remembers when the provider was last asked for the coin: a
+// repeated quote with an unchanged observation time is not stored again, so
+// the ingestion time alone would make such coins look stale forever.
+type cachedCoin struct {
+	coin      entities.Coin
+	checkedAt time.Time
+}
+
+func newPriceCache() *priceCache {
+	return &priceCache{coins: make(map[string]cachedCoin)}
+}
+
+// fresh splits titles into cached coins younger than maxAge and titles that have to be loaded.
+func (c *priceCache) fresh(titles []string, now time.Time, maxAge time.Duration) ([]entities.Coin, []string) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	found := make([]entities.Coin, 0, len(titles))
+	missing := make([]string, 0, len(titles))
+	for _, title := range titles {
+		cached, ok := c.coins[title]
+		if ok && now.Sub(cached.checkedAt) < maxAge {
+			found = append(found, cached.coin)
+			continue
+		}
+		missing = append(missing, title)
+	}
+	return found, missing
+}
+
+// put stores coins checked against the provider at checkedAt; coins read
+// from storage pass the zero time and count as checked when ingested.
+func (c *priceCache) put(coins []entities.Coin, checkedAt time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, coin := range coins {
+		entry := cachedCoin{coin: coin, checkedAt: coin.CreatedAt}
+		if checkedAt.After(entry.checkedAt) {
+			entry.checkedAt = checkedAt
+		}
+		if cached, ok := c.coins[coin.CoinName]; ok && cached.checkedAt.After(entry.checkedAt) {
+			continue
+		}
+		c.coins[coin.CoinName] = entry
+	}
+}
+
+func isFresh(coin entities.Coin, now time.Time, maxAge time.Duration) bool {
+	return !coin.CreatedAt.IsZero() && now.Sub(coin.CreatedAt) < maxAge
+}
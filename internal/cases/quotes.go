@@ -0,0 +1,153 @@
+package cases
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"Cryptoproject/internal/entities"
+)
+
+// providerFailures — ошибки провайдера, при которых можно отдать последнюю сохранённую цену
+var providerFailures = []error{
+	entities.ErrRateLimited,
+	entities.ErrProviderUnavailable,
+	entities.ErrProviderError,
+}
+
+// GetQuotes returns the latest price of every coin in titles together with a
+// per-coin status, in the order of titles. Unlike GetLastRates it does not
+// fail the whole query when the provider does not list or cannot quote some
+// of the coins: such coins get the unknown symbol, rejected, stale or provider
+// error status. Stale prices are served, and coins the provider failed for are
+// refreshed in the background, only with WithStaleFallback. Only invalid titles
+// and storage failures are returned as errors.
+func (s *Service) GetQuotes(ctx context.Context, titles []string) ([]entities.Quote, error) {
+	const op = "cases.GetQuotes"
+	startTime := time.Now()
+	logger := s.logger.With(
+		slog.String("op", op),
+		slog.Int("titles_count", len(titles)))
+
+	titles, err := entities.NormalizeTitles(titles)
+	if err != nil {
+		logger.Warn("Validation failed", slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	lookup, err := s.lookupRates(ctx, titles)
+	if err != nil {
+		logger.Error("Failed to look up rates", slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	quotes := make(map[string]entities.Quote, len(titles))
+	for _, coin := range lookup.coins {
+		coin := coin
+		quotes[coin.CoinName] = entities.Quote{Title: coin.CoinName, Status: entities.QuoteOK, Coin: &coin}
+	}
+	for _, quote := range lookup.rejected {
+		quotes[quote.CoinName] = entities.Quote{
+			Title:  quote.CoinName,
+			Status: entities.QuoteRejected,
+			Reason: rejectionReason(quote),
+		}
+	}
+
+	switch {
+	case lookup.err == nil:
+		for _, title := range lookup.missing {
+			if _, ok := quotes[title]; !ok {
+				quotes[title] = entities.Quote{
+					Title:  title,
+					Status: entities.QuoteUnknownSymbol,
+					Reason: "provider does not list the coin",
+				}
+			}
+		}
+	case errors.Is(lookup.err, entities.ErrUnknownSymbol):
+		// провайдер отвечает ошибкой, только если не знает ни одной из монет
+		for _, title := range lookup.missing {
+			quotes[title] = entities.Quote{
+				Title:  title,
+				Status: entities.QuoteUnknownSymbol,
+				Reason: "provider does not list the coin",
+			}
+		}
+	default:
+		reason, ok := providerFailure(lookup.err)
+		if !ok {
+			logger.Error("Failed to refresh rates", slog.String("error", lookup.err.Error()))
+			return nil, lookup.err
+		}
+		if s.staleMaxAge <= 0 {
+			// без WithStaleFallback устаревшие цены не отдаются и не обновляются в фоне
+			logger.Warn("Provider failed",
+				slog.Any("titles", lookup.missing),
+				slog.String("error", lookup.err.Error()))
+			for _, title := range lookup.missing {
+				quotes[title] = entities.Quote{Title: title, Status: entities.QuoteProviderError, Reason: reason}
+			}
+			break
+		}
+
+		// устаревшие цены из хранилища отдаются вместо недоступных свежих
+		stored, err := s.storedByTitle(ctx, lookup.missing, lookup.stored)
+		if err != nil {
+			logger.Error("Failed to get stored coins for fallback", slog.String("error", err.Error()))
+			return nil, err
+		}
+		logger.Warn("Provider failed, falling back to stored prices",
+			slog.Any("titles", lookup.missing),
+			slog.String("error", lookup.err.Error()))
+		now := s.now()
+		for _, title := range lookup.missing {
+			quote := entities.Quote{Title: title, Status: entities.QuoteProviderError, Reason: reason}
+			coin, ok := stored[title]
+			age := coin.PriceAge(now)
+			if ok && age <= s.staleMaxAge {
+				coin.Stale = true
+				coin.Age = age
+				quote.Status = entities.QuoteStale
+				quote.Coin = &coin
+			}
+			quotes[title] = quote
+		}
+		s.revalidate(lookup.missing)
+	}
+
+	result := make([]entities.Quote, 0, len(titles))
+	for _, title := range titles {
+		result = append(result, quotes[title])
+	}
+
+	logger.Info("Request processed successfully",
+		slog.Int("ok", entities.CountQuotes(result, entities.QuoteOK)),
+		slog.Int("stale", entities.CountQuotes(result, entities.QuoteStale)),
+		slog.Int("unknown", entities.CountQuotes(result, entities.QuoteUnknownSymbol)),
+		slog.Int("rejected", entities.CountQuotes(result, entities.QuoteRejected)),
+		slog.Int("failed", entities.CountQuotes(result, entities.QuoteProviderError)),
+		slog.Duration("duration", time.Since(startTime)))
+	return result, nil
+}
+
+// rejectionReason describes a quarantined quote to clients.
+func rejectionReason(quote entities.QuarantinedQuote) string {
+	if quote.Detail == "" {
+		return string(quote.Reason)
+	}
+	return string(quote.Reason) + ": " + quote.Detail
+}
+
+// providerFailure reports whether err is a failure of the provider rather
+// than of the service and returns a reason that is safe to show to clients.
+func providerFailure(err error) (string, bool) {
+	for _, target := range providerFailures {
+		if errors.Is(err, target) {
+			return target.Error(), true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,194 @@
+package cases_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"Cryptoproject/internal/cases"
+	"Cryptoproject/internal/cases/testdata"
+	"Cryptoproject/internal/entities"
+)
+
+func Test_GetQuotes_UnknownSymbol(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStorage := testdata.NewMockStorage(ctrl)
+	mockCryptoProvider := testdata.NewMockCryptoProvider(ctrl)
+
+	btc := entities.Coin{CoinName: "BTC", Price: 28000, CreatedAt: time.Now()}
+
+	mockCryptoProvider.EXPECT().
+		GetActualRates(gomock.Any(), []string{"BTC", "FOO"}).
+		Return([]entities.Coin{{CoinName: "BTC", Price: 28000}}, nil)
+	mockStorage.EXPECT().
+		Store(gomock.Any(), gomock.Any()).
+		Return(entities.StoreResult{Inserted: 1}, nil)
+	mockStorage.EXPECT().
+		GetActualCoins(gomock.Any(), []string{"BTC", "FOO"}).
+		Return([]entities.Coin{btc}, nil)
+
+	service, err := cases.NewService(mockStorage, mockCryptoProvider, nil)
+	require.NoError(t, err)
+
+	quotes, err := service.GetQuotes(context.Background(), []string{"foo", "btc"})
+	require.NoError(t, err)
+	require.Len(t, quotes, 2)
+	assert.Equal(t, "FOO", quotes[0].Title)
+	assert.Equal(t, entities.QuoteUnknownSymbol, quotes[0].Status)
+	assert.Nil(t, quotes[0].Coin)
+	assert.Equal(t, entities.Quote{Title: "BTC", Status: entities.QuoteOK, Coin: &btc}, quotes[1])
+}
+
+func Test_GetQuotes_ProviderUnavailable(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStorage := testdata.NewMockStorage(ctrl)
+	mockCryptoProvider := testdata.NewMockCryptoProvider(ctrl)
+
+	titles := []string{"BTC", "ETH", "LTC"}
+	btc := entities.Coin{CoinName: "BTC", Price: 28000, CreatedAt: time.Now()}
+	staleEth := entities.Coin{CoinName: "ETH", Price: 1400, CreatedAt: time.Now().Add(-time.Hour)}
+
+	mockStorage.EXPECT().
+		GetActualCoins(gomock.Any(), titles).
+		Return([]entities.Coin{btc, staleEth}, nil)
+	providerErr := errors.Wrap(entities.ErrProviderUnavailable, "dial tcp: connection refused")
+	mockCryptoProvider.EXPECT().
+		GetActualRates(gomock.Any(), []string{"ETH", "LTC"}).
+		Return(nil, providerErr)
+
+	// повторный запрос уходит провайдеру в фоне
+	revalidated := make(chan struct{})
+	mockCryptoProvider.EXPECT().
+		GetActualRates(gomock.Any(), []string{"ETH", "LTC"}).
+		DoAndReturn(func(context.Context, []string) ([]entities.Coin, error) {
+			close(revalidated)
+			return nil, providerErr
+		})
+
+	service, err := cases.NewService(mockStorage, mockCryptoProvider, nil,
+		cases.WithMaxAge(time.Minute), cases.WithStaleFallback(2*time.Hour))
+	require.NoError(t, err)
+
+	quotes, err := service.GetQuotes(context.Background(), titles)
+	require.NoError(t, err)
+	require.Len(t, quotes, 3)
+	assert.Equal(t, entities.Quote{Title: "BTC", Status: entities.QuoteOK, Coin: &btc}, quotes[0])
+	assert.Equal(t, entities.Quote{Title: "LTC", Status: entities.QuoteProviderError, Reason: "provider unavailable"}, quotes[2])
+
+	assert.Equal(t, entities.QuoteStale, quotes[1].Status)
+	assert.Equal(t, "provider unavailable", quotes[1].Reason)
+	require.NotNil(t, quotes[1].Coin)
+	assert.Equal(t, staleEth.Price, quotes[1].Coin.Price)
+	assert.True(t, quotes[1].Coin.Stale)
+	assert.GreaterOrEqual(t, quotes[1].Coin.Age, time.Hour)
+
+	<-revalidated
+	require.NoError(t, service.Shutdown(context.Background()))
+}
+
+func Test_GetQuotes_FallbackDisabled(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStorage := testdata.NewMockStorage(ctrl)
+	mockCryptoProvider := testdata.NewMockCryptoProvider(ctrl)
+
+	staleEth := entities.Coin{CoinName: "ETH", Price: 1400, CreatedAt: time.Now().Add(-time.Hour)}
+
+	mockStorage.EXPECT().
+		GetActualCoins(gomock.Any(), []string{"ETH"}).
+		Return([]entities.Coin{staleEth}, nil)
+	// без WithStaleFallback фонового обновления нет: провайдер вызывается один раз
+	mockCryptoProvider.EXPECT().
+		GetActualRates(gomock.Any(), []string{"ETH"}).
+		Return(nil, errors.Wrap(entities.ErrProviderUnavailable, "dial tcp: connection refused"))
+
+	service, err := cases.NewService(mockStorage, mockCryptoProvider, nil, cases.WithMaxAge(time.Minute))
+	require.NoError(t, err)
+
+	quotes, err := service.GetQuotes(context.Background(), []string{"ETH"})
+	require.NoError(t, err)
+	assert.Equal(t, []entities.Quote{
+		{Title: "ETH", Status: entities.QuoteProviderError, Reason: "provider unavailable"},
+	}, quotes)
+	require.NoError(t, service.Shutdown(context.Background()))
+}
+
+func Test_GetQuotes_StorageError(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStorage := testdata.NewMockStorage(ctrl)
+	mockStorage.EXPECT().
+		GetActualCoins(gomock.Any(), []string{"BTC"}).
+		Return(nil, entities.ErrInternal)
+
+	service, err := cases.NewService(mockStorage, testdata.NewMockCryptoProvider(ctrl), nil,
+		cases.WithMaxAge(time.Minute))
+	require.NoError(t, err)
+
+	_, err = service.GetQuotes(context.Background(), []string{"BTC"})
+	assert.ErrorIs(t, err, entities.ErrInternal)
+
+	_, err = service.GetQuotes(context.Background(), []string{"BTC!"})
+	assert.ErrorIs(t, err, entities.ErrInvalidParam)
+}
+
+func Test_GetQuotes_RejectedQuote(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStorage := testdata.NewMockStorage(ctrl)
+	mockCryptoProvider := testdata.NewMockCryptoProvider(ctrl)
+
+	titles := []string{"BTC", "ETH"}
+	btc := entities.Coin{CoinName: "BTC", Price: 28000, CreatedAt: time.Now()}
+	// сохранённая цена ETH старше max age и не должна вернуться как свежая
+	oldEth := entities.Coin{CoinName: "ETH", Price: 1400, CreatedAt: time.Now().Add(-time.Hour)}
+
+	mockStorage.EXPECT().
+		GetActualCoins(gomock.Any(), titles).
+		Return([]entities.Coin{oldEth}, nil)
+	mockCryptoProvider.EXPECT().
+		GetActualRates(gomock.Any(), titles).
+		Return([]entities.Coin{{CoinName: "BTC", Price: 28000}, {CoinName: "ETH", Price: -1}}, nil)
+	mockStorage.EXPECT().
+		StoreQuarantine(gomock.Any(), gomock.Len(1)).
+		Return(nil)
+	mockStorage.EXPECT().
+		Store(gomock.Any(), []entities.Coin{{CoinName: "BTC", Price: 28000}}).
+		Return(entities.StoreResult{Inserted: 1}, nil)
+	mockStorage.EXPECT().
+		GetActualCoins(gomock.Any(), []string{"BTC"}).
+		Return([]entities.Coin{btc}, nil)
+
+	service, err := cases.NewService(mockStorage, mockCryptoProvider, nil, cases.WithMaxAge(time.Minute))
+	require.NoError(t, err)
+
+	quotes, err := service.GetQuotes(context.Background(), titles)
+	require.NoError(t, err)
+	require.Len(t, quotes, 2)
+	assert.Equal(t, entities.Quote{Title: "BTC", Status: entities.QuoteOK, Coin: &btc}, quotes[0])
+	assert.Equal(t, entities.QuoteRejected, quotes[1].Status)
+	assert.Nil(t, quotes[1].Coin)
+	assert.Contains(t, quotes[1].Reason, string(entities.QuarantineNonPositivePrice))
+}
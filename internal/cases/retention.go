@@ -0,0 +1,153 @@
+package cases
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"Cryptoproject/internal/entities"
+)
+
+type RetentionConfig struct {
+	// RawRetention is how long raw minute rows are kept before they are rolled into hourly buckets.
+	RawRetention time.Duration
+	// HourlyRetention is how long hourly buckets are kept before they are rolled into daily buckets.
+	HourlyRetention time.Duration
+	// BatchSize limits rows deleted per statement to keep locks short.
+	BatchSize int
+	// BatchPause is the pause between delete batches.
+	BatchPause time.Duration
+	// PartitionsAhead is how many monthly partitions after the current one are created in advance.
+	PartitionsAhead int
+}
+
+type RetentionService struct {
+	storage RetentionStorage
+	cfg     RetentionConfig
+	logger  *slog.Logger
+	now     func() time.Time
+}
+
+func NewRetentionService(storage RetentionStorage, cfg RetentionConfig, logger *slog.Logger) (*RetentionService, error) {
+	const op = "cases.NewRetentionService"
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	if storage == nil {
+		err := errors.Wrap(entities.ErrInvalidParam, "retention storage not set")
+		logger.Error(op, slog.String("error", err.Error()))
+		return nil, err
+	}
+	if cfg.RawRetention <= 0 || cfg.HourlyRetention < cfg.RawRetention {
+		err := errors.Wrap(entities.ErrInvalidParam, "hourly retention must not be shorter than raw retention")
+		logger.Error(op, slog.String("error", err.Error()))
+		return nil, err
+	}
+	if cfg.BatchSize <= 0 {
+		err := errors.Wrap(entities.ErrInvalidParam, "batch size must be greater then 0")
+		logger.Error(op, slog.String("error", err.Error()))
+		return nil, err
+	}
+	if cfg.PartitionsAhead < 0 {
+		err := errors.Wrap(entities.ErrInvalidParam, "partitions ahead must not be negative")
+		logger.Error(op, slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	return &RetentionService{
+		storage: storage,
+		cfg:     cfg,
+		logger:  logger,
+		now:     time.Now,
+	}, nil
+}
+
+// ApplyRetention creates upcoming raw partitions, rolls expired raw rows into
+// hourly buckets and expired hourly buckets into daily ones, then drops fully
+// expired raw partitions and deletes the remaining rolled up rows in batches.
+func (s *RetentionService) ApplyRetention(ctx context.Context) error {
+	const op = "cases.ApplyRetention"
+	startTime := time.Now()
+	logger := s.logger.With(slog.String("op", op))
+
+	now := s.now().UTC()
+	rawCutoff := now.Add(-s.cfg.RawRetention).Truncate(time.Hour)
+	dailyCutoff := now.Add(-s.cfg.HourlyRetention).Truncate(24 * time.Hour)
+
+	currentMonth := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	created, err := s.storage.EnsurePartitions(ctx, currentMonth, currentMonth.AddDate(0, s.cfg.PartitionsAhead+1, 0))
+	if err != nil {
+		logger.Error("Failed to create partitions", slog.String("error", err.Error()))
+		return errors.Wrap(err, "retention ensure partitions")
+	}
+
+	rolledRaw, err := s.storage.RollupRaw(ctx, rawCutoff)
+	if err != nil {
+		logger.Error("Failed to roll up raw rows", slog.String("error", err.Error()))
+		return errors.Wrap(err, "retention rollup raw")
+	}
+
+	rolledHourly, err := s.storage.RollupHourly(ctx, dailyCutoff)
+	if err != nil {
+		logger.Error("Failed to roll up hourly rows", slog.String("error", err.Error()))
+		return errors.Wrap(err, "retention rollup hourly")
+	}
+
+	// целые партиции удаляются мгновенно, построчно дочищается только хвост
+	dropped, err := s.storage.DropPartitionsBefore(ctx, rawCutoff)
+	if err != nil {
+		logger.Error("Failed to drop partitions", slog.String("error", err.Error()))
+		return errors.Wrap(err, "retention drop partitions")
+	}
+
+	deletedRaw, err := s.deleteInBatches(ctx, s.storage.DeleteRawBatch)
+	if err != nil {
+		logger.Error("Failed to delete raw rows",
+			slog.String("error", err.Error()),
+			slog.Int64("deleted", deletedRaw))
+		return errors.Wrap(err, "retention delete raw")
+	}
+
+	deletedHourly, err := s.deleteInBatches(ctx, s.storage.DeleteHourlyBatch)
+	if err != nil {
+		logger.Error("Failed to delete hourly rows",
+			slog.String("error", err.Error()),
+			slog.Int64("deleted", deletedHourly))
+		return errors.Wrap(err, "retention delete hourly")
+	}
+
+	logger.Info("Retention applied",
+		slog.Time("raw_cutoff", rawCutoff),
+		slog.Time("daily_cutoff", dailyCutoff),
+		slog.Int64("hourly_buckets_created", rolledRaw),
+		slog.Int64("daily_buckets_created", rolledHourly),
+		slog.Int("partitions_created", created),
+		slog.Int("partitions_dropped", dropped),
+		slog.Int64("raw_deleted", deletedRaw),
+		slog.Int64("hourly_deleted", deletedHourly),
+		slog.Duration("duration", time.Since(startTime)))
+	return nil
+}
+
+func (s *RetentionService) deleteInBatches(ctx context.Context, deleteBatch func(context.Context, int) (int64, error)) (int64, error) {
+	var total int64
+	for {
+		deleted, err := deleteBatch(ctx, s.cfg.BatchSize)
+		if err != nil {
+			return total, err
+		}
+		total += deleted
+		if deleted < int64(s.cfg.BatchSize) {
+			return total, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return total, ctx.Err()
+		case <-time.After(s.cfg.BatchPause):
+		}
+	}
+}
@@ -0,0 +1,25 @@
+package cases
+
+import (
+	"context"
+	"time"
+)
+
+//go:generate mockgen -source=retention_storage.go -destination=./testdata/retention_storage.go -package=testdata
+type RetentionStorage interface {
+	// RollupRaw summarises raw rows older than before into hourly buckets and
+	// moves the raw watermark, so queries stop reading those raw rows.
+	RollupRaw(ctx context.Context, before time.Time) (int64, error)
+	// RollupHourly summarises hourly buckets older than before into daily buckets.
+	RollupHourly(ctx context.Context, before time.Time) (int64, error)
+	// DeleteRawBatch deletes up to batchSize raw rows that are already rolled up.
+	DeleteRawBatch(ctx context.Context, batchSize int) (int64, error)
+	// DeleteHourlyBatch deletes up to batchSize hourly rows that are already rolled up.
+	DeleteHourlyBatch(ctx context.Context, batchSize int) (int64, error)
+	// EnsurePartitions creates the missing monthly partitions of the raw table
+	// covering [from, to) and returns how many were created.
+	EnsurePartitions(ctx context.Context, from, to time.Time) (int, error)
+	// DropPartitionsBefore drops monthly partitions that end before the given
+	// time and are already rolled up, and returns how many were dropped.
+	DropPartitionsBefore(ctx context.Context, before time.Time) (int, error)
+}
@@ -0,0 +1,115 @@
+package cases_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"Cryptoproject/internal/cases"
+	"Cryptoproject/internal/cases/testdata"
+	"Cryptoproject/internal/entities"
+)
+
+func Test_ApplyRetention_Success(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStorage := testdata.NewMockRetentionStorage(ctrl)
+
+	cfg := cases.RetentionConfig{
+		RawRetention:    24 * time.Hour,
+		HourlyRetention: 7 * 24 * time.Hour,
+		BatchSize:       100,
+		PartitionsAhead: 2,
+	}
+
+	gomock.InOrder(
+		mockStorage.EXPECT().
+			EnsurePartitions(gomock.Any(), gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, from, to time.Time) (int, error) {
+				now := time.Now().UTC()
+				assert.Equal(t, time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC), from)
+				// текущий месяц и два следующих
+				assert.Equal(t, from.AddDate(0, 3, 0), to)
+				return 1, nil
+			}),
+		mockStorage.EXPECT().
+			RollupRaw(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, before time.Time) (int64, error) {
+				assert.Equal(t, before.Truncate(time.Hour), before)
+				assert.WithinDuration(t, time.Now().Add(-cfg.RawRetention), before, time.Hour)
+				return 24, nil
+			}),
+		mockStorage.EXPECT().
+			RollupHourly(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, before time.Time) (int64, error) {
+				assert.Equal(t, before.Truncate(24*time.Hour), before)
+				assert.WithinDuration(t, time.Now().Add(-cfg.HourlyRetention), before, 24*time.Hour)
+				return 1, nil
+			}),
+		mockStorage.EXPECT().
+			DropPartitionsBefore(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, before time.Time) (int, error) {
+				assert.WithinDuration(t, time.Now().Add(-cfg.RawRetention), before, time.Hour)
+				return 2, nil
+			}),
+		// полные батчи повторяются, неполный завершает удаление
+		mockStorage.EXPECT().DeleteRawBatch(gomock.Any(), 100).Return(int64(100), nil),
+		mockStorage.EXPECT().DeleteRawBatch(gomock.Any(), 100).Return(int64(100), nil),
+		mockStorage.EXPECT().DeleteRawBatch(gomock.Any(), 100).Return(int64(40), nil),
+		mockStorage.EXPECT().DeleteHourlyBatch(gomock.Any(), 100).Return(int64(0), nil),
+	)
+
+	service, err := cases.NewRetentionService(mockStorage, cfg, nil)
+	require.NoError(t, err)
+
+	err = service.ApplyRetention(context.Background())
+	assert.NoError(t, err)
+}
+
+func Test_ApplyRetention_RollupError(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStorage := testdata.NewMockRetentionStorage(ctrl)
+	mockStorage.EXPECT().
+		EnsurePartitions(gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(0, nil)
+	mockStorage.EXPECT().
+		RollupRaw(gomock.Any(), gomock.Any()).
+		Return(int64(0), errors.Wrap(entities.ErrInternal, "boom"))
+
+	service, err := cases.NewRetentionService(mockStorage, cases.RetentionConfig{
+		RawRetention:    time.Hour,
+		HourlyRetention: time.Hour,
+		BatchSize:       10,
+	}, nil)
+	require.NoError(t, err)
+
+	err = service.ApplyRetention(context.Background())
+	assert.ErrorIs(t, err, entities.ErrInternal)
+}
+
+func Test_NewRetentionService_ValidateError(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service, err := cases.NewRetentionService(testdata.NewMockRetentionStorage(ctrl), cases.RetentionConfig{
+		RawRetention:    48 * time.Hour,
+		HourlyRetention: 24 * time.Hour,
+		BatchSize:       10,
+	}, nil)
+	assert.Nil(t, service)
+	assert.ErrorIs(t, err, entities.ErrInvalidParam)
+}
@@ -3,6 +3,8 @@ package cases
 import (
 	"context"
 	"log/slog"
+	"slices"
+	"sort"
 	"time"
 
 	"github.com/pkg/errors"
@@ -14,9 +16,33 @@ type Service struct {
 	storage        Storage
 	cryptoProvider CryptoProvider
 	logger         *slog.Logger
+
+	maxAge  time.Duration
+	cache   *priceCache
+	flights *titleFlights
+	now     func() time.Time
+
+	quoteCurrency string
+	maxStaleness  time.Duration
+
+	staleMaxAge  time.Duration
+	revalidating *revalidator
+
+	validation ValidationConfig
+	feeds      *feedTracker
+}
+
+type ServiceOption func(s *Service)
+
+// WithMaxAge enables the read-through cache: prices younger than maxAge
+// are served from memory or storage without calling the provider.
+func WithMaxAge(maxAge time.Duration) ServiceOption {
+	return func(s *Service) {
+		s.maxAge = maxAge
+	}
 }
 
-func NewService(storage Storage, cryptoProvider CryptoProvider, logger *slog.Logger) (*Service, error) {
+func NewService(storage Storage, cryptoProvider CryptoProvider, logger *slog.Logger, opts ...ServiceOption) (*Service, error) {
 	const op = "cases.NewService"
 	if logger == nil {
 		logger = slog.Default()
@@ -38,12 +64,25 @@ func NewService(storage Storage, cryptoProvider CryptoProvider, logger *slog.Log
 		return nil, err
 	}
 
-	logger.Info("Service initialized Successfully")
-	return &Service{
+	service := &Service{
 		storage:        storage,
 		cryptoProvider: cryptoProvider,
 		logger:         logger,
-	}, nil
+		cache:          newPriceCache(),
+		flights:        newTitleFlights(),
+		now:            time.Now,
+		quoteCurrency:  defaultQuoteCurrency,
+		maxStaleness:   defaultMaxStaleness,
+		feeds:          newFeedTracker(),
+		revalidating:   newRevalidator(),
+	}
+	for _, opt := range opts {
+		opt(service)
+	}
+
+	logger.Info("Service initialized Successfully",
+		slog.Duration("max_age", service.maxAge))
+	return service, nil
 }
 
 func (s *Service) GetLastRates(ctx context.Context, titles []string) ([]entities.Coin, error) {
@@ -55,39 +94,42 @@ func (s *Service) GetLastRates(ctx context.Context, titles []string) ([]entities
 		slog.Any("titles_count", len(titles)),
 		slog.Any("titles", titles))
 
-	if len(titles) == 0 {
-		err := errors.Wrap(entities.ErrInvalidParam, "titles list is empty")
+	// титулы приходят и из HTTP, и из gRPC, и из cryptoctl, поэтому проверяются здесь
+	titles, err := entities.NormalizeTitles(titles)
+	if err != nil {
 		s.logger.Warn("Validation failed", slog.String("error", err.Error()))
 		return nil, err
 	}
 
-	logger.Debug("Getting fresh rates from provider")
-	freshCoins, err := s.cryptoProvider.GetActualRates(ctx, titles)
+	lookup, err := s.lookupRates(ctx, titles)
 	if err != nil {
-		logger.Error("Failed to get fresh rates",
+		logger.Error("Failed to look up rates",
 			slog.String("error", err.Error()),
 			slog.Duration("duration", time.Since(startTime)))
-		return nil, errors.Wrap(err, "failed to get fresh rates")
+		return nil, err
 	}
 
-	logger.Debug("Shorting fresh rates",
-		slog.Int("coins_count", len(freshCoins)))
-	if err = s.storage.Store(ctx, freshCoins); err != nil {
-		logger.Error("Failed to store fresh coins",
-			slog.String("error", err.Error()),
-			slog.Int("coins_count", len(freshCoins)))
-		return nil, errors.Wrap(err, "failed to store fresh coins")
+	coins := lookup.coins
+	if lookup.err != nil {
+		stale, err := s.staleRates(ctx, lookup.missing, lookup.stored, lookup.err)
+		if err != nil {
+			logger.Error("Failed to refresh rates",
+				slog.String("error", err.Error()),
+				slog.Duration("duration", time.Since(startTime)))
+			return nil, err
+		}
+		coins = append(coins, stale...)
 	}
-
-	logger.Debug("Retrieving actual coins from storage")
-	coins, err := s.storage.GetActualCoins(ctx, titles)
-	if err != nil {
-		logger.Error("Failed to get actual coins",
-			slog.String("error", err.Error()),
-			slog.Duration("duration", time.Since(startTime)))
-		return nil, errors.Wrap(err, "failed to get actual coins from storage")
+	for _, quote := range lookup.rejected {
+		logger.Warn("Coin left out, its quote was rejected",
+			slog.String("coin", quote.CoinName),
+			slog.String("reason", string(quote.Reason)))
 	}
 
+	sort.Slice(coins, func(i, j int) bool {
+		return coins[i].CoinName < coins[j].CoinName
+	})
+
 	logger.Info("Request processed successfully",
 		slog.Int("coins_count", len(coins)),
 		slog.Duration("duration", time.Since(startTime)))
@@ -95,6 +137,169 @@ func (s *Service) GetLastRates(ctx context.Context, titles []string) ([]entities
 	return coins, nil
 }
 
+// rateLookup is the outcome of looking titles up in the cache, then in
+// storage, then at the provider.
+type rateLookup struct {
+	// coins — актуальные цены из кэша, хранилища или от провайдера
+	coins []entities.Coin
+	// missing — монеты, за которыми пришлось идти к провайдеру
+	missing []string
+	// rejected — отклонённые проверкой котировки части missing
+	rejected []entities.QuarantinedQuote
+	// err — ошибка провайдера; тогда цен missing в coins нет
+	err error
+	// stored — прочитанные цены missing, в том числе устаревшие; nil, если хранилище не читалось
+	stored []entities.Coin
+}
+
+// lookupRates serves titles from the cache and storage while they are fresh
+// and refreshes the rest from the provider. A provider failure is returned in
+// the lookup, so callers can fall back to stored prices; only storage failures
+// are returned as errors. Without a max age every title is refreshed.
+func (s *Service) lookupRates(ctx context.Context, titles []string) (*rateLookup, error) {
+	const op = "cases.lookupRates"
+	logger := s.logger.With(slog.String("op", op))
+
+	lookup := &rateLookup{missing: titles}
+	if s.maxAge > 0 {
+		now := s.now()
+		lookup.coins, lookup.missing = s.cache.fresh(titles, now, s.maxAge)
+		logger.Debug("Checked in-memory cache",
+			slog.Int("hits", len(lookup.coins)),
+			slog.Int("misses", len(lookup.missing)))
+
+		if len(lookup.missing) > 0 {
+			stored, err := s.storage.GetActualCoins(ctx, lookup.missing)
+			if err != nil {
+				return nil, errors.Wrap(err, "failed to get actual coins from storage")
+			}
+			lookup.stored = append([]entities.Coin{}, stored...)
+
+			storedFresh := make([]entities.Coin, 0, len(stored))
+			for _, coin := range stored {
+				if isFresh(coin, now, s.maxAge) {
+					storedFresh = append(storedFresh, coin)
+				}
+			}
+			s.cache.put(storedFresh, time.Time{})
+			lookup.coins = append(lookup.coins, storedFresh...)
+			lookup.missing = subtractTitles(lookup.missing, storedFresh)
+		}
+	}
+
+	if len(lookup.missing) == 0 {
+		return lookup, nil
+	}
+
+	logger.Debug("Refreshing stale titles",
+		slog.Any("titles", lookup.missing))
+	refreshed, err := s.refreshRates(ctx, lookup.missing)
+	if err != nil {
+		lookup.err = err
+		return lookup, nil
+	}
+	lookup.coins = append(lookup.coins, refreshed.coins...)
+	lookup.rejected = refreshed.rejected
+	return lookup, nil
+}
+
+// refreshRates fetches titles from the provider, stores them and reads the
+// result back from storage. Quotes rejected by validation are returned apart
+// and their titles are left out of the coins. Concurrent calls share the
+// provider request for every title they have in common.
+func (s *Service) refreshRates(ctx context.Context, titles []string) (refreshResult, error) {
+	const op = "cases.refreshRates"
+	logger := s.logger.With(slog.String("op", op))
+
+	key := slices.Clone(titles)
+	slices.Sort(key)
+	key = slices.Compact(key)
+
+	waits, own, rest := s.flights.join(key)
+	if own != nil {
+		// запрос разделяется между вызывающими, поэтому не зависит от отмены одного из них
+		result, err := s.fetchRates(context.WithoutCancel(ctx), rest)
+		s.flights.finish(own, rest, result, err)
+		waits = append(waits, own)
+	}
+
+	requested := make(map[string]bool, len(key))
+	for _, title := range key {
+		requested[title] = true
+	}
+	result := refreshResult{coins: make([]entities.Coin, 0, len(key))}
+	for _, fl := range waits {
+		<-fl.done
+		if fl.err != nil {
+			return refreshResult{}, fl.err
+		}
+		for _, coin := range fl.result.coins {
+			if requested[coin.CoinName] {
+				result.coins = append(result.coins, coin)
+			}
+		}
+		for _, quote := range fl.result.rejected {
+			if requested[quote.CoinName] {
+				result.rejected = append(result.rejected, quote)
+			}
+		}
+	}
+
+	logger.Debug("Rates refreshed",
+		slog.Int("fetched", len(rest)),
+		slog.Int("shared", len(key)-len(rest)),
+		slog.Int("rejected", len(result.rejected)))
+	return result, nil
+}
+
+// fetchRates asks the provider for titles, stores the accepted quotes and
+// reads the actual coins back from storage. Titles whose quote was rejected
+// are not read back: their stored price is older than the rejected quote.
+func (s *Service) fetchRates(ctx context.Context, titles []string) (refreshResult, error) {
+	const op = "cases.fetchRates"
+	logger := s.logger.With(slog.String("op", op))
+
+	logger.Debug("Getting fresh rates from provider")
+	freshCoins, err := s.cryptoProvider.GetActualRates(ctx, titles)
+	if err != nil {
+		return refreshResult{}, errors.Wrap(err, "failed to get fresh rates")
+	}
+
+	freshCoins, rejected := s.validateQuotes(ctx, freshCoins)
+	logger.Debug("Storing fresh rates",
+		slog.Int("coins_count", len(freshCoins)))
+	stored, err := s.storage.Store(ctx, freshCoins)
+	if err != nil {
+		return refreshResult{}, errors.Wrap(err, "failed to store fresh coins")
+	}
+	logger.Debug("Fresh rates stored",
+		slog.Int("inserted", stored.Inserted),
+		slog.Int("skipped", stored.Skipped))
+
+	if len(rejected) > 0 {
+		rejectedTitles := make(map[string]bool, len(rejected))
+		for _, quote := range rejected {
+			rejectedTitles[quote.CoinName] = true
+		}
+		titles = slices.DeleteFunc(slices.Clone(titles), func(title string) bool {
+			return rejectedTitles[title]
+		})
+	}
+	result := refreshResult{coins: []entities.Coin{}, rejected: rejected}
+	if len(titles) == 0 {
+		return result, nil
+	}
+
+	logger.Debug("Retrieving actual coins from storage")
+	result.coins, err = s.storage.GetActualCoins(ctx, titles)
+	if err != nil {
+		return refreshResult{}, errors.Wrap(err, "failed to get actual coins from storage")
+	}
+
+	s.cache.put(result.coins, s.now())
+	return result, nil
+}
+
 func (s *Service) GetRatesWithAgg(ctx context.Context, titles []string, aggFuncTitle string) ([]entities.Coin, error) {
 	const op = "cases.GetRatesWithAgg"
 	startTime := time.Now()
@@ -105,8 +310,8 @@ func (s *Service) GetRatesWithAgg(ctx context.Context, titles []string, aggFuncT
 	s.logger.Info("Processing aggregation request",
 		slog.Any("titles_count", len(titles)))
 
-	if len(titles) == 0 {
-		err := errors.Wrap(entities.ErrInvalidParam, "titles list is empty")
+	titles, err := entities.NormalizeTitles(titles)
+	if err != nil {
 		s.logger.Warn("Validation failed", slog.String("error", err.Error()))
 		return nil, err
 	}
@@ -153,17 +358,41 @@ func (s *Service) ActualizeRates(ctx context.Context) error {
 	s.logger.Debug("Retrieved coins list",
 		slog.Int("count", len(allTitles)))
 
-	logger.Debug("Getting actual rates from provider")
-	updatedCoins, err := s.cryptoProvider.GetActualRates(ctx, allTitles)
+	logger.Debug("Getting tickers from provider")
+	tickers, err := s.cryptoProvider.GetTickers(ctx, allTitles)
 	if err != nil {
-		s.logger.Error("failed to get actual rates",
+		s.logger.Error("failed to get tickers",
 			slog.String("error", err.Error()))
-		return errors.Wrap(err, "actualizeRates get actual rates")
+		return errors.Wrap(err, "actualizeRates get tickers")
+	}
+
+	// цены берутся из тех же тикеров, второй запрос к провайдеру не нужен
+	quotes := make([]entities.Coin, 0, len(tickers))
+	for _, ticker := range tickers {
+		quotes = append(quotes, ticker.Coin())
+	}
+	updatedCoins, _ := s.validateQuotes(ctx, quotes)
+
+	// тикеры отклонённых котировок тоже не сохраняются
+	accepted := make(map[string]bool, len(updatedCoins))
+	for _, coin := range updatedCoins {
+		accepted[coin.CoinName] = true
+	}
+	tickers = slices.DeleteFunc(tickers, func(ticker entities.Ticker) bool {
+		return !accepted[ticker.CoinName]
+	})
+
+	if err = s.storage.StoreTickers(ctx, tickers); err != nil {
+		s.logger.Error("failed to store tickers",
+			slog.String("error", err.Error()),
+			slog.Int("tickers_count", len(tickers)))
+		return errors.Wrap(err, "actualizeRates store tickers")
 	}
 
 	s.logger.Debug("Shorting updated rates",
 		slog.Int("coins_count", len(updatedCoins)))
-	if err = s.storage.Store(ctx, updatedCoins); err != nil {
+	stored, err := s.storage.Store(ctx, updatedCoins)
+	if err != nil {
 		s.logger.Error("failed to store actual rates",
 			slog.String("error", err.Error()),
 			slog.Int("coins_count", len(updatedCoins)))
@@ -172,6 +401,8 @@ func (s *Service) ActualizeRates(ctx context.Context) error {
 
 	s.logger.Info("Rates actualization completed successfully",
 		slog.Int("coins_updated", len(updatedCoins)),
+		slog.Int("coins_inserted", stored.Inserted),
+		slog.Int("coins_skipped", stored.Skipped),
 		slog.Duration("duration", time.Since(startTime)))
 	return nil
 }
@@ -208,10 +439,14 @@ func (s *Service) checkExistingTitles(ctx context.Context, requestTitles []strin
 			slog.Any("missing_titles", missingTitles))
 		return errors.Wrap(err, "failed to get actual rates for missing titles")
 	}
+	if unknown := subtractTitles(missingTitles, newCoins); len(unknown) > 0 {
+		return errors.Wrapf(entities.ErrUnknownSymbol, "unknown titles: %v", unknown)
+	}
 
 	logger.Debug("Storing missing titles",
 		slog.Int("new_coins_count", len(newCoins)))
-	if err = s.storage.Store(ctx, newCoins); err != nil {
+	stored, err := s.storage.Store(ctx, newCoins)
+	if err != nil {
 		logger.Error("Failed to store new coins",
 			slog.String("error", err.Error()),
 			slog.Int("coins_count", len(newCoins)))
@@ -219,11 +454,27 @@ func (s *Service) checkExistingTitles(ctx context.Context, requestTitles []strin
 	}
 
 	logger.Info("Missing titles processed successfully",
-		slog.Int("added_count", len(newCoins)))
+		slog.Int("added_count", stored.Inserted),
+		slog.Int("skipped_count", stored.Skipped))
 
 	return nil
 }
 
+func subtractTitles(titles []string, coins []entities.Coin) []string {
+	found := make(map[string]struct{}, len(coins))
+	for _, coin := range coins {
+		found[coin.CoinName] = struct{}{}
+	}
+
+	rest := make([]string, 0, len(titles))
+	for _, title := range titles {
+		if _, ok := found[title]; !ok {
+			rest = append(rest, title)
+		}
+	}
+	return rest
+}
+
 func findMissingTitles(requestTitles, existingTitles []string) []string {
 	existingSet := make(map[string]struct{}, len(existingTitles))
 	for _, title := range existingTitles {
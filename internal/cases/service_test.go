@@ -2,9 +2,13 @@ package cases_test
 
 import (
 	"context"
+	"fmt"
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/require"
+	"math"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
@@ -29,15 +33,19 @@ func Test_GetLastRates_Success(t *testing.T) {
 		{CoinName: "ETH", Price: 1500},
 	}
 
+	mockCryptoProvider.EXPECT().
+		GetActualRates(gomock.Any(), titles).
+		Return(expectedCoins, nil)
+
 	mockStorage.EXPECT().
-		GetCoinsList(gomock.Any()).
-		Return([]string{"BTC", "ETH"}, nil)
+		Store(gomock.Any(), expectedCoins).
+		Return(entities.StoreResult{Inserted: len(expectedCoins)}, nil)
 
 	mockStorage.EXPECT().
 		GetActualCoins(gomock.Any(), titles).
 		Return(expectedCoins, nil)
 
-	service, err := cases.NewService(mockStorage, mockCryptoProvider)
+	service, err := cases.NewService(mockStorage, mockCryptoProvider, nil)
 	require.NoError(t, err)
 
 	coins, err := service.GetLastRates(context.Background(), titles)
@@ -55,16 +63,24 @@ func Test_GetLastRates_Error(t *testing.T) {
 	mockCryptoProvider := testdata.NewMockCryptoProvider(ctrl)
 
 	titles := []string{"BTC", "ETH"}
+	freshCoins := []entities.Coin{
+		{CoinName: "BTC", Price: 28000},
+		{CoinName: "ETH", Price: 1500},
+	}
+
+	mockCryptoProvider.EXPECT().
+		GetActualRates(gomock.Any(), titles).
+		Return(freshCoins, nil)
 
 	mockStorage.EXPECT().
-		GetCoinsList(gomock.Any()).
-		Return([]string{"BTC", "ETH"}, nil)
+		Store(gomock.Any(), freshCoins).
+		Return(entities.StoreResult{Inserted: len(freshCoins)}, nil)
 
 	mockStorage.EXPECT().
 		GetActualCoins(gomock.Any(), titles).
 		Return(nil, entities.ErrInvalidParam)
 
-	service, err := cases.NewService(mockStorage, mockCryptoProvider)
+	service, err := cases.NewService(mockStorage, mockCryptoProvider, nil)
 	require.NoError(t, err)
 
 	coins, err := service.GetLastRates(context.Background(), titles)
@@ -73,6 +89,45 @@ func Test_GetLastRates_Error(t *testing.T) {
 	assert.ErrorIs(t, err, entities.ErrInvalidParam)
 }
 
+func Test_GetLastRates_NormalizesTitles(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStorage := testdata.NewMockStorage(ctrl)
+	mockCryptoProvider := testdata.NewMockCryptoProvider(ctrl)
+
+	btc := []entities.Coin{{CoinName: "BTC", Price: 28000}}
+	mockCryptoProvider.EXPECT().
+		GetActualRates(gomock.Any(), []string{"BTC"}).
+		Return(btc, nil)
+	mockStorage.EXPECT().
+		Store(gomock.Any(), btc).
+		Return(entities.StoreResult{Inserted: 1}, nil)
+	mockStorage.EXPECT().
+		GetActualCoins(gomock.Any(), []string{"BTC"}).
+		Return(btc, nil)
+
+	service, err := cases.NewService(mockStorage, mockCryptoProvider, nil)
+	require.NoError(t, err)
+
+	coins, err := service.GetLastRates(context.Background(), []string{" btc", "BTC"})
+	require.NoError(t, err)
+	assert.Equal(t, btc, coins)
+
+	// недопустимые титулы и слишком длинные списки не доходят до провайдера
+	_, err = service.GetLastRates(context.Background(), []string{"BTC/USD"})
+	assert.ErrorIs(t, err, entities.ErrInvalidParam)
+
+	titles := make([]string, entities.MaxQueryTitles+1)
+	for i := range titles {
+		titles[i] = fmt.Sprintf("C%d", i)
+	}
+	_, err = service.GetLastRates(context.Background(), titles)
+	assert.ErrorIs(t, err, entities.ErrInvalidParam)
+}
+
 func Test_GetRatesWithAgg_Success(t *testing.T) {
 	t.Parallel()
 
@@ -96,7 +151,7 @@ func Test_GetRatesWithAgg_Success(t *testing.T) {
 		GetAggregateCoins(gomock.Any(), titles, "max").
 		Return(expectedCoins, nil)
 
-	service, err := cases.NewService(mockStorage, mockCryptoProvider)
+	service, err := cases.NewService(mockStorage, mockCryptoProvider, nil)
 	require.NoError(t, err)
 
 	coins, err := service.GetRatesWithAgg(context.Background(), titles, "max")
@@ -104,6 +159,29 @@ func Test_GetRatesWithAgg_Success(t *testing.T) {
 	assert.Equal(t, expectedCoins, coins)
 }
 
+func Test_GetRatesWithAgg_UnknownSymbol(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStorage := testdata.NewMockStorage(ctrl)
+	mockCryptoProvider := testdata.NewMockCryptoProvider(ctrl)
+
+	mockStorage.EXPECT().
+		GetCoinsList(gomock.Any()).
+		Return([]string{"BTC"}, nil)
+	mockCryptoProvider.EXPECT().
+		GetActualRates(gomock.Any(), []string{"XXX"}).
+		Return([]entities.Coin{}, nil)
+
+	service, err := cases.NewService(mockStorage, mockCryptoProvider, nil)
+	require.NoError(t, err)
+
+	_, err = service.GetRatesWithAgg(context.Background(), []string{"BTC", "XXX"}, "max")
+	assert.ErrorIs(t, err, entities.ErrUnknownSymbol)
+}
+
 func Test_GetRatesWithAgg_Error(t *testing.T) {
 	t.Parallel()
 
@@ -123,7 +201,7 @@ func Test_GetRatesWithAgg_Error(t *testing.T) {
 		GetAggregateCoins(gomock.Any(), titles, "max").
 		Return(nil, entities.ErrInvalidParam)
 
-	service, err := cases.NewService(mockStorage, mockCryptoProvider)
+	service, err := cases.NewService(mockStorage, mockCryptoProvider, nil)
 	require.NoError(t, err)
 
 	coins, err := service.GetRatesWithAgg(context.Background(), titles, "max")
@@ -148,21 +226,30 @@ func Test_ActualizeRates_Success(t *testing.T) {
 		Return(allTitles, nil)
 
 	// Определяем поведение мока CryptoProvider
-	actualRates := []entities.Coin{
-		{CoinName: "BTC", Price: 29000},
-		{CoinName: "ETH", Price: 1600},
+	observedAt := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	tickers := []entities.Ticker{
+		{CoinName: "BTC", Currency: "USD", Source: "cryptocompare", Price: 29000, MarketCap: 5.7e11, ObservedAt: observedAt},
+		{CoinName: "ETH", Currency: "USD", Source: "cryptocompare", Price: 1600, MarketCap: 1.9e11, ObservedAt: observedAt},
 	}
 	mockCryptoProvider.EXPECT().
-		GetActualRates(gomock.Any(), allTitles).
-		Return(actualRates, nil)
+		GetTickers(gomock.Any(), allTitles).
+		Return(tickers, nil)
 
-	// Определяем поведение мока Storage для сохранения новых монет
 	mockStorage.EXPECT().
-		Store(gomock.Any(), actualRates).
+		StoreTickers(gomock.Any(), tickers).
 		Return(nil)
 
+	// цены сохраняются из тех же тикеров
+	actualRates := []entities.Coin{
+		{CoinName: "BTC", Currency: "USD", Source: "cryptocompare", Price: 29000, ObservedAt: observedAt},
+		{CoinName: "ETH", Currency: "USD", Source: "cryptocompare", Price: 1600, ObservedAt: observedAt},
+	}
+	mockStorage.EXPECT().
+		Store(gomock.Any(), actualRates).
+		Return(entities.StoreResult{Inserted: 1, Skipped: 1}, nil)
+
 	// Создаем сервис с моками
-	service, err := cases.NewService(mockStorage, mockCryptoProvider)
+	service, err := cases.NewService(mockStorage, mockCryptoProvider, nil)
 	require.NoError(t, err)
 
 	// Вызываем метод ActualizeRates
@@ -186,10 +273,10 @@ func Test_ActualizeRates_CryptoProviderError(t *testing.T) {
 		Return(allTitles, nil)
 
 	mockCryptoProvider.EXPECT().
-		GetActualRates(gomock.Any(), allTitles).
+		GetTickers(gomock.Any(), allTitles).
 		Return(nil, errors.New("crypto provider error"))
 
-	service, err := cases.NewService(mockStorage, mockCryptoProvider)
+	service, err := cases.NewService(mockStorage, mockCryptoProvider, nil)
 	require.NoError(t, err)
 
 	err = service.ActualizeRates(context.Background())
@@ -197,7 +284,7 @@ func Test_ActualizeRates_CryptoProviderError(t *testing.T) {
 	assert.Contains(t, err.Error(), "crypto provider error")
 }
 
-func Test_GetLastRates_AllCoinsExist(t *testing.T) {
+func Test_GetLastRates_FreshInStorage(t *testing.T) {
 	t.Parallel()
 
 	ctrl := gomock.NewController(t)
@@ -206,31 +293,32 @@ func Test_GetLastRates_AllCoinsExist(t *testing.T) {
 	mockStorage := testdata.NewMockStorage(ctrl)
 	mockCryptoProvider := testdata.NewMockCryptoProvider(ctrl)
 
-	// Определяем поведение моков
 	requestTitles := []string{"BTC", "ETH"}
-	existingTitles := []string{"BTC", "ETH"}
 	expectedCoins := []entities.Coin{
-		{CoinName: "BTC", Price: 28000},
-		{CoinName: "ETH", Price: 1500},
+		{CoinName: "BTC", Price: 28000, CreatedAt: time.Now().Add(-10 * time.Second)},
+		{CoinName: "ETH", Price: 1500, CreatedAt: time.Now().Add(-20 * time.Second)},
 	}
 
-	mockStorage.EXPECT().
-		GetCoinsList(gomock.Any()).
-		Return(existingTitles, nil)
-
+	// провайдер не вызывается: цены в хранилище моложе maxAge
 	mockStorage.EXPECT().
 		GetActualCoins(gomock.Any(), requestTitles).
-		Return(expectedCoins, nil)
+		Return(expectedCoins, nil).
+		Times(1)
 
-	service, err := cases.NewService(mockStorage, mockCryptoProvider)
+	service, err := cases.NewService(mockStorage, mockCryptoProvider, nil, cases.WithMaxAge(time.Minute))
 	require.NoError(t, err)
 
 	coins, err := service.GetLastRates(context.Background(), requestTitles)
 	assert.NoError(t, err)
 	assert.Equal(t, expectedCoins, coins)
+
+	// второй запрос обслуживается из памяти
+	coins, err = service.GetLastRates(context.Background(), requestTitles)
+	assert.NoError(t, err)
+	assert.Equal(t, expectedCoins, coins)
 }
 
-func Test_GetLastRates_AddMissingCoins(t *testing.T) {
+func Test_GetLastRates_RefreshStaleCoins(t *testing.T) {
 	t.Parallel()
 
 	ctrl := gomock.NewController(t)
@@ -239,43 +327,516 @@ func Test_GetLastRates_AddMissingCoins(t *testing.T) {
 	mockStorage := testdata.NewMockStorage(ctrl)
 	mockCryptoProvider := testdata.NewMockCryptoProvider(ctrl)
 
-	// Определяем поведение моков
 	requestTitles := []string{"BTC", "ETH", "LTC"}
-	existingTitles := []string{"BTC", "ETH"}
-	missingTitles := []string{"LTC"}
+	staleTitles := []string{"ETH", "LTC"}
 
-	newCoins := []entities.Coin{
+	btc := entities.Coin{CoinName: "BTC", Price: 28000, CreatedAt: time.Now()}
+	staleEth := entities.Coin{CoinName: "ETH", Price: 1400, CreatedAt: time.Now().Add(-time.Hour)}
+	freshCoins := []entities.Coin{
+		{CoinName: "ETH", Price: 1500},
 		{CoinName: "LTC", Price: 50},
 	}
+	storedCoins := []entities.Coin{
+		{CoinName: "ETH", Price: 1500, CreatedAt: time.Now()},
+		{CoinName: "LTC", Price: 50, CreatedAt: time.Now()},
+	}
 
 	mockStorage.EXPECT().
-		GetCoinsList(gomock.Any()).
-		Return(existingTitles, nil)
+		GetActualCoins(gomock.Any(), requestTitles).
+		Return([]entities.Coin{btc, staleEth}, nil)
 
 	mockCryptoProvider.EXPECT().
-		GetActualRates(gomock.Any(), missingTitles).
-		Return(newCoins, nil)
+		GetActualRates(gomock.Any(), staleTitles).
+		Return(freshCoins, nil)
 
-	for _, coin := range newCoins {
-		mockStorage.EXPECT().
-			Store(gomock.Any(), []entities.Coin{coin}).
-			Return(nil)
-	}
+	mockStorage.EXPECT().
+		Store(gomock.Any(), freshCoins).
+		Return(entities.StoreResult{Inserted: len(freshCoins)}, nil)
 
 	mockStorage.EXPECT().
-		GetActualCoins(gomock.Any(), requestTitles).
-		Return(append([]entities.Coin{
-			{CoinName: "BTC", Price: 28000},
-			{CoinName: "ETH", Price: 1500},
-		}, newCoins...), nil)
+		GetActualCoins(gomock.Any(), staleTitles).
+		Return(storedCoins, nil)
 
-	service, err := cases.NewService(mockStorage, mockCryptoProvider)
+	service, err := cases.NewService(mockStorage, mockCryptoProvider, nil, cases.WithMaxAge(time.Minute))
 	require.NoError(t, err)
 
 	coins, err := service.GetLastRates(context.Background(), requestTitles)
 	assert.NoError(t, err)
-	assert.Equal(t, append([]entities.Coin{
-		{CoinName: "BTC", Price: 28000},
-		{CoinName: "ETH", Price: 1500},
-	}, newCoins...), coins)
+	assert.Equal(t, append([]entities.Coin{btc}, storedCoins...), coins)
+}
+
+func Test_GetLastRates_CachesUnchangedQuote(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStorage := testdata.NewMockStorage(ctrl)
+	mockCryptoProvider := testdata.NewMockCryptoProvider(ctrl)
+
+	titles := []string{"DOGE"}
+	observedAt := time.Now().Add(-10 * time.Minute)
+	quote := []entities.Coin{{CoinName: "DOGE", Price: 0.1, ObservedAt: observedAt}}
+	// котировка не менялась, поэтому в хранилище осталась старая строка
+	stored := []entities.Coin{{CoinName: "DOGE", Price: 0.1, ObservedAt: observedAt, CreatedAt: observedAt}}
+
+	mockStorage.EXPECT().
+		GetActualCoins(gomock.Any(), titles).
+		Return(stored, nil).
+		Times(2)
+	mockCryptoProvider.EXPECT().
+		GetActualRates(gomock.Any(), titles).
+		Return(quote, nil).
+		Times(1)
+	mockStorage.EXPECT().
+		Store(gomock.Any(), quote).
+		Return(entities.StoreResult{Skipped: 1}, nil).
+		Times(1)
+
+	service, err := cases.NewService(mockStorage, mockCryptoProvider, nil, cases.WithMaxAge(time.Minute))
+	require.NoError(t, err)
+
+	for i := 0; i < 2; i++ {
+		coins, err := service.GetLastRates(context.Background(), titles)
+		require.NoError(t, err)
+		assert.Equal(t, stored, coins)
+	}
+}
+
+func Test_GetLastRates_CoalescesConcurrentRequests(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStorage := testdata.NewMockStorage(ctrl)
+	mockCryptoProvider := testdata.NewMockCryptoProvider(ctrl)
+
+	titles := []string{"BTC"}
+	freshCoins := []entities.Coin{{CoinName: "BTC", Price: 28000}}
+	storedCoins := []entities.Coin{{CoinName: "BTC", Price: 28000, CreatedAt: time.Now()}}
+
+	const callers = 5
+	var started sync.WaitGroup
+	started.Add(callers)
+	release := make(chan struct{})
+
+	mockStorage.EXPECT().
+		GetActualCoins(gomock.Any(), titles).
+		DoAndReturn(func(context.Context, []string) ([]entities.Coin, error) {
+			started.Done()
+			return nil, nil
+		}).
+		Times(callers)
+
+	mockCryptoProvider.EXPECT().
+		GetActualRates(gomock.Any(), titles).
+		DoAndReturn(func(context.Context, []string) ([]entities.Coin, error) {
+			<-release
+			return freshCoins, nil
+		}).
+		Times(1)
+
+	mockStorage.EXPECT().
+		Store(gomock.Any(), freshCoins).
+		Return(entities.StoreResult{Inserted: len(freshCoins)}, nil).
+		Times(1)
+
+	mockStorage.EXPECT().
+		GetActualCoins(gomock.Any(), titles).
+		Return(storedCoins, nil).
+		Times(1)
+
+	service, err := cases.NewService(mockStorage, mockCryptoProvider, nil, cases.WithMaxAge(time.Minute))
+	require.NoError(t, err)
+
+	var wg sync.WaitGroup
+	results := make([][]entities.Coin, callers)
+	for i := 0; i < callers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			coins, err := service.GetLastRates(context.Background(), titles)
+			assert.NoError(t, err)
+			results[i] = coins
+		}(i)
+	}
+
+	started.Wait()
+	// даём всем вызовам дойти до провайдера прежде чем он ответит
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	for _, coins := range results {
+		assert.Equal(t, storedCoins, coins)
+	}
+}
+
+func Test_GetLastRates_CoalescesOverlappingTitles(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStorage := testdata.NewMockStorage(ctrl)
+	mockCryptoProvider := testdata.NewMockCryptoProvider(ctrl)
+
+	freshCoins := []entities.Coin{{CoinName: "BTC", Price: 28000}, {CoinName: "ETH", Price: 1500}}
+	storedCoins := []entities.Coin{
+		{CoinName: "BTC", Price: 28000, CreatedAt: time.Now()},
+		{CoinName: "ETH", Price: 1500, CreatedAt: time.Now()},
+	}
+
+	called := make(chan struct{})
+	release := make(chan struct{})
+	mockCryptoProvider.EXPECT().
+		GetActualRates(gomock.Any(), []string{"BTC", "ETH"}).
+		DoAndReturn(func(context.Context, []string) ([]entities.Coin, error) {
+			close(called)
+			<-release
+			return freshCoins, nil
+		}).
+		Times(1)
+	mockStorage.EXPECT().
+		Store(gomock.Any(), freshCoins).
+		Return(entities.StoreResult{Inserted: len(freshCoins)}, nil)
+	mockStorage.EXPECT().
+		GetActualCoins(gomock.Any(), []string{"BTC", "ETH"}).
+		Return(storedCoins, nil)
+
+	service, err := cases.NewService(mockStorage, mockCryptoProvider, nil)
+	require.NoError(t, err)
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		coins, err := service.GetLastRates(context.Background(), []string{"BTC", "ETH"})
+		assert.NoError(t, err)
+		assert.Equal(t, storedCoins, coins)
+	}()
+
+	<-called
+	// BTC уже запрошен у провайдера: второй вызов ждёт его, а не делает свой запрос
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		coins, err := service.GetLastRates(context.Background(), []string{"BTC"})
+		assert.NoError(t, err)
+		assert.Equal(t, storedCoins[:1], coins)
+	}()
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+}
+
+func Test_Convert_Triangulated(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStorage := testdata.NewMockStorage(ctrl)
+	mockCryptoProvider := testdata.NewMockCryptoProvider(ctrl)
+
+	now := time.Now()
+	mockStorage.EXPECT().
+		GetActualCoins(gomock.Any(), []string{"ETH", "BTC"}).
+		Return([]entities.Coin{
+			{CoinName: "BTC", Price: 50000, CreatedAt: now},
+			{CoinName: "ETH", Price: 2500, ObservedAt: now.Add(-2 * time.Minute), CreatedAt: now.Add(-time.Minute)},
+		}, nil)
+
+	service, err := cases.NewService(mockStorage, mockCryptoProvider, nil)
+	require.NoError(t, err)
+
+	conversion, err := service.Convert(context.Background(), "eth", "BTC", 2.5)
+	require.NoError(t, err)
+	assert.Equal(t, "USD", conversion.Via)
+	assert.InDelta(t, 0.05, conversion.Rate, 1e-12)
+	assert.InDelta(t, 0.125, conversion.Result, 1e-12)
+	assert.Equal(t, now.Add(-2*time.Minute), conversion.FromLeg.PriceAt())
+	// без времени наблюдения ногу датирует время записи
+	assert.Equal(t, now, conversion.ToLeg.PriceAt())
+}
+
+func Test_Convert_InvalidAmount(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStorage := testdata.NewMockStorage(ctrl)
+	mockStorage.EXPECT().
+		GetActualCoins(gomock.Any(), []string{"BTC"}).
+		Return([]entities.Coin{{CoinName: "BTC", Price: 1e-6, CreatedAt: time.Now()}}, nil)
+
+	service, err := cases.NewService(mockStorage, testdata.NewMockCryptoProvider(ctrl), nil)
+	require.NoError(t, err)
+
+	for _, amount := range []float64{0, -1, math.NaN(), math.Inf(1), math.Inf(-1)} {
+		_, err = service.Convert(context.Background(), "USD", "BTC", amount)
+		assert.ErrorIs(t, err, entities.ErrInvalidParam, "amount %v", amount)
+	}
+
+	_, err = service.Convert(context.Background(), "USD", "BTC", math.MaxFloat64)
+	assert.ErrorIs(t, err, entities.ErrInvalidParam)
+	assert.Contains(t, err.Error(), "too large")
+}
+
+func Test_Convert_DirectPair(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStorage := testdata.NewMockStorage(ctrl)
+	mockCryptoProvider := testdata.NewMockCryptoProvider(ctrl)
+
+	mockStorage.EXPECT().
+		GetActualCoins(gomock.Any(), []string{"BTC"}).
+		Return([]entities.Coin{{CoinName: "BTC", Price: 50000, CreatedAt: time.Now()}}, nil)
+
+	service, err := cases.NewService(mockStorage, mockCryptoProvider, nil)
+	require.NoError(t, err)
+
+	conversion, err := service.Convert(context.Background(), "USD", "BTC", 1000)
+	require.NoError(t, err)
+	assert.Empty(t, conversion.Via)
+	assert.Nil(t, conversion.FromLeg)
+	assert.InDelta(t, 0.02, conversion.Result, 1e-12)
+}
+
+func Test_Convert_StaleLeg(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStorage := testdata.NewMockStorage(ctrl)
+	mockCryptoProvider := testdata.NewMockCryptoProvider(ctrl)
+
+	mockStorage.EXPECT().
+		GetActualCoins(gomock.Any(), []string{"ETH", "BTC"}).
+		Return([]entities.Coin{
+			{CoinName: "BTC", Price: 50000, CreatedAt: time.Now()},
+			{CoinName: "ETH", Price: 2500, CreatedAt: time.Now().Add(-time.Hour)},
+		}, nil)
+
+	service, err := cases.NewService(mockStorage, mockCryptoProvider, nil, cases.WithMaxStaleness(10*time.Minute))
+	require.NoError(t, err)
+
+	conversion, err := service.Convert(context.Background(), "ETH", "BTC", 1)
+	assert.Nil(t, conversion)
+	assert.ErrorIs(t, err, entities.ErrStaleData)
+	assert.Contains(t, err.Error(), "price of ETH")
+}
+
+func Test_Convert_StaleLegIngestedLate(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStorage := testdata.NewMockStorage(ctrl)
+	mockStorage.EXPECT().
+		GetActualCoins(gomock.Any(), []string{"BTC"}).
+		Return([]entities.Coin{
+			{CoinName: "BTC", Price: 50000, ObservedAt: time.Now().Add(-time.Hour), CreatedAt: time.Now()},
+		}, nil)
+
+	service, err := cases.NewService(mockStorage, testdata.NewMockCryptoProvider(ctrl), nil, cases.WithMaxStaleness(10*time.Minute))
+	require.NoError(t, err)
+
+	_, err = service.Convert(context.Background(), "BTC", "USD", 1)
+	assert.ErrorIs(t, err, entities.ErrStaleData)
+}
+
+func Test_GetTickers_Sorted(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStorage := testdata.NewMockStorage(ctrl)
+	mockStorage.EXPECT().
+		GetTickers(gomock.Any(), []string(nil)).
+		Return([]entities.Ticker{
+			{CoinName: "BTC", MarketCap: 1000, Change24hPct: 1},
+			{CoinName: "DOGE", MarketCap: 10, Change24hPct: -8},
+			{CoinName: "ETH", MarketCap: 300, Change24hPct: 4},
+		}, nil)
+
+	service, err := cases.NewService(mockStorage, testdata.NewMockCryptoProvider(ctrl), nil)
+	require.NoError(t, err)
+
+	tickers, err := service.GetTickers(context.Background(), nil,
+		entities.TickerSort{Field: entities.TickerSortChange, Desc: true})
+	require.NoError(t, err)
+	require.Len(t, tickers, 3)
+	assert.Equal(t, "ETH", tickers[0].CoinName)
+	assert.Equal(t, "BTC", tickers[1].CoinName)
+	assert.Equal(t, "DOGE", tickers[2].CoinName)
+}
+
+func Test_GetMarketMovers(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStorage := testdata.NewMockStorage(ctrl)
+	mockStorage.EXPECT().
+		GetMarketMovers(gomock.Any(), "USD", gomock.Any(), 2).
+		Return(&entities.MarketOverview{
+			Gainers:      []entities.MarketMover{{CoinName: "DOGE", ChangePct: 12}, {CoinName: "ETH", ChangePct: 4}},
+			Losers:       []entities.MarketMover{{CoinName: "SOL", ChangePct: -6}},
+			MostVolatile: []entities.MarketMover{{CoinName: "DOGE", Volatility: 9}, {CoinName: "SOL", Volatility: 5}},
+			Up:           2,
+			Down:         1,
+		}, nil)
+
+	service, err := cases.NewService(mockStorage, testdata.NewMockCryptoProvider(ctrl), nil)
+	require.NoError(t, err)
+
+	overview, err := service.GetMarketMovers(context.Background(), 24*time.Hour, 2)
+	require.NoError(t, err)
+	assert.Equal(t, 24*time.Hour, overview.Window)
+	assert.WithinDuration(t, time.Now().Add(-24*time.Hour), overview.Since, time.Minute)
+	assert.Equal(t, 2, overview.Up)
+	require.Len(t, overview.Gainers, 2)
+	assert.Equal(t, "DOGE", overview.Gainers[0].CoinName)
+
+	_, err = service.GetMarketMovers(context.Background(), 24*time.Hour, 0)
+	assert.ErrorIs(t, err, entities.ErrInvalidParam)
+}
+
+func Test_GetIndicator_SMA(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	start := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	candles := make([]entities.Candle, 0, 5)
+	for i, price := range []float64{10, 11, 12, 13, 14} {
+		candles = append(candles, entities.Candle{Bucket: start.Add(time.Duration(i) * time.Hour), Close: price})
+	}
+
+	mockStorage := testdata.NewMockStorage(ctrl)
+	mockStorage.EXPECT().
+		GetCandles(gomock.Any(), "BTC", time.Hour, gomock.Any()).
+		Return(candles, nil)
+
+	service, err := cases.NewService(mockStorage, testdata.NewMockCryptoProvider(ctrl), nil)
+	require.NoError(t, err)
+
+	series, err := service.GetIndicator(context.Background(), entities.IndicatorQuery{
+		CoinName: "BTC",
+		Type:     entities.IndicatorSMA,
+		Period:   3,
+		Interval: time.Hour,
+		Limit:    2,
+	})
+	require.NoError(t, err)
+	require.Len(t, series.Points, 2)
+	assert.Equal(t, start.Add(3*time.Hour), series.Points[0].Time)
+	assert.InDelta(t, 12, series.Points[0].Values["value"], 1e-9)
+	assert.Equal(t, start.Add(4*time.Hour), series.Points[1].Time)
+	assert.InDelta(t, 13, series.Points[1].Values["value"], 1e-9)
+}
+
+func Test_GetIndicator_NotEnoughHistory(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStorage := testdata.NewMockStorage(ctrl)
+	mockStorage.EXPECT().
+		GetCandles(gomock.Any(), "BTC", time.Hour, gomock.Any()).
+		Return([]entities.Candle{{Close: 10}, {Close: 11}}, nil)
+
+	service, err := cases.NewService(mockStorage, testdata.NewMockCryptoProvider(ctrl), nil)
+	require.NoError(t, err)
+
+	_, err = service.GetIndicator(context.Background(), entities.IndicatorQuery{
+		CoinName: "BTC",
+		Type:     entities.IndicatorRSI,
+		Interval: time.Hour,
+	})
+	assert.ErrorIs(t, err, entities.ErrNotFound)
+}
+
+func hourlyCandles(start time.Time, closes ...float64) []entities.Candle {
+	candles := make([]entities.Candle, 0, len(closes))
+	for i, price := range closes {
+		candles = append(candles, entities.Candle{Bucket: start.Add(time.Duration(i) * time.Hour), Close: price})
+	}
+	return candles
+}
+
+func Test_GetVolatility(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	start := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	mockStorage := testdata.NewMockStorage(ctrl)
+	mockStorage.EXPECT().GetCoinsList(gomock.Any()).Return([]string{"BTC", "USDT"}, nil)
+	mockStorage.EXPECT().
+		GetCandles(gomock.Any(), "BTC", time.Hour, gomock.Any()).
+		Return(hourlyCandles(start, 100, 110, 99), nil)
+	mockStorage.EXPECT().
+		GetCandles(gomock.Any(), "USDT", time.Hour, gomock.Any()).
+		Return(hourlyCandles(start, 1, 1, 1), nil)
+
+	service, err := cases.NewService(mockStorage, testdata.NewMockCryptoProvider(ctrl), nil)
+	require.NoError(t, err)
+
+	volatility, err := service.GetVolatility(context.Background(), nil, 24*time.Hour, time.Hour)
+	require.NoError(t, err)
+	require.Len(t, volatility, 2)
+
+	// доходности ln(1.1) и ln(0.9), годовая волатильность при 8760 часах в году
+	expected := math.Abs(math.Log(1.1)-math.Log(0.9)) / math.Sqrt2 * math.Sqrt(8760)
+	assert.Equal(t, "BTC", volatility[0].CoinName)
+	assert.Equal(t, 2, volatility[0].Returns)
+	assert.InDelta(t, expected, volatility[0].Volatility, 1e-9)
+	assert.Zero(t, volatility[1].Volatility)
+}
+
+func Test_GetCorrelation_AlignsBuckets(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	start := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	eth := hourlyCandles(start, 10, 11, 12, 11, 13)
+	// у ETH пропущен час — доходности через разрыв не считаются
+	eth = append(eth[:2], eth[3:]...)
+
+	mockStorage := testdata.NewMockStorage(ctrl)
+	mockStorage.EXPECT().
+		GetCandles(gomock.Any(), "BTC", time.Hour, gomock.Any()).
+		Return(hourlyCandles(start, 100, 110, 120, 110, 130), nil)
+	mockStorage.EXPECT().
+		GetCandles(gomock.Any(), "ETH", time.Hour, gomock.Any()).
+		Return(eth, nil)
+
+	service, err := cases.NewService(mockStorage, testdata.NewMockCryptoProvider(ctrl), nil)
+	require.NoError(t, err)
+
+	matrix, err := service.GetCorrelation(context.Background(), []string{"ETH", "BTC", "ETH"}, 24*time.Hour, time.Hour)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"BTC", "ETH"}, matrix.Titles)
+	assert.Equal(t, 2, matrix.Observations[0][1])
+	assert.InDelta(t, 1, matrix.Matrix[0][1], 1e-9)
+	assert.InDelta(t, 1, matrix.Matrix[0][0], 1e-9)
+
+	_, err = service.GetCorrelation(context.Background(), []string{"BTC"}, 24*time.Hour, time.Hour)
+	assert.ErrorIs(t, err, entities.ErrInvalidParam)
 }
@@ -0,0 +1,186 @@
+package cases
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"Cryptoproject/internal/entities"
+)
+
+// WithStaleFallback makes GetLastRates and GetQuotes serve the last stored
+// price of a coin, marked as stale, when the provider fails and the price is
+// not older than maxAge. The failed titles are then refreshed in the
+// background. A zero maxAge disables the fallback and the background refresh.
+func WithStaleFallback(maxAge time.Duration) ServiceOption {
+	return func(s *Service) {
+		s.staleMaxAge = maxAge
+	}
+}
+
+// revalidator remembers titles with a background refresh in flight, so a
+// failing provider is not asked for the same coins by every request, and
+// tracks the refreshes so Shutdown can wait for them.
+type revalidator struct {
+	mu      sync.Mutex
+	pending map[string]struct{}
+	stopped bool
+	wg      sync.WaitGroup
+}
+
+func newRevalidator() *revalidator {
+	return &revalidator{pending: make(map[string]struct{})}
+}
+
+// start marks titles as being refreshed and returns those that were not
+// already. Each started refresh must be ended with done. After stop nothing
+// is started.
+func (r *revalidator) start(titles []string) []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.stopped {
+		return nil
+	}
+	started := make([]string, 0, len(titles))
+	for _, title := range titles {
+		if _, ok := r.pending[title]; ok {
+			continue
+		}
+		r.pending[title] = struct{}{}
+		started = append(started, title)
+	}
+	if len(started) > 0 {
+		r.wg.Add(1)
+	}
+	return started
+}
+
+func (r *revalidator) done(titles []string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, title := range titles {
+		delete(r.pending, title)
+	}
+	r.wg.Done()
+}
+
+// stop prevents new refreshes and waits for those in flight until ctx is done.
+func (r *revalidator) stop(ctx context.Context) error {
+	r.mu.Lock()
+	r.stopped = true
+	r.mu.Unlock()
+
+	finished := make(chan struct{})
+	go func() {
+		r.wg.Wait()
+		close(finished)
+	}()
+	select {
+	case <-finished:
+		return nil
+	case <-ctx.Done():
+		return errors.Wrap(ctx.Err(), "background refreshes did not finish")
+	}
+}
+
+// staleRates falls back to stored prices of titles after the provider failed
+// with cause. stored may hold coins already read from storage; when it is nil
+// the prices are read again. cause is returned when the fallback is disabled,
+// the failure is not the provider's, or some title has no price younger than
+// the fallback limit.
+func (s *Service) staleRates(ctx context.Context, titles []string, stored []entities.Coin, cause error) ([]entities.Coin, error) {
+	const op = "cases.staleRates"
+	logger := s.logger.With(slog.String("op", op))
+
+	if s.staleMaxAge <= 0 {
+		return nil, cause
+	}
+	if _, ok := providerFailure(cause); !ok {
+		return nil, cause
+	}
+
+	byTitle, err := s.storedByTitle(ctx, titles, stored)
+	if err != nil {
+		logger.Error("Failed to get stored coins for fallback",
+			slog.String("error", err.Error()))
+		return nil, cause
+	}
+
+	now := s.now()
+	coins := make([]entities.Coin, 0, len(titles))
+	for _, title := range titles {
+		coin, ok := byTitle[title]
+		if !ok {
+			return nil, errors.Wrapf(cause, "no stored price of %s to fall back to", title)
+		}
+		age := coin.PriceAge(now)
+		if age > s.staleMaxAge {
+			return nil, errors.Wrapf(cause, "stored price of %s is %s old, fallback limit is %s",
+				title, age.Truncate(time.Second), s.staleMaxAge)
+		}
+		coin.Stale = true
+		coin.Age = age
+		coins = append(coins, coin)
+	}
+
+	logger.Warn("Provider failed, serving stored prices",
+		slog.Any("titles", titles),
+		slog.String("error", cause.Error()))
+	s.revalidate(titles)
+	return coins, nil
+}
+
+// storedByTitle returns the stored prices of titles by title. stored may hold
+// coins already read from storage; when it is nil the prices are read again.
+func (s *Service) storedByTitle(ctx context.Context, titles []string, stored []entities.Coin) (map[string]entities.Coin, error) {
+	if stored == nil {
+		var err error
+		stored, err = s.storage.GetActualCoins(ctx, titles)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to get actual coins from storage")
+		}
+	}
+
+	byTitle := make(map[string]entities.Coin, len(stored))
+	for _, coin := range stored {
+		byTitle[coin.CoinName] = coin
+	}
+	return byTitle, nil
+}
+
+// revalidate refreshes titles from the provider in the background. The
+// refresh is not tied to any request: the provider call may be shared with
+// other callers, so it runs to the end and Shutdown waits for it.
+func (s *Service) revalidate(titles []string) {
+	const op = "cases.revalidate"
+	logger := s.logger.With(slog.String("op", op))
+
+	titles = s.revalidating.start(titles)
+	if len(titles) == 0 {
+		return
+	}
+
+	go func() {
+		defer s.revalidating.done(titles)
+
+		if _, err := s.refreshRates(context.Background(), titles); err != nil {
+			logger.Warn("Background refresh failed",
+				slog.Any("titles", titles),
+				slog.String("error", err.Error()))
+			return
+		}
+		logger.Info("Background refresh succeeded", slog.Any("titles", titles))
+	}()
+}
+
+// Shutdown stops starting background refreshes and waits for those in
+// flight to finish until ctx is done.
+func (s *Service) Shutdown(ctx context.Context) error {
+	s.logger.Info("Stopping background refreshes")
+	return s.revalidating.stop(ctx)
+}
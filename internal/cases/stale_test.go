@@ -0,0 +1,152 @@
+package cases_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"Cryptoproject/internal/cases"
+	"Cryptoproject/internal/cases/testdata"
+	"Cryptoproject/internal/entities"
+)
+
+func Test_GetLastRates_StaleFallback(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStorage := testdata.NewMockStorage(ctrl)
+	mockCryptoProvider := testdata.NewMockCryptoProvider(ctrl)
+
+	titles := []string{"BTC"}
+	stored := entities.Coin{CoinName: "BTC", Price: 28000, ObservedAt: time.Now().Add(-30 * time.Second)}
+	fresh := entities.Coin{CoinName: "BTC", Price: 28100, ObservedAt: time.Now()}
+
+	gomock.InOrder(
+		mockCryptoProvider.EXPECT().
+			GetActualRates(gomock.Any(), titles).
+			Return(nil, errors.Wrap(entities.ErrProviderUnavailable, "timeout")),
+		mockStorage.EXPECT().
+			GetActualCoins(gomock.Any(), titles).
+			Return([]entities.Coin{stored}, nil),
+	)
+
+	// фоновое обновление после отдачи устаревшей цены
+	refreshed := make(chan struct{})
+	gomock.InOrder(
+		mockCryptoProvider.EXPECT().
+			GetActualRates(gomock.Any(), titles).
+			Return([]entities.Coin{fresh}, nil),
+		mockStorage.EXPECT().
+			Store(gomock.Any(), []entities.Coin{fresh}).
+			Return(entities.StoreResult{Inserted: 1}, nil),
+		mockStorage.EXPECT().
+			GetActualCoins(gomock.Any(), titles).
+			DoAndReturn(func(context.Context, []string) ([]entities.Coin, error) {
+				defer close(refreshed)
+				return []entities.Coin{fresh}, nil
+			}),
+	)
+
+	service, err := cases.NewService(mockStorage, mockCryptoProvider, nil, cases.WithStaleFallback(time.Minute))
+	require.NoError(t, err)
+
+	coins, err := service.GetLastRates(context.Background(), titles)
+	require.NoError(t, err)
+	require.Len(t, coins, 1)
+	assert.Equal(t, 28000.0, coins[0].Price)
+	assert.True(t, coins[0].Stale)
+	assert.GreaterOrEqual(t, coins[0].Age, 30*time.Second)
+
+	<-refreshed
+}
+
+func Test_GetLastRates_StaleFallbackTooOld(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStorage := testdata.NewMockStorage(ctrl)
+	mockCryptoProvider := testdata.NewMockCryptoProvider(ctrl)
+
+	titles := []string{"BTC", "ETH"}
+	mockCryptoProvider.EXPECT().
+		GetActualRates(gomock.Any(), titles).
+		Return(nil, errors.Wrap(entities.ErrProviderUnavailable, "timeout"))
+	mockStorage.EXPECT().
+		GetActualCoins(gomock.Any(), titles).
+		Return([]entities.Coin{
+			{CoinName: "BTC", Price: 28000, ObservedAt: time.Now().Add(-30 * time.Second)},
+			{CoinName: "ETH", Price: 1500, ObservedAt: time.Now().Add(-time.Hour)},
+		}, nil)
+
+	service, err := cases.NewService(mockStorage, mockCryptoProvider, nil, cases.WithStaleFallback(time.Minute))
+	require.NoError(t, err)
+
+	_, err = service.GetLastRates(context.Background(), titles)
+	assert.ErrorIs(t, err, entities.ErrProviderUnavailable)
+	assert.Contains(t, err.Error(), "stored price of ETH")
+}
+
+func Test_Shutdown_WaitsForBackgroundRefresh(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStorage := testdata.NewMockStorage(ctrl)
+	mockCryptoProvider := testdata.NewMockCryptoProvider(ctrl)
+
+	titles := []string{"BTC"}
+	stored := entities.Coin{CoinName: "BTC", Price: 28000, ObservedAt: time.Now().Add(-30 * time.Second)}
+	providerErr := errors.Wrap(entities.ErrProviderUnavailable, "timeout")
+
+	mockCryptoProvider.EXPECT().
+		GetActualRates(gomock.Any(), titles).
+		Return(nil, providerErr)
+	mockStorage.EXPECT().
+		GetActualCoins(gomock.Any(), titles).
+		Return([]entities.Coin{stored}, nil).
+		Times(2)
+
+	// фоновое обновление висит, пока его не отпустят
+	started := make(chan struct{})
+	release := make(chan struct{})
+	mockCryptoProvider.EXPECT().
+		GetActualRates(gomock.Any(), titles).
+		DoAndReturn(func(context.Context, []string) ([]entities.Coin, error) {
+			close(started)
+			<-release
+			return nil, providerErr
+		})
+
+	service, err := cases.NewService(mockStorage, mockCryptoProvider, nil, cases.WithStaleFallback(time.Minute))
+	require.NoError(t, err)
+
+	_, err = service.GetLastRates(context.Background(), titles)
+	require.NoError(t, err)
+	<-started
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, service.Shutdown(ctx), context.DeadlineExceeded)
+
+	close(release)
+	require.NoError(t, service.Shutdown(context.Background()))
+
+	// после остановки фоновые обновления не запускаются; повторный вызов провайдера
+	// без ожидания вызовет ошибку мока
+	mockCryptoProvider.EXPECT().
+		GetActualRates(gomock.Any(), titles).
+		Return(nil, providerErr)
+	_, err = service.GetLastRates(context.Background(), titles)
+	require.NoError(t, err)
+	require.NoError(t, service.Shutdown(context.Background()))
+}
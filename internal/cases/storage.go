@@ -2,14 +2,43 @@ package cases
 
 import (
 	"context"
+	"time"
 
 	"Cryptoproject/internal/entities"
 )
 
 //go:generate mockgen -source=storage.go -destination=./testdata/storage.go -package=testdata
 type Storage interface {
-	Store(ctx context.Context, coins []entities.Coin) error
+	Store(ctx context.Context, coins []entities.Coin) (entities.StoreResult, error)
 	GetCoinsList(ctx context.Context) ([]string, error)
 	GetActualCoins(ctx context.Context, titles []string) ([]entities.Coin, error)
 	GetAggregateCoins(ctx context.Context, titles []string, aggFuncTitle string) ([]entities.Coin, error)
+	GetCoinsAt(ctx context.Context, titles []string, at time.Time) ([]entities.Coin, error)
+	// StoreTickers keeps the latest ticker per coin, currency and source.
+	StoreTickers(ctx context.Context, tickers []entities.Ticker) error
+	// GetTickers returns the latest tickers for titles, or for all coins when titles is empty.
+	GetTickers(ctx context.Context, titles []string) ([]entities.Ticker, error)
+	// GetMarketMovers ranks the watchlist coins quoted in currency and observed since the given
+	// time by their move since then and returns limit top gainers, losers and most volatile coins.
+	GetMarketMovers(ctx context.Context, currency string, since time.Time, limit int) (*entities.MarketOverview, error)
+	// GetCandles buckets the price history of a coin since the given time into candles of interval.
+	GetCandles(ctx context.Context, title string, interval time.Duration, since time.Time) ([]entities.Candle, error)
+	// GetCandlesBatch is GetCandles for several coins in one query, keyed by coin.
+	GetCandlesBatch(ctx context.Context, titles []string, interval time.Duration, since time.Time) (map[string][]entities.Candle, error)
+	// GetSummaries folds the price history of each coin since the given time into a single candle, keyed by coin.
+	GetSummaries(ctx context.Context, titles []string, since time.Time) (map[string]entities.Candle, error)
+	// GetRecentCoins returns the prices of titles observed since the given time, newest first per coin.
+	GetRecentCoins(ctx context.Context, titles []string, since time.Time) ([]entities.Coin, error)
+	// StoreQuarantine records quotes rejected by ingestion validation.
+	StoreQuarantine(ctx context.Context, quotes []entities.QuarantinedQuote) error
+	// AddToWatchlist starts tracking titles and returns how many were not tracked before.
+	AddToWatchlist(ctx context.Context, titles []string) (int, error)
+	// RemoveFromWatchlist stops tracking titles and returns how many were tracked.
+	RemoveFromWatchlist(ctx context.Context, titles []string) (int, error)
+	// BackfillCandles stores hourly history of a coin and returns the number of rows written.
+	BackfillCandles(ctx context.Context, title, currency string, candles []entities.Candle) (int64, error)
+	// StreamHistory passes the price history matching query to fn row by row, ordered by coin and time.
+	StreamHistory(ctx context.Context, query entities.ExportQuery, fn func(entities.HistoryRecord) error) error
+	// ImportCoins stores historical prices, skipping those already stored.
+	ImportCoins(ctx context.Context, coins []entities.Coin) (entities.StoreResult, error)
 }
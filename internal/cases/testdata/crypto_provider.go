@@ -8,6 +8,7 @@ import (
 	entities "Cryptoproject/internal/entities"
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 )
@@ -49,3 +50,33 @@ func (mr *MockCryptoProviderMockRecorder) GetActualRates(ctx, titles interface{}
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActualRates", reflect.TypeOf((*MockCryptoProvider)(nil).GetActualRates), ctx, titles)
 }
+
+// GetHistory mocks base method.
+func (m *MockCryptoProvider) GetHistory(ctx context.Context, title string, from, to time.Time) ([]entities.Candle, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHistory", ctx, title, from, to)
+	ret0, _ := ret[0].([]entities.Candle)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHistory indicates an expected call of GetHistory.
+func (mr *MockCryptoProviderMockRecorder) GetHistory(ctx, title, from, to interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHistory", reflect.TypeOf((*MockCryptoProvider)(nil).GetHistory), ctx, title, from, to)
+}
+
+// GetTickers mocks base method.
+func (m *MockCryptoProvider) GetTickers(ctx context.Context, titles []string) ([]entities.Ticker, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTickers", ctx, titles)
+	ret0, _ := ret[0].([]entities.Ticker)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTickers indicates an expected call of GetTickers.
+func (mr *MockCryptoProviderMockRecorder) GetTickers(ctx, titles interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTickers", reflect.TypeOf((*MockCryptoProvider)(nil).GetTickers), ctx, titles)
+}
@@ -0,0 +1,97 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: depeg_storage.go
+
+// Package testdata is a generated GoMock package.
+package testdata
+
+import (
+	entities "Cryptoproject/internal/entities"
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockDepegStorage is a mock of DepegStorage interface.
+type MockDepegStorage struct {
+	ctrl     *gomock.Controller
+	recorder *MockDepegStorageMockRecorder
+}
+
+// MockDepegStorageMockRecorder is the mock recorder for MockDepegStorage.
+type MockDepegStorageMockRecorder struct {
+	mock *MockDepegStorage
+}
+
+// NewMockDepegStorage creates a new mock instance.
+func NewMockDepegStorage(ctrl *gomock.Controller) *MockDepegStorage {
+	mock := &MockDepegStorage{ctrl: ctrl}
+	mock.recorder = &MockDepegStorageMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDepegStorage) EXPECT() *MockDepegStorageMockRecorder {
+	return m.recorder
+}
+
+// GetAlertEvents mocks base method.
+func (m *MockDepegStorage) GetAlertEvents(ctx context.Context, filter entities.AlertFilter) ([]entities.AlertEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAlertEvents", ctx, filter)
+	ret0, _ := ret[0].([]entities.AlertEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAlertEvents indicates an expected call of GetAlertEvents.
+func (mr *MockDepegStorageMockRecorder) GetAlertEvents(ctx, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAlertEvents", reflect.TypeOf((*MockDepegStorage)(nil).GetAlertEvents), ctx, filter)
+}
+
+// GetCandles mocks base method.
+func (m *MockDepegStorage) GetCandles(ctx context.Context, title string, interval time.Duration, since time.Time) ([]entities.Candle, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCandles", ctx, title, interval, since)
+	ret0, _ := ret[0].([]entities.Candle)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCandles indicates an expected call of GetCandles.
+func (mr *MockDepegStorageMockRecorder) GetCandles(ctx, title, interval, since interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCandles", reflect.TypeOf((*MockDepegStorage)(nil).GetCandles), ctx, title, interval, since)
+}
+
+// GetRecentCoins mocks base method.
+func (m *MockDepegStorage) GetRecentCoins(ctx context.Context, titles []string, since time.Time) ([]entities.Coin, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRecentCoins", ctx, titles, since)
+	ret0, _ := ret[0].([]entities.Coin)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRecentCoins indicates an expected call of GetRecentCoins.
+func (mr *MockDepegStorageMockRecorder) GetRecentCoins(ctx, titles, since interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRecentCoins", reflect.TypeOf((*MockDepegStorage)(nil).GetRecentCoins), ctx, titles, since)
+}
+
+// StoreAlertEvent mocks base method.
+func (m *MockDepegStorage) StoreAlertEvent(ctx context.Context, event entities.AlertEvent) (*entities.AlertEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StoreAlertEvent", ctx, event)
+	ret0, _ := ret[0].(*entities.AlertEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StoreAlertEvent indicates an expected call of StoreAlertEvent.
+func (mr *MockDepegStorageMockRecorder) StoreAlertEvent(ctx, event interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StoreAlertEvent", reflect.TypeOf((*MockDepegStorage)(nil).StoreAlertEvent), ctx, event)
+}
@@ -0,0 +1,112 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: portfolio_storage.go
+
+// Package testdata is a generated GoMock package.
+package testdata
+
+import (
+	entities "Cryptoproject/internal/entities"
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockPortfolioStorage is a mock of PortfolioStorage interface.
+type MockPortfolioStorage struct {
+	ctrl     *gomock.Controller
+	recorder *MockPortfolioStorageMockRecorder
+}
+
+// MockPortfolioStorageMockRecorder is the mock recorder for MockPortfolioStorage.
+type MockPortfolioStorageMockRecorder struct {
+	mock *MockPortfolioStorage
+}
+
+// NewMockPortfolioStorage creates a new mock instance.
+func NewMockPortfolioStorage(ctrl *gomock.Controller) *MockPortfolioStorage {
+	mock := &MockPortfolioStorage{ctrl: ctrl}
+	mock.recorder = &MockPortfolioStorageMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPortfolioStorage) EXPECT() *MockPortfolioStorageMockRecorder {
+	return m.recorder
+}
+
+// AddTransaction mocks base method.
+func (m *MockPortfolioStorage) AddTransaction(ctx context.Context, transaction entities.Transaction, check func([]entities.Transaction) error) (*entities.Transaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddTransaction", ctx, transaction, check)
+	ret0, _ := ret[0].(*entities.Transaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddTransaction indicates an expected call of AddTransaction.
+func (mr *MockPortfolioStorageMockRecorder) AddTransaction(ctx, transaction, check interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddTransaction", reflect.TypeOf((*MockPortfolioStorage)(nil).AddTransaction), ctx, transaction, check)
+}
+
+// CreatePortfolio mocks base method.
+func (m *MockPortfolioStorage) CreatePortfolio(ctx context.Context, portfolio entities.Portfolio) (*entities.Portfolio, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePortfolio", ctx, portfolio)
+	ret0, _ := ret[0].(*entities.Portfolio)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePortfolio indicates an expected call of CreatePortfolio.
+func (mr *MockPortfolioStorageMockRecorder) CreatePortfolio(ctx, portfolio interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePortfolio", reflect.TypeOf((*MockPortfolioStorage)(nil).CreatePortfolio), ctx, portfolio)
+}
+
+// GetPortfolio mocks base method.
+func (m *MockPortfolioStorage) GetPortfolio(ctx context.Context, id int64) (*entities.Portfolio, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPortfolio", ctx, id)
+	ret0, _ := ret[0].(*entities.Portfolio)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPortfolio indicates an expected call of GetPortfolio.
+func (mr *MockPortfolioStorageMockRecorder) GetPortfolio(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPortfolio", reflect.TypeOf((*MockPortfolioStorage)(nil).GetPortfolio), ctx, id)
+}
+
+// GetTransactions mocks base method.
+func (m *MockPortfolioStorage) GetTransactions(ctx context.Context, portfolioID int64, until time.Time) ([]entities.Transaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTransactions", ctx, portfolioID, until)
+	ret0, _ := ret[0].([]entities.Transaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTransactions indicates an expected call of GetTransactions.
+func (mr *MockPortfolioStorageMockRecorder) GetTransactions(ctx, portfolioID, until interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTransactions", reflect.TypeOf((*MockPortfolioStorage)(nil).GetTransactions), ctx, portfolioID, until)
+}
+
+// ListPortfolios mocks base method.
+func (m *MockPortfolioStorage) ListPortfolios(ctx context.Context) ([]entities.Portfolio, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPortfolios", ctx)
+	ret0, _ := ret[0].([]entities.Portfolio)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPortfolios indicates an expected call of ListPortfolios.
+func (mr *MockPortfolioStorageMockRecorder) ListPortfolios(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPortfolios", reflect.TypeOf((*MockPortfolioStorage)(nil).ListPortfolios), ctx)
+}
@@ -0,0 +1,126 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: retention_storage.go
+
+// Package testdata is a generated GoMock package.
+package testdata
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockRetentionStorage is a mock of RetentionStorage interface.
+type MockRetentionStorage struct {
+	ctrl     *gomock.Controller
+	recorder *MockRetentionStorageMockRecorder
+}
+
+// MockRetentionStorageMockRecorder is the mock recorder for MockRetentionStorage.
+type MockRetentionStorageMockRecorder struct {
+	mock *MockRetentionStorage
+}
+
+// NewMockRetentionStorage creates a new mock instance.
+func NewMockRetentionStorage(ctrl *gomock.Controller) *MockRetentionStorage {
+	mock := &MockRetentionStorage{ctrl: ctrl}
+	mock.recorder = &MockRetentionStorageMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRetentionStorage) EXPECT() *MockRetentionStorageMockRecorder {
+	return m.recorder
+}
+
+// DeleteHourlyBatch mocks base method.
+func (m *MockRetentionStorage) DeleteHourlyBatch(ctx context.Context, batchSize int) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteHourlyBatch", ctx, batchSize)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteHourlyBatch indicates an expected call of DeleteHourlyBatch.
+func (mr *MockRetentionStorageMockRecorder) DeleteHourlyBatch(ctx, batchSize interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteHourlyBatch", reflect.TypeOf((*MockRetentionStorage)(nil).DeleteHourlyBatch), ctx, batchSize)
+}
+
+// DeleteRawBatch mocks base method.
+func (m *MockRetentionStorage) DeleteRawBatch(ctx context.Context, batchSize int) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteRawBatch", ctx, batchSize)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteRawBatch indicates an expected call of DeleteRawBatch.
+func (mr *MockRetentionStorageMockRecorder) DeleteRawBatch(ctx, batchSize interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRawBatch", reflect.TypeOf((*MockRetentionStorage)(nil).DeleteRawBatch), ctx, batchSize)
+}
+
+// DropPartitionsBefore mocks base method.
+func (m *MockRetentionStorage) DropPartitionsBefore(ctx context.Context, before time.Time) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DropPartitionsBefore", ctx, before)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DropPartitionsBefore indicates an expected call of DropPartitionsBefore.
+func (mr *MockRetentionStorageMockRecorder) DropPartitionsBefore(ctx, before interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DropPartitionsBefore", reflect.TypeOf((*MockRetentionStorage)(nil).DropPartitionsBefore), ctx, before)
+}
+
+// EnsurePartitions mocks base method.
+func (m *MockRetentionStorage) EnsurePartitions(ctx context.Context, from, to time.Time) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnsurePartitions", ctx, from, to)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EnsurePartitions indicates an expected call of EnsurePartitions.
+func (mr *MockRetentionStorageMockRecorder) EnsurePartitions(ctx, from, to interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnsurePartitions", reflect.TypeOf((*MockRetentionStorage)(nil).EnsurePartitions), ctx, from, to)
+}
+
+// RollupHourly mocks base method.
+func (m *MockRetentionStorage) RollupHourly(ctx context.Context, before time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RollupHourly", ctx, before)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RollupHourly indicates an expected call of RollupHourly.
+func (mr *MockRetentionStorageMockRecorder) RollupHourly(ctx, before interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RollupHourly", reflect.TypeOf((*MockRetentionStorage)(nil).RollupHourly), ctx, before)
+}
+
+// RollupRaw mocks base method.
+func (m *MockRetentionStorage) RollupRaw(ctx context.Context, before time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RollupRaw", ctx, before)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RollupRaw indicates an expected call of RollupRaw.
+func (mr *MockRetentionStorageMockRecorder) RollupRaw(ctx, before interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RollupRaw", reflect.TypeOf((*MockRetentionStorage)(nil).RollupRaw), ctx, before)
+}
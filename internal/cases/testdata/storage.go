@@ -8,6 +8,7 @@ import (
 	entities "Cryptoproject/internal/entities"
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 )
@@ -35,6 +36,36 @@ func (m *MockStorage) EXPECT() *MockStorageMockRecorder {
 	return m.recorder
 }
 
+// AddToWatchlist mocks base method.
+func (m *MockStorage) AddToWatchlist(ctx context.Context, titles []string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddToWatchlist", ctx, titles)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddToWatchlist indicates an expected call of AddToWatchlist.
+func (mr *MockStorageMockRecorder) AddToWatchlist(ctx, titles interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddToWatchlist", reflect.TypeOf((*MockStorage)(nil).AddToWatchlist), ctx, titles)
+}
+
+// BackfillCandles mocks base method.
+func (m *MockStorage) BackfillCandles(ctx context.Context, title, currency string, candles []entities.Candle) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BackfillCandles", ctx, title, currency, candles)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BackfillCandles indicates an expected call of BackfillCandles.
+func (mr *MockStorageMockRecorder) BackfillCandles(ctx, title, currency, candles interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BackfillCandles", reflect.TypeOf((*MockStorage)(nil).BackfillCandles), ctx, title, currency, candles)
+}
+
 // GetActualCoins mocks base method.
 func (m *MockStorage) GetActualCoins(ctx context.Context, titles []string) ([]entities.Coin, error) {
 	m.ctrl.T.Helper()
@@ -65,6 +96,51 @@ func (mr *MockStorageMockRecorder) GetAggregateCoins(ctx, titles, aggFuncTitle i
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAggregateCoins", reflect.TypeOf((*MockStorage)(nil).GetAggregateCoins), ctx, titles, aggFuncTitle)
 }
 
+// GetCandles mocks base method.
+func (m *MockStorage) GetCandles(ctx context.Context, title string, interval time.Duration, since time.Time) ([]entities.Candle, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCandles", ctx, title, interval, since)
+	ret0, _ := ret[0].([]entities.Candle)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCandles indicates an expected call of GetCandles.
+func (mr *MockStorageMockRecorder) GetCandles(ctx, title, interval, since interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCandles", reflect.TypeOf((*MockStorage)(nil).GetCandles), ctx, title, interval, since)
+}
+
+// GetCandlesBatch mocks base method.
+func (m *MockStorage) GetCandlesBatch(ctx context.Context, titles []string, interval time.Duration, since time.Time) (map[string][]entities.Candle, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCandlesBatch", ctx, titles, interval, since)
+	ret0, _ := ret[0].(map[string][]entities.Candle)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCandlesBatch indicates an expected call of GetCandlesBatch.
+func (mr *MockStorageMockRecorder) GetCandlesBatch(ctx, titles, interval, since interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCandlesBatch", reflect.TypeOf((*MockStorage)(nil).GetCandlesBatch), ctx, titles, interval, since)
+}
+
+// GetCoinsAt mocks base method.
+func (m *MockStorage) GetCoinsAt(ctx context.Context, titles []string, at time.Time) ([]entities.Coin, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCoinsAt", ctx, titles, at)
+	ret0, _ := ret[0].([]entities.Coin)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCoinsAt indicates an expected call of GetCoinsAt.
+func (mr *MockStorageMockRecorder) GetCoinsAt(ctx, titles, at interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCoinsAt", reflect.TypeOf((*MockStorage)(nil).GetCoinsAt), ctx, titles, at)
+}
+
 // GetCoinsList mocks base method.
 func (m *MockStorage) GetCoinsList(ctx context.Context) ([]string, error) {
 	m.ctrl.T.Helper()
@@ -80,12 +156,103 @@ func (mr *MockStorageMockRecorder) GetCoinsList(ctx interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCoinsList", reflect.TypeOf((*MockStorage)(nil).GetCoinsList), ctx)
 }
 
+// GetMarketMovers mocks base method.
+func (m *MockStorage) GetMarketMovers(ctx context.Context, currency string, since time.Time, limit int) (*entities.MarketOverview, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMarketMovers", ctx, currency, since, limit)
+	ret0, _ := ret[0].(*entities.MarketOverview)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMarketMovers indicates an expected call of GetMarketMovers.
+func (mr *MockStorageMockRecorder) GetMarketMovers(ctx, currency, since, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMarketMovers", reflect.TypeOf((*MockStorage)(nil).GetMarketMovers), ctx, currency, since, limit)
+}
+
+// GetRecentCoins mocks base method.
+func (m *MockStorage) GetRecentCoins(ctx context.Context, titles []string, since time.Time) ([]entities.Coin, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRecentCoins", ctx, titles, since)
+	ret0, _ := ret[0].([]entities.Coin)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRecentCoins indicates an expected call of GetRecentCoins.
+func (mr *MockStorageMockRecorder) GetRecentCoins(ctx, titles, since interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRecentCoins", reflect.TypeOf((*MockStorage)(nil).GetRecentCoins), ctx, titles, since)
+}
+
+// GetSummaries mocks base method.
+func (m *MockStorage) GetSummaries(ctx context.Context, titles []string, since time.Time) (map[string]entities.Candle, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSummaries", ctx, titles, since)
+	ret0, _ := ret[0].(map[string]entities.Candle)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSummaries indicates an expected call of GetSummaries.
+func (mr *MockStorageMockRecorder) GetSummaries(ctx, titles, since interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSummaries", reflect.TypeOf((*MockStorage)(nil).GetSummaries), ctx, titles, since)
+}
+
+// GetTickers mocks base method.
+func (m *MockStorage) GetTickers(ctx context.Context, titles []string) ([]entities.Ticker, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTickers", ctx, titles)
+	ret0, _ := ret[0].([]entities.Ticker)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTickers indicates an expected call of GetTickers.
+func (mr *MockStorageMockRecorder) GetTickers(ctx, titles interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTickers", reflect.TypeOf((*MockStorage)(nil).GetTickers), ctx, titles)
+}
+
+// ImportCoins mocks base method.
+func (m *MockStorage) ImportCoins(ctx context.Context, coins []entities.Coin) (entities.StoreResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportCoins", ctx, coins)
+	ret0, _ := ret[0].(entities.StoreResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ImportCoins indicates an expected call of ImportCoins.
+func (mr *MockStorageMockRecorder) ImportCoins(ctx, coins interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportCoins", reflect.TypeOf((*MockStorage)(nil).ImportCoins), ctx, coins)
+}
+
+// RemoveFromWatchlist mocks base method.
+func (m *MockStorage) RemoveFromWatchlist(ctx context.Context, titles []string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveFromWatchlist", ctx, titles)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RemoveFromWatchlist indicates an expected call of RemoveFromWatchlist.
+func (mr *MockStorageMockRecorder) RemoveFromWatchlist(ctx, titles interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveFromWatchlist", reflect.TypeOf((*MockStorage)(nil).RemoveFromWatchlist), ctx, titles)
+}
+
 // Store mocks base method.
-func (m *MockStorage) Store(ctx context.Context, coins []entities.Coin) error {
+func (m *MockStorage) Store(ctx context.Context, coins []entities.Coin) (entities.StoreResult, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "Store", ctx, coins)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret0, _ := ret[0].(entities.StoreResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // Store indicates an expected call of Store.
@@ -93,3 +260,45 @@ func (mr *MockStorageMockRecorder) Store(ctx, coins interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Store", reflect.TypeOf((*MockStorage)(nil).Store), ctx, coins)
 }
+
+// StoreQuarantine mocks base method.
+func (m *MockStorage) StoreQuarantine(ctx context.Context, quotes []entities.QuarantinedQuote) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StoreQuarantine", ctx, quotes)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StoreQuarantine indicates an expected call of StoreQuarantine.
+func (mr *MockStorageMockRecorder) StoreQuarantine(ctx, quotes interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StoreQuarantine", reflect.TypeOf((*MockStorage)(nil).StoreQuarantine), ctx, quotes)
+}
+
+// StoreTickers mocks base method.
+func (m *MockStorage) StoreTickers(ctx context.Context, tickers []entities.Ticker) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StoreTickers", ctx, tickers)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StoreTickers indicates an expected call of StoreTickers.
+func (mr *MockStorageMockRecorder) StoreTickers(ctx, tickers interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StoreTickers", reflect.TypeOf((*MockStorage)(nil).StoreTickers), ctx, tickers)
+}
+
+// StreamHistory mocks base method.
+func (m *MockStorage) StreamHistory(ctx context.Context, query entities.ExportQuery, fn func(entities.HistoryRecord) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StreamHistory", ctx, query, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StreamHistory indicates an expected call of StreamHistory.
+func (mr *MockStorageMockRecorder) StreamHistory(ctx, query, fn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StreamHistory", reflect.TypeOf((*MockStorage)(nil).StreamHistory), ctx, query, fn)
+}
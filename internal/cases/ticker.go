@@ -0,0 +1,41 @@
+package cases
+
+import (
+	"context"
+	"log/slog"
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"Cryptoproject/internal/entities"
+)
+
+// GetTickers returns the stored market snapshots for titles, or for every
+// tracked coin when titles is empty, ordered as requested.
+func (s *Service) GetTickers(ctx context.Context, titles []string, order entities.TickerSort) ([]entities.Ticker, error) {
+	const op = "cases.GetTickers"
+	startTime := time.Now()
+	logger := s.logger.With(
+		slog.String("op", op),
+		slog.Int("titles_count", len(titles)),
+		slog.String("sort", string(order.Field)),
+		slog.Bool("desc", order.Desc))
+
+	tickers, err := s.storage.GetTickers(ctx, titles)
+	if err != nil {
+		logger.Error("Failed to get tickers",
+			slog.String("error", err.Error()),
+			slog.Duration("duration", time.Since(startTime)))
+		return nil, errors.Wrap(err, "failed to get tickers from storage")
+	}
+
+	sort.SliceStable(tickers, func(i, j int) bool {
+		return order.Less(tickers[i], tickers[j])
+	})
+
+	logger.Info("Tickers retrieved",
+		slog.Int("tickers_count", len(tickers)),
+		slog.Duration("duration", time.Since(startTime)))
+	return tickers, nil
+}
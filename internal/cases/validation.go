@@ -0,0 +1,226 @@
+package cases
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"math"
+	"sync"
+	"time"
+
+	"Cryptoproject/internal/entities"
+	"Cryptoproject/pkg/indicators"
+)
+
+const (
+	// minZScoreReturns — меньше доходностей в окне дают ненадёжную оценку разброса
+	minZScoreReturns = 10
+	// defaultConfirmPct — допуск подтверждения скачка, когда MaxJumpPct не задан
+	defaultConfirmPct = 5
+)
+
+// ValidationConfig configures the checks quotes pass between the provider and
+// storage. Non-positive prices are always rejected; zero values disable the
+// other checks.
+type ValidationConfig struct {
+	// MaxJumpPct flags a quote moving more than this percentage from the last stored price.
+	MaxJumpPct float64
+	// MaxZScore flags a quote whose log return from the last stored price is further
+	// than this many standard deviations from the mean return over ZScoreWindow.
+	MaxZScore float64
+	// ZScoreWindow is how much stored history the z-score is computed over.
+	ZScoreWindow time.Duration
+	// FrozenAfter flags quotes whose price has not changed for this long while
+	// the provider kept reporting new observations.
+	FrozenAfter time.Duration
+}
+
+// WithValidation enables jump and frozen feed detection on ingestion.
+// A flagged jump is accepted once the next quote confirms the new level.
+func WithValidation(cfg ValidationConfig) ServiceOption {
+	return func(s *Service) {
+		s.validation = cfg
+	}
+}
+
+// feedState — последнее состояние котировок монеты в памяти процесса
+type feedState struct {
+	price          float64
+	unchangedSince time.Time
+	lastObserved   time.Time
+	// pendingJump — цена отклонённого скачка, ждущая подтверждения следующей котировкой
+	pendingJump float64
+}
+
+type feedTracker struct {
+	mu    sync.Mutex
+	feeds map[string]*feedState
+}
+
+func newFeedTracker() *feedTracker {
+	return &feedTracker{feeds: make(map[string]*feedState)}
+}
+
+// validateQuotes splits coins into accepted quotes and quarantined ones and
+// records the latter. History lookup and quarantine failures are logged and
+// do not block ingestion.
+func (s *Service) validateQuotes(ctx context.Context, coins []entities.Coin) ([]entities.Coin, []entities.QuarantinedQuote) {
+	const op = "cases.validateQuotes"
+	logger := s.logger.With(slog.String("op", op))
+
+	history := s.recentHistory(ctx, coins, logger)
+
+	accepted := make([]entities.Coin, 0, len(coins))
+	var quarantined []entities.QuarantinedQuote
+	s.feeds.mu.Lock()
+	for _, coin := range coins {
+		if quote, rejected := s.checkQuote(coin, history[coin.CoinName]); rejected {
+			quarantined = append(quarantined, quote)
+			continue
+		}
+		accepted = append(accepted, coin)
+	}
+	s.feeds.mu.Unlock()
+
+	if len(quarantined) == 0 {
+		return accepted, nil
+	}
+	for _, quote := range quarantined {
+		logger.Warn("Quote quarantined",
+			slog.String("coin", quote.CoinName),
+			slog.Float64("price", quote.Price),
+			slog.String("reason", string(quote.Reason)),
+			slog.String("detail", quote.Detail))
+	}
+	if err := s.storage.StoreQuarantine(ctx, quarantined); err != nil {
+		logger.Error("Failed to store quarantined quotes",
+			slog.String("error", err.Error()),
+			slog.Int("quotes_count", len(quarantined)))
+	}
+	return accepted, quarantined
+}
+
+// recentHistory returns stored prices per coin, newest first. It is only
+// queried when jump detection is enabled.
+func (s *Service) recentHistory(ctx context.Context, coins []entities.Coin, logger *slog.Logger) map[string][]entities.Coin {
+	if s.validation.MaxJumpPct <= 0 && s.validation.MaxZScore <= 0 {
+		return nil
+	}
+
+	titles := make([]string, 0, len(coins))
+	for _, coin := range coins {
+		titles = append(titles, coin.CoinName)
+	}
+	// последняя цена нужна и для процентного порога, даже если окно z-оценки короче паузы между тиками
+	since := s.now().Add(-max(s.validation.ZScoreWindow, time.Hour))
+	recent, err := s.storage.GetRecentCoins(ctx, titles, since)
+	if err != nil {
+		logger.Error("Failed to get recent prices, jump detection skipped",
+			slog.String("error", err.Error()))
+		return nil
+	}
+
+	history := make(map[string][]entities.Coin, len(titles))
+	for _, coin := range recent {
+		history[coin.CoinName] = append(history[coin.CoinName], coin)
+	}
+	return history
+}
+
+// checkQuote runs the checks for a single quote. The caller holds s.feeds.mu.
+func (s *Service) checkQuote(coin entities.Coin, history []entities.Coin) (entities.QuarantinedQuote, bool) {
+	if coin.Price <= 0 || math.IsNaN(coin.Price) || math.IsInf(coin.Price, 0) {
+		return entities.NewQuarantinedQuote(coin, entities.QuarantineNonPositivePrice,
+			fmt.Sprintf("price %v", coin.Price), 0), true
+	}
+
+	feed, ok := s.feeds.feeds[coin.CoinName]
+	if !ok {
+		feed = &feedState{price: coin.Price, unchangedSince: coin.ObservedAt, lastObserved: coin.ObservedAt}
+		s.feeds.feeds[coin.CoinName] = feed
+	}
+
+	// повтор того же наблюдения отбросит дедупликация при сохранении
+	if ok && !coin.ObservedAt.After(feed.lastObserved) {
+		return entities.QuarantinedQuote{}, false
+	}
+
+	if quote, rejected := s.checkJump(coin, history, feed); rejected {
+		return quote, true
+	}
+
+	if coin.Price != feed.price {
+		feed.price, feed.unchangedSince = coin.Price, coin.ObservedAt
+	}
+	feed.lastObserved = coin.ObservedAt
+
+	if s.validation.FrozenAfter > 0 {
+		if unchanged := coin.ObservedAt.Sub(feed.unchangedSince); unchanged >= s.validation.FrozenAfter {
+			return entities.NewQuarantinedQuote(coin, entities.QuarantineFrozenFeed,
+				fmt.Sprintf("price unchanged for %s", unchanged.Round(time.Second)), feed.price), true
+		}
+	}
+	return entities.QuarantinedQuote{}, false
+}
+
+func (s *Service) checkJump(coin entities.Coin, history []entities.Coin, feed *feedState) (entities.QuarantinedQuote, bool) {
+	// сравниваем только с наблюдениями до текущего
+	for len(history) > 0 && !history[0].ObservedAt.Before(coin.ObservedAt) {
+		history = history[1:]
+	}
+	if len(history) == 0 {
+		return entities.QuarantinedQuote{}, false
+	}
+	last := history[0].Price
+
+	var detail string
+	if jumpPct := math.Abs(coin.Price-last) / last * 100; s.validation.MaxJumpPct > 0 && jumpPct > s.validation.MaxJumpPct {
+		detail = fmt.Sprintf("moved %.2f%% from last price", jumpPct)
+	} else if z, ok := zScore(coin.Price, history); s.validation.MaxZScore > 0 && ok && z > s.validation.MaxZScore {
+		detail = fmt.Sprintf("z-score %.2f over %s", z, s.validation.ZScoreWindow)
+	}
+	if detail == "" {
+		feed.pendingJump = 0
+		return entities.QuarantinedQuote{}, false
+	}
+
+	// два подряд скачка к одному уровню — это новый уровень рынка, а не выброс
+	tolerance := s.validation.MaxJumpPct
+	if tolerance <= 0 {
+		tolerance = defaultConfirmPct
+	}
+	if feed.pendingJump > 0 && math.Abs(coin.Price-feed.pendingJump)/feed.pendingJump*100 <= tolerance {
+		feed.pendingJump = 0
+		return entities.QuarantinedQuote{}, false
+	}
+	feed.pendingJump = coin.Price
+	return entities.NewQuarantinedQuote(coin, entities.QuarantinePriceJump, detail, last), true
+}
+
+// zScore is how many standard deviations the log return of price from the
+// latest stored price lies from the mean return of history (newest first).
+func zScore(price float64, history []entities.Coin) (float64, bool) {
+	if len(history) < minZScoreReturns+1 {
+		return 0, false
+	}
+
+	prices := make([]float64, 0, len(history))
+	for i := len(history) - 1; i >= 0; i-- {
+		prices = append(prices, history[i].Price)
+	}
+	returns, err := indicators.LogReturns(prices)
+	if err != nil {
+		return 0, false
+	}
+	deviation, err := indicators.StdDev(returns)
+	if err != nil || deviation == 0 {
+		return 0, false
+	}
+
+	var mean float64
+	for _, r := range returns {
+		mean += r
+	}
+	mean /= float64(len(returns))
+	return math.Abs(math.Log(price/history[0].Price)-mean) / deviation, true
+}
@@ -0,0 +1,141 @@
+package cases_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"Cryptoproject/internal/cases"
+	"Cryptoproject/internal/cases/testdata"
+	"Cryptoproject/internal/entities"
+)
+
+func Test_ActualizeRates_QuarantinesInvalidQuotes(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStorage := testdata.NewMockStorage(ctrl)
+	mockCryptoProvider := testdata.NewMockCryptoProvider(ctrl)
+
+	observedAt := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	mockStorage.EXPECT().GetCoinsList(gomock.Any()).Return([]string{"BTC", "DOGE", "ETH"}, nil)
+	mockCryptoProvider.EXPECT().
+		GetTickers(gomock.Any(), gomock.Any()).
+		Return([]entities.Ticker{
+			{CoinName: "BTC", Price: 29000, ObservedAt: observedAt},
+			{CoinName: "DOGE", Price: 0, ObservedAt: observedAt},
+			{CoinName: "ETH", Price: 3200, ObservedAt: observedAt},
+		}, nil)
+	mockStorage.EXPECT().
+		GetRecentCoins(gomock.Any(), []string{"BTC", "DOGE", "ETH"}, gomock.Any()).
+		Return([]entities.Coin{
+			{CoinName: "BTC", Price: 28900, ObservedAt: observedAt.Add(-time.Minute)},
+			{CoinName: "ETH", Price: 1600, ObservedAt: observedAt.Add(-time.Minute)},
+		}, nil)
+	mockStorage.EXPECT().
+		StoreQuarantine(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, quotes []entities.QuarantinedQuote) error {
+			require.Len(t, quotes, 2)
+			assert.Equal(t, "DOGE", quotes[0].CoinName)
+			assert.Equal(t, entities.QuarantineNonPositivePrice, quotes[0].Reason)
+			assert.Equal(t, "ETH", quotes[1].CoinName)
+			assert.Equal(t, entities.QuarantinePriceJump, quotes[1].Reason)
+			assert.Equal(t, 1600.0, quotes[1].ReferencePrice)
+			return nil
+		})
+	mockStorage.EXPECT().
+		StoreTickers(gomock.Any(), []entities.Ticker{{CoinName: "BTC", Price: 29000, ObservedAt: observedAt}}).
+		Return(nil)
+	mockStorage.EXPECT().
+		Store(gomock.Any(), []entities.Coin{{CoinName: "BTC", Price: 29000, ObservedAt: observedAt}}).
+		Return(entities.StoreResult{Inserted: 1}, nil)
+
+	service, err := cases.NewService(mockStorage, mockCryptoProvider, nil,
+		cases.WithValidation(cases.ValidationConfig{MaxJumpPct: 30}))
+	require.NoError(t, err)
+
+	require.NoError(t, service.ActualizeRates(context.Background()))
+}
+
+func Test_ActualizeRates_JumpConfirmedByNextQuote(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStorage := testdata.NewMockStorage(ctrl)
+	mockCryptoProvider := testdata.NewMockCryptoProvider(ctrl)
+
+	observedAt := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	history := []entities.Coin{{CoinName: "ETH", Price: 1600, ObservedAt: observedAt.Add(-time.Minute)}}
+
+	mockStorage.EXPECT().GetCoinsList(gomock.Any()).Return([]string{"ETH"}, nil).Times(2)
+	gomock.InOrder(
+		mockCryptoProvider.EXPECT().GetTickers(gomock.Any(), gomock.Any()).
+			Return([]entities.Ticker{{CoinName: "ETH", Price: 3200, ObservedAt: observedAt}}, nil),
+		mockCryptoProvider.EXPECT().GetTickers(gomock.Any(), gomock.Any()).
+			Return([]entities.Ticker{{CoinName: "ETH", Price: 3210, ObservedAt: observedAt.Add(time.Minute)}}, nil),
+	)
+	mockStorage.EXPECT().GetRecentCoins(gomock.Any(), gomock.Any(), gomock.Any()).Return(history, nil).Times(2)
+	mockStorage.EXPECT().StoreQuarantine(gomock.Any(), gomock.Len(1)).Return(nil)
+	mockStorage.EXPECT().StoreTickers(gomock.Any(), gomock.Any()).Return(nil).Times(2)
+	gomock.InOrder(
+		mockStorage.EXPECT().Store(gomock.Any(), []entities.Coin{}).Return(entities.StoreResult{}, nil),
+		mockStorage.EXPECT().
+			Store(gomock.Any(), []entities.Coin{{CoinName: "ETH", Price: 3210, ObservedAt: observedAt.Add(time.Minute)}}).
+			Return(entities.StoreResult{Inserted: 1}, nil),
+	)
+
+	service, err := cases.NewService(mockStorage, mockCryptoProvider, nil,
+		cases.WithValidation(cases.ValidationConfig{MaxJumpPct: 30}))
+	require.NoError(t, err)
+
+	require.NoError(t, service.ActualizeRates(context.Background()))
+	require.NoError(t, service.ActualizeRates(context.Background()))
+}
+
+func Test_ActualizeRates_FrozenFeed(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStorage := testdata.NewMockStorage(ctrl)
+	mockCryptoProvider := testdata.NewMockCryptoProvider(ctrl)
+
+	start := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	mockStorage.EXPECT().GetCoinsList(gomock.Any()).Return([]string{"BTC"}, nil).Times(3)
+	gomock.InOrder(
+		mockCryptoProvider.EXPECT().GetTickers(gomock.Any(), gomock.Any()).
+			Return([]entities.Ticker{{CoinName: "BTC", Price: 29000, ObservedAt: start}}, nil),
+		mockCryptoProvider.EXPECT().GetTickers(gomock.Any(), gomock.Any()).
+			Return([]entities.Ticker{{CoinName: "BTC", Price: 29000, ObservedAt: start.Add(30 * time.Minute)}}, nil),
+		mockCryptoProvider.EXPECT().GetTickers(gomock.Any(), gomock.Any()).
+			Return([]entities.Ticker{{CoinName: "BTC", Price: 29000, ObservedAt: start.Add(time.Hour)}}, nil),
+	)
+	mockStorage.EXPECT().StoreTickers(gomock.Any(), gomock.Any()).Return(nil).Times(3)
+	mockStorage.EXPECT().Store(gomock.Any(), gomock.Len(1)).Return(entities.StoreResult{Inserted: 1}, nil).Times(2)
+	mockStorage.EXPECT().Store(gomock.Any(), gomock.Len(0)).Return(entities.StoreResult{}, nil)
+	mockStorage.EXPECT().
+		StoreQuarantine(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, quotes []entities.QuarantinedQuote) error {
+			require.Len(t, quotes, 1)
+			assert.Equal(t, entities.QuarantineFrozenFeed, quotes[0].Reason)
+			assert.Equal(t, start.Add(time.Hour), quotes[0].ObservedAt)
+			return nil
+		})
+
+	service, err := cases.NewService(mockStorage, mockCryptoProvider, nil,
+		cases.WithValidation(cases.ValidationConfig{FrozenAfter: time.Hour}))
+	require.NoError(t, err)
+
+	for range 3 {
+		require.NoError(t, service.ActualizeRates(context.Background()))
+	}
+}
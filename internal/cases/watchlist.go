@@ -0,0 +1,156 @@
+package cases
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"Cryptoproject/internal/entities"
+)
+
+// maxBackfillRange ограничивает догрузку истории, чтобы один вызов не выбирал весь лимит API.
+const maxBackfillRange = 2 * 365 * 24 * time.Hour
+
+// GetWatchlist returns the titles refreshed by ActualizeRates.
+func (s *Service) GetWatchlist(ctx context.Context) ([]string, error) {
+	const op = "cases.GetWatchlist"
+	logger := s.logger.With(slog.String("op", op))
+
+	titles, err := s.storage.GetCoinsList(ctx)
+	if err != nil {
+		logger.Error("Failed to get coins list", slog.String("error", err.Error()))
+		return nil, errors.Wrap(err, "failed to get coins list from storage")
+	}
+	return titles, nil
+}
+
+// AddToWatchlist checks titles against the provider, stores their current
+// prices and starts tracking them. It returns the number of newly tracked titles.
+func (s *Service) AddToWatchlist(ctx context.Context, titles []string) (int, error) {
+	const op = "cases.AddToWatchlist"
+	logger := s.logger.With(
+		slog.String("op", op),
+		slog.Any("titles", titles))
+
+	if len(titles) == 0 {
+		err := errors.Wrap(entities.ErrInvalidParam, "titles list is empty")
+		logger.Warn("Validation failed", slog.String("error", err.Error()))
+		return 0, err
+	}
+
+	coins, err := s.cryptoProvider.GetActualRates(ctx, titles)
+	if err != nil {
+		logger.Error("Failed to get actual rates", slog.String("error", err.Error()))
+		return 0, errors.Wrap(err, "failed to get actual rates")
+	}
+	if unknown := subtractTitles(titles, coins); len(unknown) > 0 {
+		err := errors.Wrapf(entities.ErrUnknownSymbol, "unknown titles: %v", unknown)
+		logger.Warn("Validation failed", slog.String("error", err.Error()))
+		return 0, err
+	}
+
+	accepted, _ := s.validateQuotes(ctx, coins)
+	if _, err = s.storage.Store(ctx, accepted); err != nil {
+		logger.Error("Failed to store coins", slog.String("error", err.Error()))
+		return 0, errors.Wrap(err, "failed to store coins")
+	}
+	added, err := s.storage.AddToWatchlist(ctx, titles)
+	if err != nil {
+		logger.Error("Failed to add to watchlist", slog.String("error", err.Error()))
+		return 0, errors.Wrap(err, "failed to add coins to watchlist")
+	}
+
+	logger.Info("Watchlist updated", slog.Int("added", added))
+	return added, nil
+}
+
+// RemoveFromWatchlist stops tracking titles; stored history is kept.
+func (s *Service) RemoveFromWatchlist(ctx context.Context, titles []string) (int, error) {
+	const op = "cases.RemoveFromWatchlist"
+	logger := s.logger.With(
+		slog.String("op", op),
+		slog.Any("titles", titles))
+
+	if len(titles) == 0 {
+		err := errors.Wrap(entities.ErrInvalidParam, "titles list is empty")
+		logger.Warn("Validation failed", slog.String("error", err.Error()))
+		return 0, err
+	}
+
+	removed, err := s.storage.RemoveFromWatchlist(ctx, titles)
+	if err != nil {
+		logger.Error("Failed to remove from watchlist", slog.String("error", err.Error()))
+		return 0, errors.Wrap(err, "failed to remove coins from watchlist")
+	}
+	if removed == 0 {
+		err := errors.Wrapf(entities.ErrNotFound, "titles are not tracked: %v", titles)
+		logger.Warn("Nothing removed", slog.String("error", err.Error()))
+		return 0, err
+	}
+
+	logger.Info("Watchlist updated", slog.Int("removed", removed))
+	return removed, nil
+}
+
+// Backfill loads hourly history of titles between from and to from the
+// provider and stores it. Backfilled coins are added to the watchlist.
+func (s *Service) Backfill(ctx context.Context, titles []string, from, to time.Time) ([]entities.BackfillResult, error) {
+	const op = "cases.Backfill"
+	startTime := time.Now()
+	logger := s.logger.With(
+		slog.String("op", op),
+		slog.Any("titles", titles),
+		slog.Time("from", from),
+		slog.Time("to", to))
+
+	if len(titles) == 0 {
+		err := errors.Wrap(entities.ErrInvalidParam, "titles list is empty")
+		logger.Warn("Validation failed", slog.String("error", err.Error()))
+		return nil, err
+	}
+	if !from.Before(to) {
+		err := errors.Wrap(entities.ErrInvalidParam, "from must be before to")
+		logger.Warn("Validation failed", slog.String("error", err.Error()))
+		return nil, err
+	}
+	if to.Sub(from) > maxBackfillRange {
+		err := errors.Wrapf(entities.ErrInvalidParam, "range must not exceed %s", maxBackfillRange)
+		logger.Warn("Validation failed", slog.String("error", err.Error()))
+		return nil, err
+	}
+	if now := s.now(); to.After(now) {
+		to = now
+	}
+
+	results := make([]entities.BackfillResult, 0, len(titles))
+	for _, title := range titles {
+		candles, err := s.cryptoProvider.GetHistory(ctx, title, from, to)
+		if err != nil {
+			logger.Error("Failed to get history",
+				slog.String("title", title),
+				slog.String("error", err.Error()))
+			return results, errors.Wrapf(err, "failed to get history of %s", title)
+		}
+
+		stored, err := s.storage.BackfillCandles(ctx, title, s.quoteCurrency, candles)
+		if err != nil {
+			logger.Error("Failed to store history",
+				slog.String("title", title),
+				slog.String("error", err.Error()))
+			return results, errors.Wrapf(err, "failed to store history of %s", title)
+		}
+
+		results = append(results, entities.BackfillResult{
+			CoinName: title,
+			Fetched:  len(candles),
+			Stored:   stored,
+		})
+	}
+
+	logger.Info("Backfill completed",
+		slog.Int("coins_count", len(results)),
+		slog.Duration("duration", time.Since(startTime)))
+	return results, nil
+}
@@ -0,0 +1,108 @@
+package cases_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"Cryptoproject/internal/cases"
+	"Cryptoproject/internal/cases/testdata"
+	"Cryptoproject/internal/entities"
+)
+
+func Test_AddToWatchlist(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStorage := testdata.NewMockStorage(ctrl)
+	mockCryptoProvider := testdata.NewMockCryptoProvider(ctrl)
+
+	observedAt := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	coins := []entities.Coin{{CoinName: "SOL", Price: 150, ObservedAt: observedAt}}
+	mockCryptoProvider.EXPECT().GetActualRates(gomock.Any(), []string{"SOL"}).Return(coins, nil)
+	mockStorage.EXPECT().Store(gomock.Any(), coins).Return(entities.StoreResult{Inserted: 1}, nil)
+	mockStorage.EXPECT().AddToWatchlist(gomock.Any(), []string{"SOL"}).Return(1, nil)
+
+	service, err := cases.NewService(mockStorage, mockCryptoProvider, nil)
+	require.NoError(t, err)
+
+	added, err := service.AddToWatchlist(context.Background(), []string{"SOL"})
+	require.NoError(t, err)
+	assert.Equal(t, 1, added)
+}
+
+func Test_AddToWatchlist_UnknownTitle(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStorage := testdata.NewMockStorage(ctrl)
+	mockCryptoProvider := testdata.NewMockCryptoProvider(ctrl)
+
+	mockCryptoProvider.EXPECT().
+		GetActualRates(gomock.Any(), []string{"SOL", "NOPE"}).
+		Return([]entities.Coin{{CoinName: "SOL", Price: 150}}, nil)
+
+	service, err := cases.NewService(mockStorage, mockCryptoProvider, nil)
+	require.NoError(t, err)
+
+	_, err = service.AddToWatchlist(context.Background(), []string{"SOL", "NOPE"})
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, entities.ErrNotFound))
+	assert.Contains(t, err.Error(), "NOPE")
+}
+
+func Test_RemoveFromWatchlist_NotTracked(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStorage := testdata.NewMockStorage(ctrl)
+	mockCryptoProvider := testdata.NewMockCryptoProvider(ctrl)
+
+	mockStorage.EXPECT().RemoveFromWatchlist(gomock.Any(), []string{"SOL"}).Return(0, nil)
+
+	service, err := cases.NewService(mockStorage, mockCryptoProvider, nil)
+	require.NoError(t, err)
+
+	_, err = service.RemoveFromWatchlist(context.Background(), []string{"SOL"})
+	assert.True(t, errors.Is(err, entities.ErrNotFound))
+}
+
+func Test_Backfill(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStorage := testdata.NewMockStorage(ctrl)
+	mockCryptoProvider := testdata.NewMockCryptoProvider(ctrl)
+
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := from.Add(48 * time.Hour)
+	candles := []entities.Candle{
+		{Bucket: from, Open: 1, High: 1, Low: 1, Close: 1, Avg: 1, Count: 1},
+		{Bucket: from.Add(time.Hour), Open: 2, High: 2, Low: 2, Close: 2, Avg: 2, Count: 1},
+	}
+	mockCryptoProvider.EXPECT().GetHistory(gomock.Any(), "BTC", from, to).Return(candles, nil)
+	mockStorage.EXPECT().BackfillCandles(gomock.Any(), "BTC", "EUR", candles).Return(int64(1), nil)
+
+	service, err := cases.NewService(mockStorage, mockCryptoProvider, nil, cases.WithQuoteCurrency("EUR"))
+	require.NoError(t, err)
+
+	results, err := service.Backfill(context.Background(), []string{"BTC"}, from, to)
+	require.NoError(t, err)
+	assert.Equal(t, []entities.BackfillResult{{CoinName: "BTC", Fetched: 2, Stored: 1}}, results)
+
+	_, err = service.Backfill(context.Background(), []string{"BTC"}, to, from)
+	assert.True(t, errors.Is(err, entities.ErrInvalidParam))
+}
@@ -0,0 +1,32 @@
+package entities
+
+import "time"
+
+type AlertKind string
+
+const (
+	// AlertDepegStarted — стейблкоин дольше допустимого отклоняется от привязки
+	AlertDepegStarted AlertKind = "depeg_started"
+	// AlertDepegRecovered — стейблкоин вернулся к привязке
+	AlertDepegRecovered AlertKind = "depeg_recovered"
+)
+
+// AlertEvent is a notable state change raised by a monitor job.
+type AlertEvent struct {
+	ID        int64     `json:"id"`
+	Kind      AlertKind `json:"kind"`
+	CoinName  string    `json:"coin_name"`
+	Price     float64   `json:"price"`
+	Value     float64   `json:"value"`     // наблюдаемое значение, например отклонение в б.п.
+	Threshold float64   `json:"threshold"` // порог, пересечение которого вызвало событие
+	Message   string    `json:"message"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// AlertFilter selects alert events; zero fields do not filter.
+type AlertFilter struct {
+	CoinName string
+	Kinds    []AlertKind
+	Since    time.Time
+	Limit    int
+}
@@ -0,0 +1,47 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// AnalyticsWindows are the supported look-back windows for risk analytics.
+var AnalyticsWindows = map[string]time.Duration{
+	"7d":   7 * 24 * time.Hour,
+	"30d":  30 * 24 * time.Hour,
+	"90d":  90 * 24 * time.Hour,
+	"365d": 365 * 24 * time.Hour,
+}
+
+// MaxCorrelationCoins caps the size of a correlation matrix.
+const MaxCorrelationCoins = 20
+
+func ParseAnalyticsWindow(value string) (time.Duration, error) {
+	if value == "" {
+		return AnalyticsWindows["30d"], nil
+	}
+	window, ok := AnalyticsWindows[value]
+	if !ok {
+		return 0, errors.Wrapf(ErrInvalidParam, "unsupported window: %q (allowed: 7d, 30d, 90d, 365d)", value)
+	}
+	return window, nil
+}
+
+// Volatility is the realised volatility of a coin: the standard deviation of
+// log returns between consecutive buckets, annualised over a 24/7 year.
+type Volatility struct {
+	CoinName string `json:"coin_name"`
+	// Returns — число доходностей, по которым посчитана волатильность
+	Returns    int     `json:"returns"`
+	Volatility float64 `json:"volatility"`
+}
+
+// CorrelationMatrix holds the pairwise Pearson correlation of log returns.
+// Matrix[i][j] is NaN when the pair has too few common buckets or one of
+// the coins did not move; Observations[i][j] is the number of common returns.
+type CorrelationMatrix struct {
+	Titles       []string    `json:"titles"`
+	Matrix       [][]float64 `json:"matrix"`
+	Observations [][]int     `json:"observations"`
+}
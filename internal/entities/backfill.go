@@ -0,0 +1,10 @@
+package entities
+
+// BackfillResult reports how much history was loaded for a coin.
+type BackfillResult struct {
+	CoinName string
+	// Fetched is the number of hourly candles received from the provider.
+	Fetched int
+	// Stored is the number of rows written to storage, duplicates are skipped.
+	Stored int64
+}
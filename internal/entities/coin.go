@@ -1,28 +1,109 @@
 package entities
 
 import (
+	"regexp"
+	"strings"
 	"time"
 
 	"github.com/pkg/errors"
 )
 
+// MaxQueryTitles caps the number of coins in a single price query.
+const MaxQueryTitles = 100
+
+// Limits of the stored coin fields, as declared in the coins table.
+const (
+	MaxSourceLength = 50
+	// MinPrice and MaxPrice bound the prices NUMERIC(24,10) keeps: smaller
+	// ones round to zero and larger ones overflow the 14 integer digits.
+	MinPrice = 1e-10
+	MaxPrice = 1e14
+)
+
+// tickerPattern — символ монеты у провайдера: латиница и цифры
+var tickerPattern = regexp.MustCompile(`^[A-Z0-9]{1,16}$`)
+
 type Coin struct {
-	CoinName  string    `json:"coin_name"`
-	Price     float64   `json:"price"`
+	CoinName string  `json:"coin_name"`
+	Price    float64 `json:"price"`
+	// Currency is the quote currency of Price; empty means the storage default.
+	Currency string `json:"currency,omitempty"`
+	// Source is the name of the provider that quoted the price.
+	Source string `json:"source,omitempty"`
+	// ObservedAt is when the provider observed the price on the market.
+	ObservedAt time.Time `json:"observed_at"`
+	// CreatedAt is when the price was ingested into storage.
 	CreatedAt time.Time `json:"created_at"`
+	// Stale marks a stored price served in place of a fresh quote because the
+	// provider failed; Age is how old the price was when it was served.
+	Stale bool          `json:"stale,omitempty"`
+	Age   time.Duration `json:"age,omitempty"`
+}
+
+// PriceAge returns how old the price is at now: the time since the provider
+// observed it, or since it was ingested when the observation time is unknown.
+func (c Coin) PriceAge(now time.Time) time.Duration {
+	if c.ObservedAt.IsZero() {
+		return now.Sub(c.CreatedAt)
+	}
+	return now.Sub(c.ObservedAt)
 }
 
-func NewCoin(coinName string, price float64) (*Coin, error) {
+// StoreResult reports how many of the stored coins were new observations and
+// how many were skipped as duplicates of already stored ones.
+type StoreResult struct {
+	Inserted int
+	Skipped  int
+}
+
+func NewCoin(coinName string, price float64, observedAt time.Time) (*Coin, error) {
 	if coinName == "" {
 		return nil, errors.Wrap(ErrInvalidParam, "coin name not set")
 	}
 	if price <= 0 {
 		return nil, errors.Wrap(ErrInvalidParam, "price must be greater then 0")
 	}
-	now := time.Now()
+	if observedAt.IsZero() {
+		return nil, errors.Wrap(ErrInvalidParam, "observation time not set")
+	}
 	return &Coin{
-		CoinName:  coinName,
-		Price:     price,
-		CreatedAt: now,
+		CoinName:   coinName,
+		Price:      price,
+		ObservedAt: observedAt,
 	}, nil
 }
+
+// NormalizeTitle upper-cases and validates a coin symbol.
+func NormalizeTitle(title string) (string, error) {
+	title = strings.ToUpper(strings.TrimSpace(title))
+	if !tickerPattern.MatchString(title) {
+		return "", errors.Wrapf(ErrInvalidParam, "invalid title: %q", title)
+	}
+	return title, nil
+}
+
+// NormalizeTitles upper-cases and validates coin symbols and drops
+// duplicates, keeping the first occurrence.
+func NormalizeTitles(titles []string) ([]string, error) {
+	if len(titles) == 0 {
+		return nil, errors.Wrap(ErrInvalidParam, "titles list is empty")
+	}
+
+	normalized := make([]string, 0, len(titles))
+	seen := make(map[string]struct{}, len(titles))
+	for _, title := range titles {
+		title, err := NormalizeTitle(title)
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := seen[title]; ok {
+			continue
+		}
+		seen[title] = struct{}{}
+		normalized = append(normalized, title)
+	}
+	if len(normalized) > MaxQueryTitles {
+		return nil, errors.Wrapf(ErrInvalidParam, "too many titles: %d (max %d)", len(normalized), MaxQueryTitles)
+	}
+	return normalized, nil
+}
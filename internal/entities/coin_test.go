@@ -1,7 +1,9 @@
 package entities_test
 
 import (
+	"strconv"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
@@ -13,10 +15,13 @@ func Test_NewCoin_Success(t *testing.T) {
 
 	coinName := "test name"
 	price := 0.1
-	coin, err := entities.NewCoin(coinName, price)
+	observedAt := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	coin, err := entities.NewCoin(coinName, price, observedAt)
 	require.NoError(t, err)
 	require.Equal(t, coinName, coin.CoinName)
 	require.Equal(t, price, coin.Price)
+	require.Equal(t, observedAt, coin.ObservedAt)
+	require.True(t, coin.CreatedAt.IsZero())
 }
 
 func Test_NewCoin_ValidateError(t *testing.T) {
@@ -24,15 +29,42 @@ func Test_NewCoin_ValidateError(t *testing.T) {
 
 	coinName := ""
 	price := 1.0
-	coin, err := entities.NewCoin(coinName, price)
+	observedAt := time.Now()
+	coin, err := entities.NewCoin(coinName, price, observedAt)
 	require.ErrorIs(t, err, entities.ErrInvalidParam)
 	require.Nil(t, coin)
 	require.Contains(t, err.Error(), "coin name not set")
 
 	coinName = "name"
 	price = 0.0
-	coin, err = entities.NewCoin(coinName, price)
+	coin, err = entities.NewCoin(coinName, price, observedAt)
 	require.ErrorIs(t, err, entities.ErrInvalidParam)
 	require.Nil(t, coin)
 	require.Contains(t, err.Error(), "price must be greater then 0")
+
+	price = 1.0
+	coin, err = entities.NewCoin(coinName, price, time.Time{})
+	require.ErrorIs(t, err, entities.ErrInvalidParam)
+	require.Nil(t, coin)
+	require.Contains(t, err.Error(), "observation time not set")
+}
+
+func Test_NormalizeTitles(t *testing.T) {
+	t.Parallel()
+
+	titles, err := entities.NormalizeTitles([]string{"btc", " ETH", "BTC", "usdt"})
+	require.NoError(t, err)
+	require.Equal(t, []string{"BTC", "ETH", "USDT"}, titles)
+
+	for _, invalid := range [][]string{nil, {""}, {"BTC-USD"}, {"BITCOINBITCOINBTC"}} {
+		_, err = entities.NormalizeTitles(invalid)
+		require.ErrorIs(t, err, entities.ErrInvalidParam, invalid)
+	}
+
+	tooMany := make([]string, entities.MaxQueryTitles+1)
+	for i := range tooMany {
+		tooMany[i] = "C" + strconv.Itoa(i)
+	}
+	_, err = entities.NormalizeTitles(tooMany)
+	require.ErrorIs(t, err, entities.ErrInvalidParam)
 }
@@ -0,0 +1,32 @@
+package entities
+
+import "time"
+
+// ConversionLeg is a stored price used to convert between currencies.
+type ConversionLeg struct {
+	CoinName  string    `json:"coin_name"`
+	Price     float64   `json:"price"`
+	CreatedAt time.Time `json:"created_at"`
+	// ObservedAt is when the provider observed the price; staleness is measured from it.
+	ObservedAt time.Time `json:"observed_at"`
+}
+
+// PriceAt returns when the price was observed, or when it was stored for
+// prices saved before the observation time was tracked.
+func (l ConversionLeg) PriceAt() time.Time {
+	if l.ObservedAt.IsZero() {
+		return l.CreatedAt
+	}
+	return l.ObservedAt
+}
+
+type Conversion struct {
+	From    string         `json:"from"`
+	To      string         `json:"to"`
+	Amount  float64        `json:"amount"`
+	Rate    float64        `json:"rate"`
+	Result  float64        `json:"result"`
+	Via     string         `json:"via"`
+	FromLeg *ConversionLeg `json:"from_leg,omitempty"`
+	ToLeg   *ConversionLeg `json:"to_leg,omitempty"`
+}
@@ -0,0 +1,66 @@
+package entities
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Stablecoin is a coin expected to trade at Peg in the quote currency.
+type Stablecoin struct {
+	CoinName string  `json:"coin_name"`
+	Peg      float64 `json:"peg"`
+}
+
+// ParseStablecoins parses a comma-separated list of COIN=peg pairs; the peg
+// defaults to 1 when omitted, e.g. "USDT,USDC=1,DAI".
+func ParseStablecoins(value string) ([]Stablecoin, error) {
+	var stablecoins []Stablecoin
+	for _, item := range strings.Split(value, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		name, pegValue, hasPeg := strings.Cut(item, "=")
+		stablecoin := Stablecoin{CoinName: strings.ToUpper(strings.TrimSpace(name)), Peg: 1}
+		if hasPeg {
+			peg, err := strconv.ParseFloat(strings.TrimSpace(pegValue), 64)
+			if err != nil || peg <= 0 {
+				return nil, errors.Wrapf(ErrInvalidParam, "invalid peg for %s: %q", stablecoin.CoinName, pegValue)
+			}
+			stablecoin.Peg = peg
+		}
+		if stablecoin.CoinName == "" {
+			return nil, errors.Wrapf(ErrInvalidParam, "invalid stablecoin: %q", item)
+		}
+		stablecoins = append(stablecoins, stablecoin)
+	}
+	return stablecoins, nil
+}
+
+// DeviationBps is the deviation of price from peg in basis points.
+func DeviationBps(price, peg float64) float64 {
+	return (price - peg) / peg * 10000
+}
+
+// DepegStatus is the current peg deviation of a stablecoin.
+type DepegStatus struct {
+	CoinName     string    `json:"coin_name"`
+	Peg          float64   `json:"peg"`
+	Price        float64   `json:"price"`
+	DeviationBps float64   `json:"deviation_bps"`
+	ObservedAt   time.Time `json:"observed_at"`
+	// BreachedSince — начало текущего непрерывного выхода за порог
+	BreachedSince *time.Time `json:"breached_since,omitempty"`
+	// Depegged — по монете открыт алерт об отвязке
+	Depegged bool `json:"depegged"`
+}
+
+// DeviationPoint is the peg deviation of a candle close.
+type DeviationPoint struct {
+	Time         time.Time `json:"time"`
+	Price        float64   `json:"price"`
+	DeviationBps float64   `json:"deviation_bps"`
+}
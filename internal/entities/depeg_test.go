@@ -0,0 +1,27 @@
+package entities_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"Cryptoproject/internal/entities"
+)
+
+func Test_ParseStablecoins(t *testing.T) {
+	t.Parallel()
+
+	stablecoins, err := entities.ParseStablecoins("usdt, USDC=1, EURS=1.08,")
+	require.NoError(t, err)
+	assert.Equal(t, []entities.Stablecoin{
+		{CoinName: "USDT", Peg: 1},
+		{CoinName: "USDC", Peg: 1},
+		{CoinName: "EURS", Peg: 1.08},
+	}, stablecoins)
+
+	_, err = entities.ParseStablecoins("DAI=abc")
+	assert.ErrorIs(t, err, entities.ErrInvalidParam)
+
+	assert.InDelta(t, -50, entities.DeviationBps(0.995, 1), 1e-9)
+}
@@ -1,9 +1,104 @@
 package entities
 
-import "github.com/pkg/errors"
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+)
 
 var (
 	ErrInvalidParam = errors.New("invalid param")
 	ErrInternal     = errors.New("internal error")
 	ErrNotFound     = errors.New("missing data")
+	ErrStaleData    = errors.New("stale data")
+
+	// ErrUnknownSymbol is returned for coins the provider does not list. It is also an ErrNotFound.
+	ErrUnknownSymbol error = &subError{msg: "unknown symbol", parent: ErrNotFound}
+	// ErrRateLimited is returned when the client or the service itself exceeded a request rate limit.
+	ErrRateLimited = errors.New("rate limited")
+	// ErrProviderUnavailable is returned when the price provider cannot be reached or fails.
+	ErrProviderUnavailable = errors.New("provider unavailable")
+	// ErrProviderError is returned when the price provider answers with an unexpected response.
+	ErrProviderError = errors.New("provider error")
+	// ErrCircuitOpen is returned without calling the provider while it is considered down.
+	// It is also an ErrProviderUnavailable.
+	ErrCircuitOpen error = &subError{msg: "circuit open", parent: ErrProviderUnavailable}
 )
+
+// subError — частный случай другой ошибки: errors.Is находит и его, и родителя
+type subError struct {
+	msg    string
+	parent error
+}
+
+func (e *subError) Error() string {
+	return e.msg
+}
+
+func (e *subError) Unwrap() error {
+	return e.parent
+}
+
+// Stable error codes of every API: clients branch on them, not on messages.
+const (
+	CodeInvalidParam        = "INVALID_PARAM"
+	CodeUnknownSymbol       = "UNKNOWN_SYMBOL"
+	CodeNotFound            = "NOT_FOUND"
+	CodeStaleData           = "STALE_DATA"
+	CodeRateLimited         = "RATE_LIMITED"
+	CodeUnauthorized        = "UNAUTHORIZED"
+	CodeProviderError       = "PROVIDER_ERROR"
+	CodeProviderUnavailable = "PROVIDER_UNAVAILABLE"
+	CodeInternal            = "INTERNAL"
+)
+
+// ErrorClass is how a class of errors is reported to API clients.
+type ErrorClass struct {
+	// Sentinel matches the errors of the class; it is nil for internal errors.
+	Sentinel error
+	Code     string
+	// Message replaces the error text for classes whose details are not
+	// caused by the request; empty means the detail is shown.
+	Message string
+}
+
+// errorClasses проверяются по порядку: частные ошибки раньше общих
+var errorClasses = []ErrorClass{
+	{Sentinel: ErrInvalidParam, Code: CodeInvalidParam},
+	{Sentinel: ErrUnknownSymbol, Code: CodeUnknownSymbol},
+	{Sentinel: ErrNotFound, Code: CodeNotFound},
+	{Sentinel: ErrStaleData, Code: CodeStaleData},
+	{Sentinel: ErrRateLimited, Code: CodeRateLimited, Message: "Too many requests, retry later"},
+	{Sentinel: ErrProviderError, Code: CodeProviderError, Message: "The price provider returned an unexpected response"},
+	{Sentinel: ErrProviderUnavailable, Code: CodeProviderUnavailable, Message: "The price provider is unavailable, retry later"},
+}
+
+var internalErrorClass = ErrorClass{Code: CodeInternal, Message: "The request could not be processed"}
+
+// ClassifyError returns the class of err and the detail that is safe to show
+// to clients: the message a request error was created with, without the
+// context added by callers on the way up, or the fixed message of the class.
+func ClassifyError(err error) (ErrorClass, string) {
+	for _, class := range errorClasses {
+		if !errors.Is(err, class.Sentinel) {
+			continue
+		}
+		if class.Message != "" {
+			return class, class.Message
+		}
+		return class, publicDetail(err, class.Sentinel)
+	}
+	return internalErrorClass, internalErrorClass.Message
+}
+
+// publicDetail returns the message err was created with around sentinel,
+// without the sentinel text.
+func publicDetail(err, sentinel error) string {
+	for e := err; e != nil; e = errors.Unwrap(e) {
+		if errors.Unwrap(e) == sentinel {
+			return strings.TrimSuffix(e.Error(), ": "+sentinel.Error())
+		}
+	}
+	// ошибка без пояснения: обёртки вызывающих не показываются
+	return sentinel.Error()
+}
@@ -0,0 +1,79 @@
+package entities_test
+
+import (
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+
+	"Cryptoproject/internal/entities"
+)
+
+func Test_ErrUnknownSymbol_IsNotFound(t *testing.T) {
+	t.Parallel()
+
+	err := errors.Wrap(entities.ErrUnknownSymbol, "unknown titles: [XXX]")
+	assert.ErrorIs(t, err, entities.ErrUnknownSymbol)
+	assert.ErrorIs(t, err, entities.ErrNotFound)
+	assert.NotErrorIs(t, errors.Wrap(entities.ErrNotFound, "no history"), entities.ErrUnknownSymbol)
+	assert.Equal(t, "unknown titles: [XXX]: unknown symbol", err.Error())
+}
+
+func Test_ErrCircuitOpen_IsProviderUnavailable(t *testing.T) {
+	t.Parallel()
+
+	err := errors.Wrap(entities.ErrCircuitOpen, "retry in 10s")
+	assert.ErrorIs(t, err, entities.ErrProviderUnavailable)
+	assert.NotErrorIs(t, entities.ErrProviderUnavailable, entities.ErrCircuitOpen)
+}
+
+func Test_ClassifyError(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		err    error
+		code   string
+		detail string
+	}{
+		{
+			name:   "caller context is dropped",
+			err:    errors.Wrap(errors.Wrapf(entities.ErrInvalidParam, "window %s exceeds 24h", "48h"), "failed to get candles from storage"),
+			code:   entities.CodeInvalidParam,
+			detail: "window 48h exceeds 24h",
+		},
+		{
+			name:   "bare sentinel",
+			err:    entities.ErrNotFound,
+			code:   entities.CodeNotFound,
+			detail: "missing data",
+		},
+		{
+			name:   "unknown symbol before not found",
+			err:    errors.Wrap(errors.Wrap(entities.ErrUnknownSymbol, "unknown titles: [XXX]"), "failed to check existing titles"),
+			code:   entities.CodeUnknownSymbol,
+			detail: "unknown titles: [XXX]",
+		},
+		{
+			name:   "provider details stay internal",
+			err:    errors.Wrap(entities.ErrCircuitOpen, "dial tcp 10.0.0.1:443"),
+			code:   entities.CodeProviderUnavailable,
+			detail: "The price provider is unavailable, retry later",
+		},
+		{
+			name:   "internal",
+			err:    errors.Wrap(entities.ErrInternal, "pq: password authentication failed"),
+			code:   entities.CodeInternal,
+			detail: "The request could not be processed",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			class, detail := entities.ClassifyError(tt.err)
+			assert.Equal(t, tt.code, class.Code)
+			assert.Equal(t, tt.detail, detail)
+		})
+	}
+}
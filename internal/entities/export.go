@@ -0,0 +1,51 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+type ExportFormat string
+
+const (
+	ExportCSV     ExportFormat = "csv"
+	ExportNDJSON  ExportFormat = "ndjson"
+	ExportParquet ExportFormat = "parquet"
+)
+
+// MaxExportCoins caps the number of coins in a single export.
+const MaxExportCoins = 100
+
+func ParseExportFormat(value string) (ExportFormat, error) {
+	switch format := ExportFormat(value); format {
+	case "":
+		return ExportCSV, nil
+	case ExportCSV, ExportNDJSON, ExportParquet:
+		return format, nil
+	default:
+		return "", errors.Wrapf(ErrInvalidParam, "unsupported format: %q (allowed: csv, ndjson, parquet)", value)
+	}
+}
+
+// ExportQuery selects the price history to export. Empty Titles means all tracked coins.
+type ExportQuery struct {
+	Titles []string
+	From   time.Time
+	// To is exclusive.
+	To time.Time
+}
+
+// HistoryRecord is a row of coins_history: a raw price, or an hourly or daily
+// aggregate for the periods that were already downsampled.
+type HistoryRecord struct {
+	CoinName   string    `json:"coin_name"`
+	Time       time.Time `json:"time"`
+	Resolution string    `json:"resolution"` // raw, hour или day
+	Open       float64   `json:"open"`
+	High       float64   `json:"high"`
+	Low        float64   `json:"low"`
+	Close      float64   `json:"close"`
+	Avg        float64   `json:"avg"`
+	Count      int64     `json:"count"`
+}
@@ -0,0 +1,25 @@
+package entities_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"Cryptoproject/internal/entities"
+)
+
+func Test_ParseExportFormat(t *testing.T) {
+	t.Parallel()
+
+	format, err := entities.ParseExportFormat("")
+	require.NoError(t, err)
+	assert.Equal(t, entities.ExportCSV, format)
+
+	format, err = entities.ParseExportFormat("parquet")
+	require.NoError(t, err)
+	assert.Equal(t, entities.ExportParquet, format)
+
+	_, err = entities.ParseExportFormat("xlsx")
+	assert.ErrorIs(t, err, entities.ErrInvalidParam)
+}
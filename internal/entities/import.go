@@ -0,0 +1,64 @@
+package entities
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+)
+
+// MaxImportErrors caps the line errors kept in an ImportReport; the rest are only counted.
+const MaxImportErrors = 100
+
+// ParseImportFormat accepts the text formats of ExportFormat; Parquet cannot be imported.
+func ParseImportFormat(value string) (ExportFormat, error) {
+	switch format := ExportFormat(value); format {
+	case "":
+		return ExportCSV, nil
+	case ExportCSV, ExportNDJSON:
+		return format, nil
+	default:
+		return "", errors.Wrapf(ErrInvalidParam, "unsupported import format: %q (allowed: csv, ndjson)", value)
+	}
+}
+
+// CoinReader yields coins parsed from an import file. Next returns io.EOF
+// after the last line and *LineError for a line that cannot be parsed;
+// reading may continue after it.
+type CoinReader interface {
+	Next() (Coin, error)
+	// Line is the input line of the last coin returned by Next.
+	Line() int
+}
+
+// LineError is an input line that could not be imported.
+type LineError struct {
+	Line int
+	Err  error
+}
+
+func (e *LineError) Error() string {
+	return fmt.Sprintf("line %d: %v", e.Line, e.Err)
+}
+
+func (e *LineError) Unwrap() error {
+	return e.Err
+}
+
+// ImportReport summarises an import. Imported counts new rows, Skipped the
+// rows already in storage, so re-running an import only increases Skipped.
+type ImportReport struct {
+	Lines    int
+	Imported int
+	Skipped  int
+	Rejected int
+	// Errors holds the first MaxImportErrors rejected lines.
+	Errors []LineError
+}
+
+// Reject records a rejected line.
+func (r *ImportReport) Reject(lineErr LineError) {
+	r.Rejected++
+	if len(r.Errors) < MaxImportErrors {
+		r.Errors = append(r.Errors, lineErr)
+	}
+}
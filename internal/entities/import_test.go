@@ -0,0 +1,34 @@
+package entities_test
+
+import (
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"Cryptoproject/internal/entities"
+)
+
+func Test_ParseImportFormat(t *testing.T) {
+	t.Parallel()
+
+	format, err := entities.ParseImportFormat("ndjson")
+	require.NoError(t, err)
+	assert.Equal(t, entities.ExportNDJSON, format)
+
+	_, err = entities.ParseImportFormat("parquet")
+	assert.ErrorIs(t, err, entities.ErrInvalidParam)
+}
+
+func Test_ImportReport_Reject(t *testing.T) {
+	t.Parallel()
+
+	var report entities.ImportReport
+	for line := 1; line <= entities.MaxImportErrors+5; line++ {
+		report.Reject(entities.LineError{Line: line, Err: errors.New("bad price")})
+	}
+	assert.Equal(t, entities.MaxImportErrors+5, report.Rejected)
+	require.Len(t, report.Errors, entities.MaxImportErrors)
+	assert.Equal(t, "line 1: bad price", report.Errors[0].Error())
+}
@@ -0,0 +1,124 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Candle is the OHLC summary of the prices observed in one bucket.
+type Candle struct {
+	Bucket time.Time `json:"bucket"`
+	Open   float64   `json:"open"`
+	High   float64   `json:"high"`
+	Low    float64   `json:"low"`
+	Close  float64   `json:"close"`
+	Avg    float64   `json:"avg"`
+	Count  int64     `json:"count"`
+}
+
+// CandleIntervals are the supported bucket sizes for candles.
+var CandleIntervals = map[string]time.Duration{
+	"5m":  5 * time.Minute,
+	"15m": 15 * time.Minute,
+	"1h":  time.Hour,
+	"4h":  4 * time.Hour,
+	"1d":  24 * time.Hour,
+}
+
+func ParseCandleInterval(value string) (time.Duration, error) {
+	if value == "" {
+		return CandleIntervals["1h"], nil
+	}
+	interval, ok := CandleIntervals[value]
+	if !ok {
+		return 0, errors.Wrapf(ErrInvalidParam, "unsupported interval: %q (allowed: 5m, 15m, 1h, 4h, 1d)", value)
+	}
+	return interval, nil
+}
+
+type IndicatorType string
+
+const (
+	IndicatorSMA       IndicatorType = "sma"
+	IndicatorEMA       IndicatorType = "ema"
+	IndicatorRSI       IndicatorType = "rsi"
+	IndicatorBollinger IndicatorType = "bollinger"
+	IndicatorMACD      IndicatorType = "macd"
+)
+
+// Стандартные параметры индикаторов
+const (
+	DefaultMAPeriod        = 20
+	DefaultRSIPeriod       = 14
+	BollingerDeviations    = 2
+	MACDFastPeriod         = 12
+	MACDSlowPeriod         = 26
+	MACDSignalPeriod       = 9
+	MaxIndicatorPeriod     = 200
+	DefaultIndicatorPoints = 100
+	MaxIndicatorPoints     = 1000
+)
+
+func ParseIndicatorType(value string) (IndicatorType, error) {
+	switch indicator := IndicatorType(value); indicator {
+	case IndicatorSMA, IndicatorEMA, IndicatorRSI, IndicatorBollinger, IndicatorMACD:
+		return indicator, nil
+	case "":
+		return "", errors.Wrap(ErrInvalidParam, "indicator type is required")
+	default:
+		return "", errors.Wrapf(ErrInvalidParam,
+			"unsupported indicator: %q (allowed: sma, ema, rsi, bollinger, macd)", value)
+	}
+}
+
+// DefaultPeriod is the period used when the request does not set one.
+// MACD always uses the standard 12/26/9 periods and reports the slow one.
+func (t IndicatorType) DefaultPeriod() int {
+	switch t {
+	case IndicatorRSI:
+		return DefaultRSIPeriod
+	case IndicatorMACD:
+		return MACDSlowPeriod
+	default:
+		return DefaultMAPeriod
+	}
+}
+
+// Warmup is the number of buckets consumed before the first value.
+func (t IndicatorType) Warmup(period int) int {
+	switch t {
+	case IndicatorRSI:
+		return period
+	case IndicatorMACD:
+		return MACDSlowPeriod + MACDSignalPeriod - 2
+	default:
+		return period - 1
+	}
+}
+
+// IndicatorQuery selects an indicator over bucketed prices of a coin.
+type IndicatorQuery struct {
+	CoinName string
+	Type     IndicatorType
+	Period   int
+	Interval time.Duration
+	Limit    int
+}
+
+// IndicatorPoint holds the indicator values for a bucket, keyed by line
+// name: "value" for sma, ema and rsi; "upper", "middle", "lower" for
+// bollinger; "macd", "signal", "histogram" for macd.
+type IndicatorPoint struct {
+	Time   time.Time          `json:"time"`
+	Close  float64            `json:"close"`
+	Values map[string]float64 `json:"values"`
+}
+
+type IndicatorSeries struct {
+	CoinName string           `json:"coin_name"`
+	Type     IndicatorType    `json:"type"`
+	Period   int              `json:"period"`
+	Interval time.Duration    `json:"interval"`
+	Points   []IndicatorPoint `json:"points"`
+}
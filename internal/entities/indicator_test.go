@@ -0,0 +1,40 @@
+package entities_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"Cryptoproject/internal/entities"
+)
+
+func Test_ParseIndicatorType(t *testing.T) {
+	t.Parallel()
+
+	indicator, err := entities.ParseIndicatorType("macd")
+	require.NoError(t, err)
+	assert.Equal(t, entities.IndicatorMACD, indicator)
+
+	_, err = entities.ParseIndicatorType("")
+	assert.ErrorIs(t, err, entities.ErrInvalidParam)
+
+	_, err = entities.ParseIndicatorType("vwap")
+	assert.ErrorIs(t, err, entities.ErrInvalidParam)
+}
+
+func Test_ParseCandleInterval(t *testing.T) {
+	t.Parallel()
+
+	interval, err := entities.ParseCandleInterval("")
+	require.NoError(t, err)
+	assert.Equal(t, time.Hour, interval)
+
+	interval, err = entities.ParseCandleInterval("15m")
+	require.NoError(t, err)
+	assert.Equal(t, 15*time.Minute, interval)
+
+	_, err = entities.ParseCandleInterval("2h")
+	assert.ErrorIs(t, err, entities.ErrInvalidParam)
+}
@@ -0,0 +1,46 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// MoverWindows are the supported look-back windows for market movers.
+var MoverWindows = map[string]time.Duration{
+	"1h":  time.Hour,
+	"24h": 24 * time.Hour,
+	"7d":  7 * 24 * time.Hour,
+}
+
+func ParseMoverWindow(value string) (time.Duration, error) {
+	if value == "" {
+		return MoverWindows["24h"], nil
+	}
+	window, ok := MoverWindows[value]
+	if !ok {
+		return 0, errors.Wrapf(ErrInvalidParam, "unsupported window: %q (allowed: 1h, 24h, 7d)", value)
+	}
+	return window, nil
+}
+
+// MarketMover is the price move of a coin over a window.
+type MarketMover struct {
+	CoinName   string  `json:"coin_name"`
+	OpenPrice  float64 `json:"open_price"`
+	LastPrice  float64 `json:"last_price"`
+	ChangePct  float64 `json:"change_pct"`
+	Volatility float64 `json:"volatility"` // стандартное отклонение цены в процентах от средней
+}
+
+// MarketOverview ranks tracked coins by their move over a window.
+type MarketOverview struct {
+	Window       time.Duration `json:"window"`
+	Since        time.Time     `json:"since"`
+	Gainers      []MarketMover `json:"gainers"`
+	Losers       []MarketMover `json:"losers"`
+	MostVolatile []MarketMover `json:"most_volatile"`
+	Up           int           `json:"up"`
+	Down         int           `json:"down"`
+	Unchanged    int           `json:"unchanged"`
+}
@@ -0,0 +1,26 @@
+package entities_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"Cryptoproject/internal/entities"
+)
+
+func Test_ParseMoverWindow(t *testing.T) {
+	t.Parallel()
+
+	window, err := entities.ParseMoverWindow("")
+	require.NoError(t, err)
+	assert.Equal(t, 24*time.Hour, window)
+
+	window, err = entities.ParseMoverWindow("7d")
+	require.NoError(t, err)
+	assert.Equal(t, 7*24*time.Hour, window)
+
+	_, err = entities.ParseMoverWindow("30d")
+	assert.ErrorIs(t, err, entities.ErrInvalidParam)
+}
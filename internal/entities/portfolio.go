@@ -0,0 +1,98 @@
+package entities
+
+import (
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+type TransactionSide string
+
+const (
+	SideBuy  TransactionSide = "buy"
+	SideSell TransactionSide = "sell"
+)
+
+type Portfolio struct {
+	ID        int64     `json:"id"`
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type Transaction struct {
+	ID          int64           `json:"id"`
+	PortfolioID int64           `json:"portfolio_id"`
+	CoinName    string          `json:"coin_name"`
+	Side        TransactionSide `json:"side"`
+	Quantity    float64         `json:"quantity"`
+	Price       float64         `json:"price"`
+	Fee         float64         `json:"fee"`
+	ExecutedAt  time.Time       `json:"executed_at"`
+}
+
+func NewPortfolio(name string) (*Portfolio, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, errors.Wrap(ErrInvalidParam, "portfolio name not set")
+	}
+	return &Portfolio{
+		Name:      name,
+		CreatedAt: time.Now(),
+	}, nil
+}
+
+func NewTransaction(portfolioID int64, coinName string, side TransactionSide, quantity, price, fee float64, executedAt time.Time) (*Transaction, error) {
+	if portfolioID <= 0 {
+		return nil, errors.Wrap(ErrInvalidParam, "portfolio id not set")
+	}
+	if coinName == "" {
+		return nil, errors.Wrap(ErrInvalidParam, "coin name not set")
+	}
+	if side != SideBuy && side != SideSell {
+		return nil, errors.Wrapf(ErrInvalidParam, "unknown side: %s", side)
+	}
+	if quantity <= 0 {
+		return nil, errors.Wrap(ErrInvalidParam, "quantity must be greater then 0")
+	}
+	if price <= 0 {
+		return nil, errors.Wrap(ErrInvalidParam, "price must be greater then 0")
+	}
+	if fee < 0 {
+		return nil, errors.Wrap(ErrInvalidParam, "fee must not be negative")
+	}
+	if executedAt.IsZero() {
+		executedAt = time.Now()
+	}
+	return &Transaction{
+		PortfolioID: portfolioID,
+		CoinName:    strings.ToUpper(coinName),
+		Side:        side,
+		Quantity:    quantity,
+		Price:       price,
+		Fee:         fee,
+		ExecutedAt:  executedAt,
+	}, nil
+}
+
+// Position is the state of a single coin in a portfolio valued at a point in time.
+type Position struct {
+	CoinName      string    `json:"coin_name"`
+	Quantity      float64   `json:"quantity"`
+	CostBasis     float64   `json:"cost_basis"`
+	Price         float64   `json:"price"`
+	PriceAt       time.Time `json:"price_at"`
+	MarketValue   float64   `json:"market_value"`
+	RealizedPnL   float64   `json:"realized_pnl"`
+	UnrealizedPnL float64   `json:"unrealized_pnl"`
+}
+
+type Valuation struct {
+	PortfolioID   int64      `json:"portfolio_id"`
+	At            time.Time  `json:"at"`
+	Positions     []Position `json:"positions"`
+	CostBasis     float64    `json:"cost_basis"`
+	MarketValue   float64    `json:"market_value"`
+	RealizedPnL   float64    `json:"realized_pnl"`
+	UnrealizedPnL float64    `json:"unrealized_pnl"`
+}
@@ -0,0 +1,40 @@
+package entities
+
+import "time"
+
+type QuarantineReason string
+
+const (
+	// QuarantineNonPositivePrice — цена нулевая или отрицательная
+	QuarantineNonPositivePrice QuarantineReason = "non_positive_price"
+	// QuarantinePriceJump — скачок от предыдущей цены выше порога по проценту или z-оценке
+	QuarantinePriceJump QuarantineReason = "price_jump"
+	// QuarantineFrozenFeed — цена не менялась дольше допустимого
+	QuarantineFrozenFeed QuarantineReason = "frozen_feed"
+)
+
+// QuarantinedQuote is a provider quote rejected by ingestion validation.
+type QuarantinedQuote struct {
+	CoinName   string           `json:"coin_name"`
+	Currency   string           `json:"currency"`
+	Source     string           `json:"source"`
+	Price      float64          `json:"price"`
+	ObservedAt time.Time        `json:"observed_at"`
+	Reason     QuarantineReason `json:"reason"`
+	Detail     string           `json:"detail"`
+	// ReferencePrice — последняя принятая цена, с которой сравнивалась котировка
+	ReferencePrice float64 `json:"reference_price,omitempty"`
+}
+
+func NewQuarantinedQuote(coin Coin, reason QuarantineReason, detail string, reference float64) QuarantinedQuote {
+	return QuarantinedQuote{
+		CoinName:       coin.CoinName,
+		Currency:       coin.Currency,
+		Source:         coin.Source,
+		Price:          coin.Price,
+		ObservedAt:     coin.ObservedAt,
+		Reason:         reason,
+		Detail:         detail,
+		ReferencePrice: reference,
+	}
+}
@@ -0,0 +1,37 @@
+package entities
+
+// QuoteStatus tells whether a price could be served for a requested coin.
+type QuoteStatus string
+
+const (
+	// QuoteOK — актуальная цена получена из кэша, хранилища или от провайдера
+	QuoteOK QuoteStatus = "ok"
+	// QuoteUnknownSymbol — провайдер не знает такую монету
+	QuoteUnknownSymbol QuoteStatus = "unknown_symbol"
+	// QuoteStale — провайдер недоступен, отдана последняя сохранённая цена
+	QuoteStale QuoteStatus = "stale"
+	// QuoteProviderError — провайдер недоступен, а сохранённой цены нет
+	QuoteProviderError QuoteStatus = "provider_error"
+	// QuoteRejected — котировка провайдера не прошла проверку и помещена в карантин
+	QuoteRejected QuoteStatus = "rejected"
+)
+
+// Quote is the outcome of a price query for a single coin. Coin is set for
+// the ok and stale statuses, Reason explains the other ones.
+type Quote struct {
+	Title  string
+	Status QuoteStatus
+	Coin   *Coin
+	Reason string
+}
+
+// CountQuotes returns how many quotes have the given status.
+func CountQuotes(quotes []Quote, status QuoteStatus) int {
+	n := 0
+	for _, quote := range quotes {
+		if quote.Status == status {
+			n++
+		}
+	}
+	return n
+}
@@ -0,0 +1,90 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Ticker is a market snapshot of a coin: the price together with 24h
+// statistics and capitalisation as reported by the provider.
+type Ticker struct {
+	CoinName string  `json:"coin_name"`
+	Currency string  `json:"currency"`
+	Source   string  `json:"source"`
+	Price    float64 `json:"price"`
+	// Volume24h is the traded volume over the last 24 hours in Currency.
+	Volume24h    float64   `json:"volume_24h"`
+	High24h      float64   `json:"high_24h"`
+	Low24h       float64   `json:"low_24h"`
+	Change24hPct float64   `json:"change_24h_pct"`
+	MarketCap    float64   `json:"market_cap"`
+	Supply       float64   `json:"supply"`
+	ObservedAt   time.Time `json:"observed_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+// Coin returns the price part of the ticker.
+func (t Ticker) Coin() Coin {
+	return Coin{
+		CoinName:   t.CoinName,
+		Price:      t.Price,
+		Currency:   t.Currency,
+		Source:     t.Source,
+		ObservedAt: t.ObservedAt,
+	}
+}
+
+type TickerSortField string
+
+const (
+	TickerSortMarketCap TickerSortField = "market_cap"
+	TickerSortChange    TickerSortField = "change"
+	TickerSortVolume    TickerSortField = "volume"
+	TickerSortName      TickerSortField = "name"
+)
+
+// TickerSort orders tickers by a field, e.g. "-market_cap" is parsed as
+// market cap descending.
+type TickerSort struct {
+	Field TickerSortField
+	Desc  bool
+}
+
+func ParseTickerSort(value string) (TickerSort, error) {
+	if value == "" {
+		return TickerSort{Field: TickerSortMarketCap, Desc: true}, nil
+	}
+
+	var sort TickerSort
+	if value[0] == '-' {
+		sort.Desc = true
+		value = value[1:]
+	}
+
+	switch field := TickerSortField(value); field {
+	case TickerSortMarketCap, TickerSortChange, TickerSortVolume, TickerSortName:
+		sort.Field = field
+	default:
+		return TickerSort{}, errors.Wrapf(ErrInvalidParam,
+			"unsupported sort field: %q (allowed: market_cap, change, volume, name)", value)
+	}
+	return sort, nil
+}
+
+// Less reports whether a goes before b in this order.
+func (s TickerSort) Less(a, b Ticker) bool {
+	if s.Desc {
+		a, b = b, a
+	}
+	switch s.Field {
+	case TickerSortChange:
+		return a.Change24hPct < b.Change24hPct
+	case TickerSortVolume:
+		return a.Volume24h < b.Volume24h
+	case TickerSortName:
+		return a.CoinName < b.CoinName
+	default:
+		return a.MarketCap < b.MarketCap
+	}
+}
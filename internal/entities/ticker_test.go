@@ -0,0 +1,60 @@
+package entities_test
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"Cryptoproject/internal/entities"
+)
+
+func Test_ParseTickerSort(t *testing.T) {
+	t.Parallel()
+
+	order, err := entities.ParseTickerSort("")
+	require.NoError(t, err)
+	assert.Equal(t, entities.TickerSort{Field: entities.TickerSortMarketCap, Desc: true}, order)
+
+	order, err = entities.ParseTickerSort("-change")
+	require.NoError(t, err)
+	assert.Equal(t, entities.TickerSort{Field: entities.TickerSortChange, Desc: true}, order)
+
+	order, err = entities.ParseTickerSort("volume")
+	require.NoError(t, err)
+	assert.Equal(t, entities.TickerSort{Field: entities.TickerSortVolume}, order)
+
+	_, err = entities.ParseTickerSort("price")
+	assert.ErrorIs(t, err, entities.ErrInvalidParam)
+}
+
+func Test_TickerSort_Less(t *testing.T) {
+	t.Parallel()
+
+	tickers := []entities.Ticker{
+		{CoinName: "ETH", MarketCap: 300, Change24hPct: 5},
+		{CoinName: "BTC", MarketCap: 1000, Change24hPct: -2},
+		{CoinName: "DOGE", MarketCap: 10, Change24hPct: 12},
+	}
+
+	order := entities.TickerSort{Field: entities.TickerSortMarketCap, Desc: true}
+	sort.SliceStable(tickers, func(i, j int) bool { return order.Less(tickers[i], tickers[j]) })
+	assert.Equal(t, []string{"BTC", "ETH", "DOGE"}, tickerNames(tickers))
+
+	order = entities.TickerSort{Field: entities.TickerSortChange}
+	sort.SliceStable(tickers, func(i, j int) bool { return order.Less(tickers[i], tickers[j]) })
+	assert.Equal(t, []string{"BTC", "ETH", "DOGE"}, tickerNames(tickers))
+
+	order = entities.TickerSort{Field: entities.TickerSortChange, Desc: true}
+	sort.SliceStable(tickers, func(i, j int) bool { return order.Less(tickers[i], tickers[j]) })
+	assert.Equal(t, []string{"DOGE", "ETH", "BTC"}, tickerNames(tickers))
+}
+
+func tickerNames(tickers []entities.Ticker) []string {
+	names := make([]string, 0, len(tickers))
+	for _, ticker := range tickers {
+		names = append(names, ticker.CoinName)
+	}
+	return names
+}
@@ -0,0 +1,76 @@
+// Package graphql implements GraphQL endpoint for cryptocurrency API
+package graphql
+
+import (
+	"context"
+	_ "embed"
+	"log/slog"
+	"net/http"
+
+	"github.com/graph-gophers/graphql-go"
+	"github.com/graph-gophers/graphql-go/relay"
+	"github.com/pkg/errors"
+
+	"Cryptoproject/internal/entities"
+)
+
+const (
+	// maxDepth и maxParallelism ограничивают стоимость одного запроса
+	maxDepth       = 6
+	maxParallelism = 16
+)
+
+//go:embed schema.graphql
+var schema string
+
+// NewHandler returns an HTTP handler serving GraphQL queries posted as JSON.
+func NewHandler(service CoinService, logger *slog.Logger) (http.Handler, error) {
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	parsed, err := graphql.ParseSchema(schema, &queryResolver{service: service},
+		graphql.UseStringDescriptions(),
+		graphql.MaxDepth(maxDepth),
+		graphql.MaxParallelism(maxParallelism),
+		graphql.Logger(&panicLogger{logger: logger.With(slog.String("component", "graphql"))}),
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse graphql schema")
+	}
+	return &relay.Handler{Schema: parsed}, nil
+}
+
+// serviceError adds a machine-readable code to errors returned by resolvers.
+// The codes are the ones the HTTP API reports.
+type serviceError struct {
+	err     error
+	code    string
+	message string
+}
+
+func newError(err error) error {
+	// внутренние подробности клиенту не возвращаются
+	class, message := entities.ClassifyError(err)
+	return &serviceError{err: err, code: class.Code, message: message}
+}
+
+func (e *serviceError) Error() string {
+	return e.message
+}
+
+func (e *serviceError) Unwrap() error {
+	return e.err
+}
+
+func (e *serviceError) Extensions() map[string]any {
+	return map[string]any{"code": e.code}
+}
+
+type panicLogger struct {
+	logger *slog.Logger
+}
+
+func (l *panicLogger) LogPanic(ctx context.Context, value any) {
+	l.logger.ErrorContext(ctx, "Resolver panicked", slog.Any("panic", value))
+}
@@ -0,0 +1,180 @@
+package graphql_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"Cryptoproject/internal/entities"
+	"Cryptoproject/internal/ports/graphql"
+)
+
+type countingCoinService struct {
+	mu    sync.Mutex
+	calls map[string]int
+	at    time.Time
+}
+
+func (s *countingCoinService) count(method string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.calls[method]++
+}
+
+func (s *countingCoinService) GetLastRates(_ context.Context, titles []string) ([]entities.Coin, error) {
+	s.count("GetLastRates")
+	coins := make([]entities.Coin, 0, len(titles))
+	for i, title := range titles {
+		coins = append(coins, entities.Coin{CoinName: title, Price: float64(i + 1), Currency: "USD", ObservedAt: s.at})
+	}
+	return coins, nil
+}
+
+func (s *countingCoinService) GetWatchlist(_ context.Context) ([]string, error) {
+	s.count("GetWatchlist")
+	return []string{"BTC", "ETH"}, nil
+}
+
+func (s *countingCoinService) GetSummaries(_ context.Context, titles []string, _ time.Duration) (map[string]entities.Candle, error) {
+	s.count("GetSummaries")
+	summaries := make(map[string]entities.Candle, len(titles))
+	for _, title := range titles {
+		summaries[title] = entities.Candle{Bucket: s.at, Open: 1, High: 3, Low: 1, Close: 2, Avg: 2, Count: 10}
+	}
+	return summaries, nil
+}
+
+func (s *countingCoinService) GetCandlesBatch(_ context.Context, titles []string, interval, _ time.Duration) (map[string][]entities.Candle, error) {
+	s.count("GetCandlesBatch")
+	candles := make(map[string][]entities.Candle, len(titles))
+	for _, title := range titles {
+		candles[title] = []entities.Candle{{Bucket: s.at}, {Bucket: s.at.Add(interval)}}
+	}
+	return candles, nil
+}
+
+func (s *countingCoinService) GetRecentPrices(_ context.Context, _ []string, window time.Duration) (map[string][]entities.Coin, error) {
+	s.count("GetRecentPrices")
+	if window > 24*time.Hour {
+		return nil, entities.ErrInvalidParam
+	}
+	return map[string][]entities.Coin{"BTC": {{CoinName: "BTC", Price: 1, ObservedAt: s.at}}}, nil
+}
+
+type graphQLResponse struct {
+	Data   json.RawMessage `json:"data"`
+	Errors []struct {
+		Message    string         `json:"message"`
+		Extensions map[string]any `json:"extensions"`
+	} `json:"errors"`
+}
+
+func query(t *testing.T, handler http.Handler, q string) graphQLResponse {
+	t.Helper()
+
+	body, err := json.Marshal(map[string]string{"query": q})
+	require.NoError(t, err)
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/api/v1/graphql", strings.NewReader(string(body))))
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var resp graphQLResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	return resp
+}
+
+func Test_GraphQL_BatchesFieldsAcrossCoins(t *testing.T) {
+	t.Parallel()
+
+	service := &countingCoinService{calls: make(map[string]int), at: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)}
+	handler, err := graphql.NewHandler(service, nil)
+	require.NoError(t, err)
+
+	titles := make([]string, 50)
+	for i := range titles {
+		titles[i] = fmt.Sprintf("%q", fmt.Sprintf("COIN%02d", i))
+	}
+	resp := query(t, handler, `{
+		coins(titles: [`+strings.Join(titles, ",")+`]) {
+			title
+			latest { price observedAt }
+			avg: aggregate(function: AVG)
+			high: aggregate(function: MAX)
+			summary { close count }
+			history { price }
+			candles(interval: "1h", window: "2h") { bucket }
+		}
+	}`)
+	require.Empty(t, resp.Errors)
+
+	var data struct {
+		Coins []struct {
+			Title   string
+			Latest  struct{ Price float64 }
+			Avg     float64
+			High    float64
+			Candles []struct{ Bucket time.Time }
+		}
+	}
+	require.NoError(t, json.Unmarshal(resp.Data, &data))
+	require.Len(t, data.Coins, 50)
+	assert.Equal(t, "COIN00", data.Coins[0].Title)
+	assert.Equal(t, 2.0, data.Coins[0].Avg)
+	assert.Equal(t, 3.0, data.Coins[0].High)
+	assert.Len(t, data.Coins[49].Candles, 2)
+
+	assert.Equal(t, map[string]int{
+		"GetLastRates":    1,
+		"GetSummaries":    1,
+		"GetRecentPrices": 1,
+		"GetCandlesBatch": 1,
+	}, service.calls)
+}
+
+func Test_GraphQL_Errors(t *testing.T) {
+	t.Parallel()
+
+	service := &countingCoinService{calls: make(map[string]int), at: time.Now()}
+	handler, err := graphql.NewHandler(service, nil)
+	require.NoError(t, err)
+
+	resp := query(t, handler, `{ coins { title history(window: "48h") { price } } }`)
+	require.NotEmpty(t, resp.Errors)
+	assert.Equal(t, "INVALID_PARAM", resp.Errors[0].Extensions["code"])
+	assert.Equal(t, 1, service.calls["GetWatchlist"])
+
+	resp = query(t, handler, `{ coin(title: "BTC") { candles(interval: "2h") { bucket } } }`)
+	require.NotEmpty(t, resp.Errors)
+	assert.Equal(t, "INVALID_PARAM", resp.Errors[0].Extensions["code"])
+
+	resp = query(t, handler, `{ coins(titles: ["btc", "BTC/USD"]) { title } }`)
+	require.NotEmpty(t, resp.Errors)
+	assert.Equal(t, "INVALID_PARAM", resp.Errors[0].Extensions["code"])
+	assert.Contains(t, resp.Errors[0].Message, `invalid title: "BTC/USD"`)
+
+	resp = query(t, handler, `{ coin(title: "") { title } }`)
+	require.NotEmpty(t, resp.Errors)
+	assert.Equal(t, "INVALID_PARAM", resp.Errors[0].Extensions["code"])
+}
+
+func Test_GraphQL_NormalizesTitles(t *testing.T) {
+	t.Parallel()
+
+	service := &countingCoinService{calls: make(map[string]int), at: time.Now()}
+	handler, err := graphql.NewHandler(service, nil)
+	require.NoError(t, err)
+
+	resp := query(t, handler, `{ coins(titles: ["eth", " btc", "ETH"]) { title latest { price } } one: coin(title: "sol") { title } }`)
+	require.Empty(t, resp.Errors)
+	assert.JSONEq(t, `{"coins":[{"title":"BTC","latest":{"price":1}},{"title":"ETH","latest":{"price":2}}],
+		"one":{"title":"SOL"}}`, string(resp.Data))
+}
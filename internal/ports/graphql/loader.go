@@ -0,0 +1,50 @@
+package graphql
+
+import (
+	"sync"
+)
+
+// batch loads a field for every coin of one list at once. The first resolver
+// asking for a field with given arguments queries the service for all titles;
+// resolvers of the other coins wait for that result.
+type batch struct {
+	service CoinService
+	titles  []string
+
+	mu    sync.Mutex
+	loads map[string]*load
+}
+
+type load struct {
+	once  sync.Once
+	value any
+	err   error
+}
+
+func newBatch(service CoinService, titles []string) *batch {
+	return &batch{
+		service: service,
+		titles:  titles,
+		loads:   make(map[string]*load),
+	}
+}
+
+// fetch returns the result of fn for key, calling fn once per batch.
+func fetch[T any](b *batch, key string, fn func(titles []string) (T, error)) (T, error) {
+	b.mu.Lock()
+	l, ok := b.loads[key]
+	if !ok {
+		l = &load{}
+		b.loads[key] = l
+	}
+	b.mu.Unlock()
+
+	l.once.Do(func() {
+		l.value, l.err = fn(b.titles)
+	})
+	if l.err != nil {
+		var zero T
+		return zero, l.err
+	}
+	return l.value.(T), nil
+}
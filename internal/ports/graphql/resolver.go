@@ -0,0 +1,250 @@
+package graphql
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/graph-gophers/graphql-go"
+	"github.com/pkg/errors"
+
+	"Cryptoproject/internal/entities"
+)
+
+type queryResolver struct {
+	service CoinService
+}
+
+func (r *queryResolver) Coins(ctx context.Context, args struct{ Titles *[]string }) ([]*coinResolver, error) {
+	var titles []string
+	if args.Titles != nil {
+		normalized, err := entities.NormalizeTitles(*args.Titles)
+		if err != nil {
+			return nil, newError(err)
+		}
+		titles = normalized
+		slices.Sort(titles)
+	} else {
+		watchlist, err := r.service.GetWatchlist(ctx)
+		if err != nil {
+			return nil, newError(err)
+		}
+		titles = watchlist
+	}
+
+	b := newBatch(r.service, titles)
+	coins := make([]*coinResolver, 0, len(titles))
+	for _, title := range titles {
+		coins = append(coins, &coinResolver{title: title, batch: b})
+	}
+	return coins, nil
+}
+
+func (r *queryResolver) Coin(args struct{ Title string }) (*coinResolver, error) {
+	title, err := entities.NormalizeTitle(args.Title)
+	if err != nil {
+		return nil, newError(err)
+	}
+	return &coinResolver{title: title, batch: newBatch(r.service, []string{title})}, nil
+}
+
+type coinResolver struct {
+	title string
+	batch *batch
+}
+
+func (r *coinResolver) Title() string {
+	return r.title
+}
+
+func (r *coinResolver) Latest(ctx context.Context) (*priceResolver, error) {
+	latest, err := fetch(r.batch, "latest", func(titles []string) (map[string]entities.Coin, error) {
+		coins, err := r.batch.service.GetLastRates(ctx, titles)
+		if err != nil {
+			return nil, err
+		}
+		latest := make(map[string]entities.Coin, len(coins))
+		for _, coin := range coins {
+			latest[coin.CoinName] = coin
+		}
+		return latest, nil
+	})
+	if err != nil {
+		return nil, newError(err)
+	}
+	coin, ok := latest[r.title]
+	if !ok {
+		return nil, nil
+	}
+	return &priceResolver{coin: coin}, nil
+}
+
+func (r *coinResolver) Aggregate(ctx context.Context, args struct {
+	Function string
+	Window   string
+}) (*float64, error) {
+	summary, err := r.Summary(ctx, struct{ Window string }{args.Window})
+	if err != nil || summary == nil {
+		return nil, err
+	}
+
+	var value float64
+	switch args.Function {
+	case "AVG":
+		value = summary.candle.Avg
+	case "MAX":
+		value = summary.candle.High
+	case "MIN":
+		value = summary.candle.Low
+	default:
+		return nil, newError(errors.Wrapf(entities.ErrInvalidParam, "unsupported aggregate function: %s", args.Function))
+	}
+	return &value, nil
+}
+
+func (r *coinResolver) Summary(ctx context.Context, args struct{ Window string }) (*candleResolver, error) {
+	window, err := parseWindow(args.Window)
+	if err != nil {
+		return nil, newError(err)
+	}
+
+	summaries, err := fetch(r.batch, fmt.Sprintf("summary:%s", window), func(titles []string) (map[string]entities.Candle, error) {
+		return r.batch.service.GetSummaries(ctx, titles, window)
+	})
+	if err != nil {
+		return nil, newError(err)
+	}
+	summary, ok := summaries[r.title]
+	if !ok {
+		return nil, nil
+	}
+	return &candleResolver{candle: summary}, nil
+}
+
+func (r *coinResolver) History(ctx context.Context, args struct{ Window string }) ([]*priceResolver, error) {
+	window, err := parseWindow(args.Window)
+	if err != nil {
+		return nil, newError(err)
+	}
+
+	history, err := fetch(r.batch, fmt.Sprintf("history:%s", window), func(titles []string) (map[string][]entities.Coin, error) {
+		return r.batch.service.GetRecentPrices(ctx, titles, window)
+	})
+	if err != nil {
+		return nil, newError(err)
+	}
+
+	prices := make([]*priceResolver, 0, len(history[r.title]))
+	for _, coin := range history[r.title] {
+		prices = append(prices, &priceResolver{coin: coin})
+	}
+	return prices, nil
+}
+
+func (r *coinResolver) Candles(ctx context.Context, args struct {
+	Interval string
+	Window   string
+}) ([]*candleResolver, error) {
+	interval, err := entities.ParseCandleInterval(args.Interval)
+	if err != nil {
+		return nil, newError(err)
+	}
+	window, err := parseWindow(args.Window)
+	if err != nil {
+		return nil, newError(err)
+	}
+
+	key := fmt.Sprintf("candles:%s:%s", interval, window)
+	candles, err := fetch(r.batch, key, func(titles []string) (map[string][]entities.Candle, error) {
+		return r.batch.service.GetCandlesBatch(ctx, titles, interval, window)
+	})
+	if err != nil {
+		return nil, newError(err)
+	}
+
+	result := make([]*candleResolver, 0, len(candles[r.title]))
+	for _, candle := range candles[r.title] {
+		result = append(result, &candleResolver{candle: candle})
+	}
+	return result, nil
+}
+
+type priceResolver struct {
+	coin entities.Coin
+}
+
+func (r *priceResolver) Price() float64 {
+	return r.coin.Price
+}
+
+func (r *priceResolver) Currency() string {
+	return r.coin.Currency
+}
+
+func (r *priceResolver) Source() string {
+	return r.coin.Source
+}
+
+func (r *priceResolver) ObservedAt() graphql.Time {
+	return graphql.Time{Time: r.coin.ObservedAt}
+}
+
+func (r *priceResolver) CreatedAt() *graphql.Time {
+	if r.coin.CreatedAt.IsZero() {
+		return nil
+	}
+	return &graphql.Time{Time: r.coin.CreatedAt}
+}
+
+func (r *priceResolver) Stale() bool {
+	return r.coin.Stale
+}
+
+func (r *priceResolver) AgeSeconds() *float64 {
+	if !r.coin.Stale {
+		return nil
+	}
+	age := r.coin.Age.Seconds()
+	return &age
+}
+
+type candleResolver struct {
+	candle entities.Candle
+}
+
+func (r *candleResolver) Bucket() graphql.Time {
+	return graphql.Time{Time: r.candle.Bucket}
+}
+
+func (r *candleResolver) Open() float64 {
+	return r.candle.Open
+}
+
+func (r *candleResolver) High() float64 {
+	return r.candle.High
+}
+
+func (r *candleResolver) Low() float64 {
+	return r.candle.Low
+}
+
+func (r *candleResolver) Close() float64 {
+	return r.candle.Close
+}
+
+func (r *candleResolver) Avg() float64 {
+	return r.candle.Avg
+}
+
+func (r *candleResolver) Count() int32 {
+	return int32(min(r.candle.Count, 1<<31-1))
+}
+
+func parseWindow(value string) (time.Duration, error) {
+	window, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, errors.Wrapf(entities.ErrInvalidParam, "invalid window: %q", value)
+	}
+	return window, nil
+}
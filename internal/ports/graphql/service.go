@@ -0,0 +1,16 @@
+package graphql
+
+import (
+	"context"
+	"time"
+
+	"Cryptoproject/internal/entities"
+)
+
+type CoinService interface {
+	GetLastRates(ctx context.Context, titles []string) ([]entities.Coin, error)
+	GetWatchlist(ctx context.Context) ([]string, error)
+	GetSummaries(ctx context.Context, titles []string, window time.Duration) (map[string]entities.Candle, error)
+	GetCandlesBatch(ctx context.Context, titles []string, interval, window time.Duration) (map[string][]entities.Candle, error)
+	GetRecentPrices(ctx context.Context, titles []string, window time.Duration) (map[string][]entities.Coin, error)
+}
@@ -0,0 +1,204 @@
+package grpc
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"Cryptoproject/internal/entities"
+	"Cryptoproject/pkg/api/cryptov1"
+)
+
+var aggregateFunctions = map[cryptov1.AggregateFunction]string{
+	cryptov1.AggregateFunction_AGGREGATE_FUNCTION_AVG: "AVG",
+	cryptov1.AggregateFunction_AGGREGATE_FUNCTION_MAX: "MAX",
+	cryptov1.AggregateFunction_AGGREGATE_FUNCTION_MIN: "MIN",
+}
+
+func (s *Server) GetLastRates(ctx context.Context, req *cryptov1.GetLastRatesRequest) (*cryptov1.GetLastRatesResponse, error) {
+	coins, err := s.coinService.GetLastRates(ctx, req.GetTitles())
+	if err != nil {
+		return nil, s.toStatus(err)
+	}
+
+	resp := &cryptov1.GetLastRatesResponse{Coins: make([]*cryptov1.Coin, 0, len(coins))}
+	for _, coin := range coins {
+		resp.Coins = append(resp.Coins, toCoin(coin))
+	}
+	return resp, nil
+}
+
+func (s *Server) GetAggregate(ctx context.Context, req *cryptov1.GetAggregateRequest) (*cryptov1.GetAggregateResponse, error) {
+	aggFunc, ok := aggregateFunctions[req.GetFunction()]
+	if !ok {
+		return nil, status.Errorf(codes.InvalidArgument, "unsupported aggregate function: %s", req.GetFunction())
+	}
+
+	coins, err := s.coinService.GetRatesWithAgg(ctx, req.GetTitles(), aggFunc)
+	if err != nil {
+		return nil, s.toStatus(err)
+	}
+
+	resp := &cryptov1.GetAggregateResponse{Coins: make([]*cryptov1.AggregateCoin, 0, len(coins))}
+	for _, coin := range coins {
+		resp.Coins = append(resp.Coins, &cryptov1.AggregateCoin{CoinName: coin.CoinName, Price: coin.Price})
+	}
+	return resp, nil
+}
+
+func (s *Server) GetHistory(ctx context.Context, req *cryptov1.GetHistoryRequest) (*cryptov1.GetHistoryResponse, error) {
+	interval, err := entities.ParseCandleInterval(req.GetInterval())
+	if err != nil {
+		return nil, s.toStatus(err)
+	}
+	if req.GetFrom() == nil {
+		return nil, status.Error(codes.InvalidArgument, "from is required")
+	}
+	var to time.Time
+	if req.GetTo() != nil {
+		to = req.GetTo().AsTime()
+	}
+
+	candles, err := s.coinService.GetHistory(ctx, req.GetTitle(), interval, req.GetFrom().AsTime(), to)
+	if err != nil {
+		return nil, s.toStatus(err)
+	}
+
+	resp := &cryptov1.GetHistoryResponse{
+		CoinName: req.GetTitle(),
+		Candles:  make([]*cryptov1.Candle, 0, len(candles)),
+	}
+	for _, candle := range candles {
+		resp.Candles = append(resp.Candles, &cryptov1.Candle{
+			Bucket: timestamppb.New(candle.Bucket),
+			Open:   candle.Open,
+			High:   candle.High,
+			Low:    candle.Low,
+			Close:  candle.Close,
+			Avg:    candle.Avg,
+			Count:  candle.Count,
+		})
+	}
+	return resp, nil
+}
+
+// WatchPrices sends the current prices of the requested coins and then every
+// newer observation until the client cancels or the server stops.
+func (s *Server) WatchPrices(req *cryptov1.WatchPricesRequest, stream cryptov1.CoinService_WatchPricesServer) error {
+	const op = "grpc.WatchPrices"
+	ctx := stream.Context()
+	logger := s.logger.With(
+		slog.String("op", op),
+		slog.Any("titles", req.GetTitles()))
+
+	interval := s.watchInterval
+	if req.GetInterval() != nil {
+		interval = req.GetInterval().AsDuration()
+	}
+	if interval < minWatchInterval {
+		return status.Errorf(codes.InvalidArgument, "interval must be at least %s", minWatchInterval)
+	}
+
+	// каждый поток опрашивает сервис до отмены, поэтому их число ограничено
+	client := s.clientKey(ctx)
+	if !s.watchStreams.acquire(client) {
+		logger.Warn("Too many watch streams", slog.String("client", client))
+		return status.Error(codes.ResourceExhausted, "too many concurrent WatchPrices streams")
+	}
+	defer s.watchStreams.release(client)
+
+	// первая выборка отсекает неизвестные монеты и пустой список до открытия потока
+	coins, err := s.coinService.GetLastRates(ctx, req.GetTitles())
+	if err != nil {
+		return s.toStatus(err)
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	lastObserved := make(map[string]time.Time, len(req.GetTitles()))
+	for {
+		for _, coin := range coins {
+			if !coin.ObservedAt.After(lastObserved[coin.CoinName]) {
+				continue
+			}
+			if err := stream.Send(&cryptov1.WatchPricesResponse{Coin: toCoin(coin)}); err != nil {
+				return err
+			}
+			lastObserved[coin.CoinName] = coin.ObservedAt
+		}
+
+		select {
+		case <-ctx.Done():
+			return status.FromContextError(ctx.Err()).Err()
+		case <-s.shutdown:
+			return status.Error(codes.Unavailable, "server is shutting down")
+		case <-ticker.C:
+		}
+
+		// сбой одной выборки не обрывает подписку: клиент получит цены со следующего тика
+		if coins, err = s.coinService.GetLastRates(ctx, req.GetTitles()); err != nil {
+			logger.Warn("Failed to get last rates", slog.String("error", err.Error()))
+			coins = nil
+		}
+	}
+}
+
+// statusCodes сопоставляет коды ошибок API кодам gRPC
+var statusCodes = map[string]codes.Code{
+	entities.CodeInvalidParam:        codes.InvalidArgument,
+	entities.CodeUnknownSymbol:       codes.NotFound,
+	entities.CodeNotFound:            codes.NotFound,
+	entities.CodeStaleData:           codes.FailedPrecondition,
+	entities.CodeRateLimited:         codes.ResourceExhausted,
+	entities.CodeProviderError:       codes.Unavailable,
+	entities.CodeProviderUnavailable: codes.Unavailable,
+	entities.CodeInternal:            codes.Internal,
+}
+
+func (s *Server) toStatus(err error) error {
+	var (
+		code    codes.Code
+		message string
+	)
+	switch {
+	case errors.Is(err, context.Canceled):
+		code, message = codes.Canceled, context.Canceled.Error()
+	case errors.Is(err, context.DeadlineExceeded):
+		code, message = codes.DeadlineExceeded, context.DeadlineExceeded.Error()
+	default:
+		// внутренние подробности остаются в логе
+		var class entities.ErrorClass
+		class, message = entities.ClassifyError(err)
+		code = statusCodes[class.Code]
+	}
+
+	s.logger.Warn("Rendering error status",
+		slog.String("code", code.String()),
+		slog.String("error", err.Error()))
+	return status.Error(code, message)
+}
+
+func toCoin(coin entities.Coin) *cryptov1.Coin {
+	result := &cryptov1.Coin{
+		CoinName:   coin.CoinName,
+		Price:      coin.Price,
+		Currency:   coin.Currency,
+		Source:     coin.Source,
+		ObservedAt: timestamppb.New(coin.ObservedAt),
+	}
+	if !coin.CreatedAt.IsZero() {
+		result.CreatedAt = timestamppb.New(coin.CreatedAt)
+	}
+	if coin.Stale {
+		result.Stale = true
+		result.Age = durationpb.New(coin.Age)
+	}
+	return result
+}
@@ -0,0 +1,123 @@
+package grpc
+
+import (
+	"context"
+	"log/slog"
+	"math"
+	"net"
+	"strconv"
+	"sync"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"Cryptoproject/internal/ports/ratelimit"
+	"Cryptoproject/pkg/api/cryptov1"
+)
+
+// methodRoutes сопоставляет методы маршрутам HTTP с теми же данными: клиент расходует общий лимит
+var methodRoutes = map[string]string{
+	cryptov1.CoinService_GetLastRates_FullMethodName: ratelimit.RouteCoinsActual,
+	cryptov1.CoinService_WatchPrices_FullMethodName:  ratelimit.RouteCoinsActual,
+	cryptov1.CoinService_GetAggregate_FullMethodName: ratelimit.RouteCoinsAggregate,
+	cryptov1.CoinService_GetHistory_FullMethodName:   ratelimit.RouteCoinsAggregate,
+}
+
+// watchStreams caps concurrent WatchPrices streams in total and per client;
+// zero means no cap.
+type watchStreams struct {
+	max       int
+	perClient int
+
+	mu      sync.Mutex
+	total   int
+	clients map[string]int
+}
+
+func (w *watchStreams) acquire(client string) bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if (w.max > 0 && w.total >= w.max) || (w.perClient > 0 && w.clients[client] >= w.perClient) {
+		return false
+	}
+	w.total++
+	w.clients[client]++
+	return true
+}
+
+func (w *watchStreams) release(client string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.total--
+	if w.clients[client]--; w.clients[client] <= 0 {
+		delete(w.clients, client)
+	}
+}
+
+// clientKey identifies the caller by a known API key from metadata or by peer host.
+func (s *Server) clientKey(ctx context.Context) string {
+	var host string
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		host = p.Addr.String()
+		if h, _, err := net.SplitHostPort(host); err == nil {
+			host = h
+		}
+	}
+	if s.limiter == nil {
+		return "ip:" + host
+	}
+
+	var apiKey string
+	if header := s.limiter.KeyHeader(); header != "" {
+		// ключи метаданных gRPC в нижнем регистре, поиск по имени заголовка регистронезависимый
+		if values := metadata.ValueFromIncomingContext(ctx, header); len(values) > 0 {
+			apiKey = values[0]
+		}
+	}
+	return s.limiter.ClientKey(apiKey, host)
+}
+
+// allow takes a token for method and returns ResourceExhausted with a
+// retry-after header when the client's bucket is empty.
+func (s *Server) allow(ctx context.Context, method string, setHeader func(metadata.MD) error) error {
+	route, ok := methodRoutes[method]
+	if s.limiter == nil || !ok {
+		return nil
+	}
+
+	client := s.clientKey(ctx)
+	allowed, retryAfter := s.limiter.Allow(route, client)
+	if allowed {
+		return nil
+	}
+
+	seconds := int(math.Ceil(retryAfter.Seconds()))
+	s.logger.Warn("Rate limit exceeded",
+		slog.String("method", method),
+		slog.String("route", route),
+		slog.String("client", client),
+		slog.Int("retry_after", seconds))
+
+	_ = setHeader(metadata.Pairs("retry-after", strconv.Itoa(seconds)))
+	return status.Error(codes.ResourceExhausted, "Rate limit exceeded")
+}
+
+func (s *Server) rateLimitUnary(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	setHeader := func(md metadata.MD) error { return grpc.SetHeader(ctx, md) }
+	if err := s.allow(ctx, info.FullMethod, setHeader); err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+func (s *Server) rateLimitStream(srv any, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	if err := s.allow(stream.Context(), info.FullMethod, stream.SetHeader); err != nil {
+		return err
+	}
+	return handler(srv, stream)
+}
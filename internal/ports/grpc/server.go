@@ -0,0 +1,161 @@
+// Package grpc implements gRPC server for cryptocurrency API
+package grpc
+
+import (
+	"context"
+	"log/slog"
+	"net"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
+
+	"Cryptoproject/internal/ports/ratelimit"
+	"Cryptoproject/pkg/api/cryptov1"
+)
+
+const (
+	// minWatchInterval не даёт клиенту превратить WatchPrices в опрос провайдера без пауз
+	minWatchInterval = time.Second
+
+	defaultMaxWatchStreams          = 1000
+	defaultMaxWatchStreamsPerClient = 10
+)
+
+type Server struct {
+	cryptov1.UnimplementedCoinServiceServer
+
+	grpcServer  *grpc.Server
+	addr        string
+	coinService CoinService
+	logger      *slog.Logger
+	// shutdown закрывается при остановке, чтобы бесконечные потоки WatchPrices не держали GracefulStop
+	shutdown chan struct{}
+	stopOnce sync.Once
+
+	watchInterval time.Duration
+	limiter       *ratelimit.Limiter
+	watchStreams  *watchStreams
+}
+
+type ServerOption func(s *Server)
+
+// WithWatchInterval sets how often WatchPrices checks for new prices when the client does not ask for an interval.
+func WithWatchInterval(interval time.Duration) ServerOption {
+	return func(s *Server) {
+		s.watchInterval = interval
+	}
+}
+
+// WithRateLimit limits calls per client with limiter, usually the one shared with the HTTP server.
+func WithRateLimit(limiter *ratelimit.Limiter) ServerOption {
+	return func(s *Server) {
+		s.limiter = limiter
+	}
+}
+
+// WithWatchStreamLimit caps concurrent WatchPrices streams in total and per client; zero disables a cap.
+func WithWatchStreamLimit(total, perClient int) ServerOption {
+	return func(s *Server) {
+		s.watchStreams.max = total
+		s.watchStreams.perClient = perClient
+	}
+}
+
+func NewServer(coinService CoinService, port string, logger *slog.Logger, opts ...ServerOption) *Server {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	logger = logger.With(slog.String("component", "grpc-server"))
+
+	s := &Server{
+		addr:          ":" + port,
+		coinService:   coinService,
+		logger:        logger,
+		shutdown:      make(chan struct{}),
+		watchInterval: time.Minute,
+		watchStreams: &watchStreams{
+			max:       defaultMaxWatchStreams,
+			perClient: defaultMaxWatchStreamsPerClient,
+			clients:   make(map[string]int),
+		},
+	}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	s.grpcServer = grpc.NewServer(
+		grpc.ChainUnaryInterceptor(s.logUnary, s.rateLimitUnary),
+		grpc.ChainStreamInterceptor(s.logStream, s.rateLimitStream),
+	)
+	cryptov1.RegisterCoinServiceServer(s.grpcServer, s)
+	reflection.Register(s.grpcServer)
+
+	logger.Info("Server initialized", slog.String("port", port))
+	return s
+}
+
+func (s *Server) logUnary(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	start := time.Now()
+	resp, err := handler(ctx, req)
+	s.logRequest(ctx, info.FullMethod, start, err)
+	return resp, err
+}
+
+func (s *Server) logStream(srv any, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	start := time.Now()
+	err := handler(srv, stream)
+	s.logRequest(stream.Context(), info.FullMethod, start, err)
+	return err
+}
+
+func (s *Server) logRequest(ctx context.Context, method string, start time.Time, err error) {
+	s.logger.LogAttrs(
+		ctx,
+		slog.LevelInfo,
+		"Request processed",
+		slog.String("method", method),
+		slog.String("code", status.Code(err).String()),
+		slog.Duration("duration", time.Since(start)),
+	)
+}
+
+func (s *Server) Start() error {
+	s.logger.Info("Starting gRPC server",
+		slog.String("address", s.addr))
+	lis, err := net.Listen("tcp", s.addr)
+	if err != nil {
+		return err
+	}
+	return s.Serve(lis)
+}
+
+// Serve accepts connections on lis until Stop is called.
+func (s *Server) Serve(lis net.Listener) error {
+	return s.grpcServer.Serve(lis)
+}
+
+// Stop ends WatchPrices streams, waits for in-flight RPCs to finish and
+// closes remaining connections forcibly once ctx is done.
+func (s *Server) Stop(ctx context.Context) error {
+	s.logger.Info("Shutting down gRPC server")
+	s.stopOnce.Do(func() { close(s.shutdown) })
+
+	stopped := make(chan struct{})
+	go func() {
+		s.grpcServer.GracefulStop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+		return nil
+	case <-ctx.Done():
+		s.grpcServer.Stop()
+		<-stopped
+		return ctx.Err()
+	}
+}
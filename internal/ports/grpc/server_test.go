@@ -0,0 +1,274 @@
+package grpc_test
+
+import (
+	"context"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"Cryptoproject/internal/entities"
+	grpcport "Cryptoproject/internal/ports/grpc"
+	"Cryptoproject/internal/ports/ratelimit"
+	"Cryptoproject/pkg/api/cryptov1"
+)
+
+type stubCoinService struct {
+	mu sync.Mutex
+	// rates — ответы GetLastRates по очереди; последний повторяется
+	rates   [][]entities.Coin
+	candles []entities.Candle
+	aggFunc string
+}
+
+func (s *stubCoinService) GetLastRates(_ context.Context, titles []string) ([]entities.Coin, error) {
+	if len(titles) == 0 {
+		return nil, entities.ErrInvalidParam
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	coins := s.rates[0]
+	if len(s.rates) > 1 {
+		s.rates = s.rates[1:]
+	}
+	return coins, nil
+}
+
+func (s *stubCoinService) GetRatesWithAgg(_ context.Context, _ []string, aggFunc string) ([]entities.Coin, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.aggFunc = aggFunc
+	return []entities.Coin{{CoinName: "BTC", Price: 30000}}, nil
+}
+
+func (s *stubCoinService) GetHistory(_ context.Context, title string, _ time.Duration, _, _ time.Time) ([]entities.Candle, error) {
+	if title != "BTC" {
+		return nil, entities.ErrNotFound
+	}
+	return s.candles, nil
+}
+
+func newClient(t *testing.T, service grpcport.CoinService, opts ...grpcport.ServerOption) (cryptov1.CoinServiceClient, *grpcport.Server) {
+	t.Helper()
+
+	lis := bufconn.Listen(1 << 20)
+	opts = append([]grpcport.ServerOption{grpcport.WithWatchInterval(time.Second)}, opts...)
+	server := grpcport.NewServer(service, "0", nil, opts...)
+	go func() { _ = server.Serve(lis) }()
+	t.Cleanup(func() { _ = server.Stop(context.Background()) })
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+	return cryptov1.NewCoinServiceClient(conn), server
+}
+
+func Test_GetLastRates(t *testing.T) {
+	t.Parallel()
+
+	observedAt := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	client, _ := newClient(t, &stubCoinService{rates: [][]entities.Coin{{
+		{CoinName: "BTC", Price: 30000, Currency: "USD", ObservedAt: observedAt},
+	}}})
+
+	resp, err := client.GetLastRates(context.Background(), &cryptov1.GetLastRatesRequest{Titles: []string{"BTC"}})
+	require.NoError(t, err)
+	require.Len(t, resp.GetCoins(), 1)
+	assert.Equal(t, "BTC", resp.GetCoins()[0].GetCoinName())
+	assert.Equal(t, 30000.0, resp.GetCoins()[0].GetPrice())
+	assert.Equal(t, observedAt, resp.GetCoins()[0].GetObservedAt().AsTime())
+	assert.Nil(t, resp.GetCoins()[0].GetCreatedAt())
+
+	_, err = client.GetLastRates(context.Background(), &cryptov1.GetLastRatesRequest{})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func Test_GetAggregate(t *testing.T) {
+	t.Parallel()
+
+	service := &stubCoinService{}
+	client, _ := newClient(t, service)
+
+	resp, err := client.GetAggregate(context.Background(), &cryptov1.GetAggregateRequest{
+		Titles:   []string{"BTC"},
+		Function: cryptov1.AggregateFunction_AGGREGATE_FUNCTION_MAX,
+	})
+	require.NoError(t, err)
+	require.Len(t, resp.GetCoins(), 1)
+	assert.Equal(t, "MAX", service.aggFunc)
+
+	_, err = client.GetAggregate(context.Background(), &cryptov1.GetAggregateRequest{Titles: []string{"BTC"}})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func Test_GetHistory(t *testing.T) {
+	t.Parallel()
+
+	bucket := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	client, _ := newClient(t, &stubCoinService{candles: []entities.Candle{
+		{Bucket: bucket, Open: 1, High: 3, Low: 1, Close: 2, Avg: 2, Count: 3},
+	}})
+
+	resp, err := client.GetHistory(context.Background(), &cryptov1.GetHistoryRequest{
+		Title: "BTC",
+		From:  timestamppb.New(bucket),
+	})
+	require.NoError(t, err)
+	require.Len(t, resp.GetCandles(), 1)
+	assert.Equal(t, bucket, resp.GetCandles()[0].GetBucket().AsTime())
+	assert.Equal(t, int64(3), resp.GetCandles()[0].GetCount())
+
+	_, err = client.GetHistory(context.Background(), &cryptov1.GetHistoryRequest{Title: "BTC"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = client.GetHistory(context.Background(), &cryptov1.GetHistoryRequest{
+		Title:    "BTC",
+		Interval: "2h",
+		From:     timestamppb.New(bucket),
+	})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = client.GetHistory(context.Background(), &cryptov1.GetHistoryRequest{
+		Title: "DOGE",
+		From:  timestamppb.New(bucket),
+	})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func Test_WatchPrices_SendsNewObservations(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	btc := entities.Coin{CoinName: "BTC", Price: 30000, ObservedAt: start}
+	eth := entities.Coin{CoinName: "ETH", Price: 2000, ObservedAt: start}
+	btcNext := entities.Coin{CoinName: "BTC", Price: 30100, ObservedAt: start.Add(time.Minute)}
+	client, server := newClient(t, &stubCoinService{rates: [][]entities.Coin{
+		{btc, eth},
+		{btcNext, eth},
+	}})
+
+	stream, err := client.WatchPrices(context.Background(), &cryptov1.WatchPricesRequest{
+		Titles:   []string{"BTC", "ETH"},
+		Interval: durationpb.New(time.Second),
+	})
+	require.NoError(t, err)
+
+	var received []string
+	for range 3 {
+		resp, err := stream.Recv()
+		require.NoError(t, err)
+		received = append(received, resp.GetCoin().GetCoinName())
+	}
+	assert.Equal(t, []string{"BTC", "ETH", "BTC"}, received)
+
+	// остановка сервера закрывает подписку, а не ждёт её бесконечно
+	require.NoError(t, server.Stop(context.Background()))
+	_, err = stream.Recv()
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+}
+
+func Test_WatchPrices_Validation(t *testing.T) {
+	t.Parallel()
+
+	client, _ := newClient(t, &stubCoinService{rates: [][]entities.Coin{nil}})
+
+	stream, err := client.WatchPrices(context.Background(), &cryptov1.WatchPricesRequest{
+		Titles:   []string{"BTC"},
+		Interval: durationpb.New(time.Millisecond),
+	})
+	require.NoError(t, err)
+	_, err = stream.Recv()
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	stream, err = client.WatchPrices(context.Background(), &cryptov1.WatchPricesRequest{})
+	require.NoError(t, err)
+	_, err = stream.Recv()
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func Test_RateLimit(t *testing.T) {
+	t.Parallel()
+
+	client, _ := newClient(t, &stubCoinService{rates: [][]entities.Coin{{{CoinName: "BTC", Price: 30000}}}},
+		grpcport.WithRateLimit(ratelimit.New(ratelimit.Config{
+			Default:   ratelimit.Limit{RPS: 0.001, Burst: 1},
+			KeyHeader: "X-API-Key",
+			APIKeys:   []string{"secret"},
+		})))
+	req := &cryptov1.GetLastRatesRequest{Titles: []string{"BTC"}}
+
+	_, err := client.GetLastRates(context.Background(), req)
+	require.NoError(t, err)
+
+	var header metadata.MD
+	_, err = client.GetLastRates(context.Background(), req, grpc.Header(&header))
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+	assert.NotEmpty(t, header.Get("retry-after"))
+
+	// WatchPrices расходует тот же лимит, что и GetLastRates
+	stream, err := client.WatchPrices(context.Background(), &cryptov1.WatchPricesRequest{Titles: []string{"BTC"}})
+	require.NoError(t, err)
+	_, err = stream.Recv()
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+
+	// неизвестный ключ не даёт нового bucket, известный — даёт
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "x-api-key", "random")
+	_, err = client.GetLastRates(ctx, req)
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+
+	ctx = metadata.AppendToOutgoingContext(context.Background(), "x-api-key", "secret")
+	_, err = client.GetLastRates(ctx, req)
+	assert.NoError(t, err)
+
+	// другие маршруты имеют собственные bucket
+	_, err = client.GetAggregate(context.Background(), &cryptov1.GetAggregateRequest{
+		Titles:   []string{"BTC"},
+		Function: cryptov1.AggregateFunction_AGGREGATE_FUNCTION_AVG,
+	})
+	assert.NoError(t, err)
+}
+
+func Test_WatchPrices_StreamLimit(t *testing.T) {
+	t.Parallel()
+
+	client, _ := newClient(t, &stubCoinService{rates: [][]entities.Coin{{{CoinName: "BTC", Price: 30000, ObservedAt: time.Now()}}}},
+		grpcport.WithWatchStreamLimit(0, 1))
+	req := &cryptov1.WatchPricesRequest{Titles: []string{"BTC"}}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	first, err := client.WatchPrices(ctx, req)
+	require.NoError(t, err)
+	_, err = first.Recv()
+	require.NoError(t, err)
+
+	second, err := client.WatchPrices(context.Background(), req)
+	require.NoError(t, err)
+	_, err = second.Recv()
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+
+	// закрытый поток освобождает место
+	cancel()
+	assert.Eventually(t, func() bool {
+		stream, err := client.WatchPrices(context.Background(), req)
+		if err != nil {
+			return false
+		}
+		_, err = stream.Recv()
+		return err == nil
+	}, 5*time.Second, 50*time.Millisecond)
+}
@@ -0,0 +1,14 @@
+package grpc
+
+import (
+	"context"
+	"time"
+
+	"Cryptoproject/internal/entities"
+)
+
+type CoinService interface {
+	GetLastRates(ctx context.Context, titles []string) ([]entities.Coin, error)
+	GetRatesWithAgg(ctx context.Context, titles []string, aggFunc string) ([]entities.Coin, error)
+	GetHistory(ctx context.Context, title string, interval time.Duration, from, to time.Time) ([]entities.Candle, error)
+}
@@ -0,0 +1,36 @@
+package http
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"strings"
+
+	"Cryptoproject/pkg/dto"
+)
+
+// WithAdminToken enables the admin endpoints for requests presenting token as a bearer token.
+func WithAdminToken(token string) ServerOption {
+	return func(s *Server) {
+		s.adminToken = token
+	}
+}
+
+// requireAdmin пропускает только запросы с токеном администратора.
+func (s *Server) requireAdmin(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok || subtle.ConstantTimeCompare([]byte(token), []byte(s.adminToken)) != 1 {
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			s.renderProblem(w, dto.ProblemResponse{
+				Type:     problemType(CodeUnauthorized),
+				Title:    "Unauthorized",
+				Status:   http.StatusUnauthorized,
+				Detail:   "admin token required",
+				Instance: r.URL.Path,
+				Code:     CodeUnauthorized,
+			})
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
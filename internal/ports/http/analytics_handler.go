@@ -0,0 +1,150 @@
+package http
+
+import (
+	"log/slog"
+	"math"
+	"net/http"
+	"time"
+
+	"Cryptoproject/internal/entities"
+	"Cryptoproject/pkg/dto"
+)
+
+// handleGetVolatility godoc
+// @Summary Realised volatility
+// @Description Annualised standard deviation of log returns between adjacent buckets of stored history. Without titles all tracked coins are returned
+// @Tags analytics
+// @Produce json
+// @Param titles query string false "Comma-separated coin symbols" Example("BTC,ETH")
+// @Param window query string false "Window" Enums(7d, 30d, 90d, 365d) default(30d)
+// @Param interval query string false "Bucket size" Enums(5m, 15m, 1h, 4h, 1d) default(1h)
+// @Success 200 {object} dto.VolatilityListResponse
+// @Failure 400 {object} dto.ProblemResponse
+// @Failure 429 {object} dto.ProblemResponse
+// @Failure 500 {object} dto.ProblemResponse
+// @Router /api/v1/analytics/volatility [get]
+func (s *Server) handleGetVolatility(w http.ResponseWriter, r *http.Request) {
+	const op = "http.handleGetVolatility"
+	startTime := time.Now()
+	logger := s.logger.With(slog.String("op", op))
+
+	window, interval, err := parseAnalyticsParams(r)
+	if err != nil {
+		logger.Warn("Validation failed", slog.String("error", err.Error()))
+		s.renderError(w, r, err)
+		return
+	}
+	titles, err := titlesFromQuery(r)
+	if err != nil {
+		logger.Warn("Validation failed", slog.String("error", err.Error()))
+		s.renderError(w, r, err)
+		return
+	}
+
+	volatility, err := s.coinService.GetVolatility(r.Context(), titles, window, interval)
+	if err != nil {
+		logger.Error("Service call failed",
+			slog.String("error", err.Error()),
+			slog.Duration("duration", time.Since(startTime)))
+		s.renderError(w, r, err)
+		return
+	}
+
+	response := dto.VolatilityListResponse{
+		Window:   queryParamOr(r, "window", "30d"),
+		Interval: queryParamOr(r, "interval", "1h"),
+		Coins:    make([]dto.VolatilityResponse, 0, len(volatility)),
+	}
+	for _, coin := range volatility {
+		response.Coins = append(response.Coins, dto.VolatilityResponse{
+			CoinName:   coin.CoinName,
+			Returns:    coin.Returns,
+			Volatility: coin.Volatility,
+		})
+	}
+
+	logger.Info("Request processed successfully",
+		slog.Int("coins_count", len(response.Coins)),
+		slog.Duration("duration", time.Since(startTime)))
+	s.renderResponse(w, http.StatusOK, response)
+}
+
+// handleGetCorrelation godoc
+// @Summary Correlation matrix
+// @Description Pearson correlation of log returns for every pair of coins, aligned on the buckets both coins have. A null entry means the pair has fewer than two common returns or one coin did not move
+// @Tags analytics
+// @Produce json
+// @Param titles query string true "Comma-separated coin symbols, 2 to 20" Example("BTC,ETH,SOL")
+// @Param window query string false "Window" Enums(7d, 30d, 90d, 365d) default(30d)
+// @Param interval query string false "Bucket size" Enums(5m, 15m, 1h, 4h, 1d) default(1h)
+// @Success 200 {object} dto.CorrelationResponse
+// @Failure 400 {object} dto.ProblemResponse
+// @Failure 429 {object} dto.ProblemResponse
+// @Failure 500 {object} dto.ProblemResponse
+// @Router /api/v1/analytics/correlation [get]
+func (s *Server) handleGetCorrelation(w http.ResponseWriter, r *http.Request) {
+	const op = "http.handleGetCorrelation"
+	startTime := time.Now()
+	logger := s.logger.With(slog.String("op", op))
+
+	window, interval, err := parseAnalyticsParams(r)
+	if err != nil {
+		logger.Warn("Validation failed", slog.String("error", err.Error()))
+		s.renderError(w, r, err)
+		return
+	}
+	titles, err := titlesFromQuery(r)
+	if err != nil {
+		logger.Warn("Validation failed", slog.String("error", err.Error()))
+		s.renderError(w, r, err)
+		return
+	}
+
+	correlation, err := s.coinService.GetCorrelation(r.Context(), titles, window, interval)
+	if err != nil {
+		logger.Error("Service call failed",
+			slog.String("error", err.Error()),
+			slog.Duration("duration", time.Since(startTime)))
+		s.renderError(w, r, err)
+		return
+	}
+
+	response := dto.CorrelationResponse{
+		Window:       queryParamOr(r, "window", "30d"),
+		Interval:     queryParamOr(r, "interval", "1h"),
+		Titles:       correlation.Titles,
+		Matrix:       make([][]*float64, len(correlation.Matrix)),
+		Observations: correlation.Observations,
+	}
+	for i, row := range correlation.Matrix {
+		response.Matrix[i] = make([]*float64, len(row))
+		for j, value := range row {
+			// NaN не кодируется в JSON
+			if !math.IsNaN(value) {
+				response.Matrix[i][j] = &value
+			}
+		}
+	}
+
+	logger.Info("Request processed successfully",
+		slog.Int("coins_count", len(response.Titles)),
+		slog.Duration("duration", time.Since(startTime)))
+	s.renderResponse(w, http.StatusOK, response)
+}
+
+func parseAnalyticsParams(r *http.Request) (window, interval time.Duration, err error) {
+	if window, err = entities.ParseAnalyticsWindow(r.URL.Query().Get("window")); err != nil {
+		return 0, 0, err
+	}
+	if interval, err = entities.ParseCandleInterval(r.URL.Query().Get("interval")); err != nil {
+		return 0, 0, err
+	}
+	return window, interval, nil
+}
+
+func queryParamOr(r *http.Request, name, fallback string) string {
+	if value := r.URL.Query().Get(name); value != "" {
+		return value
+	}
+	return fallback
+}
@@ -0,0 +1,28 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_GetCorrelation_UndefinedIsNull(t *testing.T) {
+	t.Parallel()
+
+	server := NewServer(&stubCoinService{}, "0", nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/analytics/correlation?titles=BTC,USDT&window=7d", nil)
+	rec := httptest.NewRecorder()
+	server.router.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"matrix":[[1,null],[null,1]]`)
+	assert.Contains(t, rec.Body.String(), `"window":"7d"`)
+
+	req = httptest.NewRequest(http.MethodGet, "/api/v1/analytics/correlation?titles=BTC,ETH&window=2d", nil)
+	rec = httptest.NewRecorder()
+	server.router.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
@@ -0,0 +1,179 @@
+package http
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"Cryptoproject/internal/entities"
+	"Cryptoproject/pkg/dto"
+)
+
+const (
+	batchTypeLatest    = "latest"
+	batchTypeQuotes    = "quotes"
+	batchTypeAggregate = "aggregate"
+	batchTypeHistory   = "history"
+
+	maxBatchRequests = 20
+	// batchParallelism — сколько запросов пакета выполняются одновременно
+	batchParallelism = 4
+)
+
+// handleBatch godoc
+// @Summary Run several queries at once
+// @Description Runs up to 20 latest, quotes, aggregate and history queries and returns a result per query in request order. Every query is validated, rate limited and fails on its own: the batch itself returns 200 unless the body is malformed. Latest and quotes queries count against the coins_actual limit, the others against coins_aggregate
+// @Tags coins
+// @Accept json
+// @Produce json
+// @Param request body dto.BatchRequest true "Queries to run"
+// @Success 200 {object} dto.BatchResponse
+// @Failure 400 {object} dto.ProblemResponse
+// @Router /api/v1/batch [post]
+func (s *Server) handleBatch(w http.ResponseWriter, r *http.Request) {
+	const op = "http.handleBatch"
+	startTime := time.Now()
+	logger := s.logger.With(slog.String("op", op))
+
+	var request dto.BatchRequest
+	err := decodeJSONBody(w, r, &request)
+	if err == nil {
+		switch {
+		case len(request.Requests) == 0:
+			err = errors.Wrap(entities.ErrInvalidParam, "requests list is empty")
+		case len(request.Requests) > maxBatchRequests:
+			err = errors.Wrapf(entities.ErrInvalidParam, "too many requests: %d (max %d)", len(request.Requests), maxBatchRequests)
+		}
+	}
+	if err != nil {
+		logger.Warn("Validation failed", slog.String("error", err.Error()))
+		s.renderError(w, r, err)
+		return
+	}
+
+	results := make([]dto.BatchResult, len(request.Requests))
+	client := ""
+	if s.limiter != nil {
+		client = s.clientKey(r)
+	}
+
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, batchParallelism)
+	for i, sub := range request.Requests {
+		if sub.ID == "" {
+			sub.ID = strconv.Itoa(i)
+		}
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+			results[i] = s.runBatchRequest(r.Context(), client, sub)
+		}()
+	}
+	wg.Wait()
+
+	var failed int
+	for _, result := range results {
+		if result.Status >= http.StatusBadRequest {
+			failed++
+		}
+	}
+	logger.Info("Batch processed",
+		slog.Int("requests_count", len(results)),
+		slog.Int("failed_count", failed),
+		slog.Duration("duration", time.Since(startTime)))
+
+	s.renderResponse(w, http.StatusOK, dto.BatchResponse{Results: results})
+}
+
+func (s *Server) runBatchRequest(ctx context.Context, client string, sub dto.BatchSubRequest) dto.BatchResult {
+	data, err := s.batchRequestData(ctx, client, sub)
+	if err != nil {
+		problem := newProblem(err, "")
+		s.logger.Warn("Batch request failed",
+			slog.String("id", sub.ID),
+			slog.String("type", sub.Type),
+			slog.Int("status", problem.Status),
+			slog.String("code", problem.Code),
+			slog.String("error", err.Error()))
+		return dto.BatchResult{ID: sub.ID, Status: problem.Status, Code: problem.Code, Error: problem.Detail}
+	}
+	return dto.BatchResult{ID: sub.ID, Status: http.StatusOK, Data: data}
+}
+
+func (s *Server) batchRequestData(ctx context.Context, client string, sub dto.BatchSubRequest) (any, error) {
+	route := routeCoinsAggregate
+	switch sub.Type {
+	case batchTypeLatest, batchTypeQuotes:
+		route = routeCoinsActual
+	case batchTypeAggregate, batchTypeHistory:
+	default:
+		return nil, errors.Wrapf(entities.ErrInvalidParam, "unsupported type: %q (allowed: latest, quotes, aggregate, history)", sub.Type)
+	}
+
+	request, err := validateCoinsRequest(sub.CoinsRequest)
+	if err != nil {
+		return nil, err
+	}
+	if s.limiter != nil {
+		if ok, _ := s.limiter.Allow(route, client); !ok {
+			return nil, errors.Wrapf(entities.ErrRateLimited, "%s rate limit exceeded", route)
+		}
+	}
+
+	switch sub.Type {
+	case batchTypeLatest:
+		return s.actualCoins(ctx, request)
+	case batchTypeQuotes:
+		return s.quotes(ctx, request)
+	case batchTypeAggregate:
+		return s.aggregateCoins(ctx, request)
+	default:
+		return s.historyCandles(ctx, request, sub)
+	}
+}
+
+func (s *Server) historyCandles(ctx context.Context, request dto.CoinsRequest, sub dto.BatchSubRequest) ([]dto.CandleResponse, error) {
+	if len(request.Titles) != 1 {
+		return nil, errors.Wrap(entities.ErrInvalidParam, "history takes exactly one title")
+	}
+	if request.Currency != "" {
+		return nil, errors.Wrap(entities.ErrInvalidParam, "currency is not supported for history")
+	}
+	if sub.From == nil {
+		return nil, errors.Wrap(entities.ErrInvalidParam, "from is required for history")
+	}
+	interval, err := entities.ParseCandleInterval(sub.Interval)
+	if err != nil {
+		return nil, err
+	}
+	var to time.Time
+	if sub.To != nil {
+		to = *sub.To
+	}
+
+	candles, err := s.coinService.GetHistory(ctx, request.Titles[0], interval, *sub.From, to)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get history")
+	}
+
+	response := make([]dto.CandleResponse, 0, len(candles))
+	for _, candle := range candles {
+		response = append(response, dto.CandleResponse{
+			Bucket: candle.Bucket,
+			Open:   candle.Open,
+			High:   candle.High,
+			Low:    candle.Low,
+			Close:  candle.Close,
+			Avg:    candle.Avg,
+			Count:  candle.Count,
+		})
+	}
+	return response, nil
+}
@@ -0,0 +1,115 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"Cryptoproject/internal/entities"
+	"Cryptoproject/internal/ports/ratelimit"
+	"Cryptoproject/pkg/dto"
+)
+
+func Test_GetActualCoins_JSONBody(t *testing.T) {
+	t.Parallel()
+
+	server := NewServer(&stubCoinService{coins: []entities.Coin{
+		{CoinName: "BTC", Price: 28000, Currency: "USD"},
+	}}, "0", nil)
+
+	tests := []struct {
+		name   string
+		target string
+		body   string
+		status int
+	}{
+		{"body", "/api/v1/coins/actual", `{"titles":["btc","BTC"],"currency":"usd"}`, http.StatusOK},
+		{"query fallback", "/api/v1/coins/actual?titles=BTC,ETH", "", http.StatusOK},
+		{"invalid ticker", "/api/v1/coins/actual", `{"titles":["BTC/USD"]}`, http.StatusBadRequest},
+		{"unknown field", "/api/v1/coins/actual", `{"titles":["BTC"],"limit":1}`, http.StatusBadRequest},
+		{"other currency", "/api/v1/coins/actual", `{"titles":["BTC"],"currency":"EUR"}`, http.StatusBadRequest},
+		{"no titles", "/api/v1/coins/actual", `{}`, http.StatusBadRequest},
+		{"agg in body", "/api/v1/coins/aggregate", `{"titles":["BTC"],"agg":"max"}`, http.StatusOK},
+		{"agg mismatch", "/api/v1/coins/aggregate/AVG", `{"titles":["BTC"],"agg":"MAX"}`, http.StatusBadRequest},
+		{"agg missing", "/api/v1/coins/aggregate", `{"titles":["BTC"]}`, http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, tt.target, strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			rec := httptest.NewRecorder()
+			server.router.ServeHTTP(rec, req)
+			assert.Equal(t, tt.status, rec.Code, rec.Body.String())
+		})
+	}
+}
+
+func Test_Batch_IndividualResults(t *testing.T) {
+	t.Parallel()
+
+	bucket := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	server := NewServer(&stubCoinService{
+		coins:   []entities.Coin{{CoinName: "BTC", Price: 28000}},
+		candles: []entities.Candle{{Bucket: bucket, Close: 28000, Count: 60}},
+	}, "0", nil, WithRateLimit(ratelimit.New(ratelimit.Config{
+		Default: ratelimit.Limit{RPS: 100, Burst: 100},
+		Routes:  map[string]ratelimit.Limit{routeCoinsActual: {RPS: 0.001, Burst: 1}},
+	})))
+
+	body := `{"requests":[
+		{"id":"latest","type":"latest","titles":["BTC"]},
+		{"id":"avg","type":"aggregate","titles":["BTC"],"agg":"AVG"},
+		{"id":"bad-agg","type":"aggregate","titles":["BTC"],"agg":"SUM"},
+		{"id":"history","type":"history","titles":["BTC"],"interval":"1h","from":"2025-01-01T00:00:00Z"},
+		{"type":"history","titles":["BTC","ETH"],"from":"2025-01-01T00:00:00Z"},
+		{"id":"limited","type":"latest","titles":["ETH"]}
+	]}`
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/batch", strings.NewReader(body))
+	rec := httptest.NewRecorder()
+	server.router.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+	var response dto.BatchResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+	require.Len(t, response.Results, 6)
+
+	statuses := make(map[string]int, len(response.Results))
+	for _, result := range response.Results {
+		statuses[result.ID] = result.Status
+	}
+	assert.Equal(t, http.StatusOK, statuses["avg"])
+	assert.Equal(t, http.StatusBadRequest, statuses["bad-agg"])
+	assert.Equal(t, http.StatusOK, statuses["history"])
+	assert.Equal(t, http.StatusBadRequest, statuses["4"])
+	// лимит coins_actual пропускает только один из двух latest-запросов
+	assert.ElementsMatch(t, []int{http.StatusOK, http.StatusTooManyRequests}, []int{statuses["latest"], statuses["limited"]})
+	assert.Contains(t, response.Results[3].Data.([]any)[0].(map[string]any)["bucket"], "2025-01-01")
+}
+
+func Test_Batch_Validation(t *testing.T) {
+	t.Parallel()
+
+	server := NewServer(&stubCoinService{}, "0", nil)
+
+	requests := make([]string, maxBatchRequests+1)
+	for i := range requests {
+		requests[i] = `{"type":"latest","titles":["BTC"]}`
+	}
+	for _, body := range []string{
+		``,
+		`{"requests":[]}`,
+		`{"requests":[` + strings.Join(requests, ",") + `]}`,
+		`{"requests":`,
+	} {
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/batch", strings.NewReader(body))
+		rec := httptest.NewRecorder()
+		server.router.ServeHTTP(rec, req)
+		assert.Equal(t, http.StatusBadRequest, rec.Code, body)
+	}
+}
@@ -0,0 +1,89 @@
+package http
+
+import (
+	"fmt"
+	"hash/fnv"
+	"net/http"
+	"strings"
+	"time"
+
+	"Cryptoproject/internal/entities"
+)
+
+// notModified sets validators and Cache-Control for coins and replies 304
+// when the client's copy is still current.
+func (s *Server) notModified(w http.ResponseWriter, r *http.Request, coins []entities.Coin) bool {
+	var newest time.Time
+	stale := false
+	for _, coin := range coins {
+		if coin.CreatedAt.After(newest) {
+			newest = coin.CreatedAt
+		}
+		stale = stale || coin.Stale
+	}
+
+	etag := coinsETag(newest, coins)
+	w.Header().Set("ETag", etag)
+	if stale {
+		// устаревшая цена отдаётся, пока провайдер недоступен: кэшировать её нельзя,
+		// а 304 скрыл бы от клиента флаг stale
+		w.Header().Set("Cache-Control", "no-cache")
+		return false
+	}
+	w.Header().Set("Cache-Control", s.cacheControl(newest))
+	if !newest.IsZero() {
+		w.Header().Set("Last-Modified", newest.UTC().Format(http.TimeFormat))
+	}
+
+	if match := r.Header.Get("If-None-Match"); match != "" {
+		if !etagMatches(match, etag) {
+			return false
+		}
+	} else {
+		since, err := http.ParseTime(r.Header.Get("If-Modified-Since"))
+		if err != nil || newest.IsZero() || newest.Truncate(time.Second).After(since) {
+			return false
+		}
+	}
+
+	w.WriteHeader(http.StatusNotModified)
+	return true
+}
+
+// cacheControl allows caching until the next scheduled refresh is expected.
+func (s *Server) cacheControl(newest time.Time) string {
+	if s.refreshInterval <= 0 || newest.IsZero() {
+		return "no-cache"
+	}
+
+	maxAge := s.refreshInterval - time.Since(newest)
+	if maxAge < 0 {
+		maxAge = 0
+	}
+	if maxAge > s.refreshInterval {
+		maxAge = s.refreshInterval
+	}
+	return fmt.Sprintf("public, max-age=%d", int(maxAge.Seconds()))
+}
+
+func coinsETag(newest time.Time, coins []entities.Coin) string {
+	h := fnv.New64a()
+	for _, coin := range coins {
+		_, _ = h.Write([]byte(coin.CoinName))
+		if coin.Stale {
+			_, _ = h.Write([]byte("~stale"))
+		}
+		_, _ = h.Write([]byte{0})
+	}
+	return fmt.Sprintf(`"%x-%x"`, newest.UnixNano(), h.Sum64())
+}
+
+func etagMatches(header, etag string) bool {
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+		if candidate == "*" || candidate == etag {
+			return true
+		}
+	}
+	return false
+}
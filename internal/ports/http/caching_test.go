@@ -0,0 +1,195 @@
+package http
+
+import (
+	"context"
+	"io"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"Cryptoproject/internal/entities"
+)
+
+type stubCoinService struct {
+	coins   []entities.Coin
+	tickers []entities.Ticker
+	history []entities.HistoryRecord
+	candles []entities.Candle
+	quotes  []entities.Quote
+	// err, если задана, возвращается из GetLastRates
+	err error
+}
+
+func (s *stubCoinService) GetLastRates(_ context.Context, _ []string) ([]entities.Coin, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	return s.coins, nil
+}
+
+func (s *stubCoinService) GetQuotes(_ context.Context, _ []string) ([]entities.Quote, error) {
+	return s.quotes, nil
+}
+
+func (s *stubCoinService) GetRatesWithAgg(_ context.Context, _ []string, _ string) ([]entities.Coin, error) {
+	return s.coins, nil
+}
+
+func (s *stubCoinService) GetHistory(_ context.Context, _ string, _ time.Duration, _, _ time.Time) ([]entities.Candle, error) {
+	return s.candles, nil
+}
+
+func (s *stubCoinService) Convert(_ context.Context, _, _ string, _ float64) (*entities.Conversion, error) {
+	return nil, entities.ErrNotFound
+}
+
+func (s *stubCoinService) GetIndicator(_ context.Context, query entities.IndicatorQuery) (*entities.IndicatorSeries, error) {
+	return &entities.IndicatorSeries{CoinName: query.CoinName, Type: query.Type, Period: query.Period, Interval: query.Interval}, nil
+}
+
+func (s *stubCoinService) GetVolatility(_ context.Context, _ []string, _, _ time.Duration) ([]entities.Volatility, error) {
+	return nil, nil
+}
+
+func (s *stubCoinService) GetCorrelation(_ context.Context, titles []string, _, _ time.Duration) (*entities.CorrelationMatrix, error) {
+	return &entities.CorrelationMatrix{
+		Titles:       titles,
+		Matrix:       [][]float64{{1, math.NaN()}, {math.NaN(), 1}},
+		Observations: [][]int{{3, 0}, {0, 3}},
+	}, nil
+}
+
+func (s *stubCoinService) GetMarketMovers(_ context.Context, window time.Duration, _ int) (*entities.MarketOverview, error) {
+	return &entities.MarketOverview{Window: window}, nil
+}
+
+func (s *stubCoinService) GetTickers(_ context.Context, _ []string, _ entities.TickerSort) ([]entities.Ticker, error) {
+	return s.tickers, nil
+}
+
+func (s *stubCoinService) GetWatchlist(_ context.Context) ([]string, error) {
+	return nil, nil
+}
+
+func (s *stubCoinService) AddToWatchlist(_ context.Context, titles []string) (int, error) {
+	return len(titles), nil
+}
+
+func (s *stubCoinService) RemoveFromWatchlist(_ context.Context, _ []string) (int, error) {
+	return 0, entities.ErrNotFound
+}
+
+func (s *stubCoinService) ActualizeRates(_ context.Context) error {
+	return nil
+}
+
+func (s *stubCoinService) Export(_ context.Context, _ entities.ExportQuery, fn func(entities.HistoryRecord) error) error {
+	for _, record := range s.history {
+		if err := fn(record); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *stubCoinService) Import(_ context.Context, source entities.CoinReader) (*entities.ImportReport, error) {
+	report := &entities.ImportReport{}
+	for {
+		_, err := source.Next()
+		if err == io.EOF {
+			return report, nil
+		}
+		report.Lines++
+		var lineErr *entities.LineError
+		if errors.As(err, &lineErr) {
+			report.Reject(*lineErr)
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		report.Imported++
+	}
+}
+
+func Test_ListCoins_ConditionalGet(t *testing.T) {
+	t.Parallel()
+
+	createdAt := time.Now().Add(-20 * time.Second)
+	server := NewServer(&stubCoinService{coins: []entities.Coin{
+		{CoinName: "BTC", Price: 28000, CreatedAt: createdAt},
+	}}, "0", nil, WithRefreshInterval(time.Minute))
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/coins?titles=BTC", nil)
+	rec := httptest.NewRecorder()
+	server.router.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	etag := rec.Header().Get("ETag")
+	require.NotEmpty(t, etag)
+	assert.Equal(t, createdAt.UTC().Format(http.TimeFormat), rec.Header().Get("Last-Modified"))
+	assert.Regexp(t, `^public, max-age=(39|40)$`, rec.Header().Get("Cache-Control"))
+
+	req = httptest.NewRequest(http.MethodGet, "/api/v1/coins?titles=BTC", nil)
+	req.Header.Set("If-None-Match", etag)
+	rec = httptest.NewRecorder()
+	server.router.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusNotModified, rec.Code)
+	assert.Empty(t, rec.Body.String())
+
+	req = httptest.NewRequest(http.MethodGet, "/api/v1/coins/BTC", nil)
+	req.Header.Set("If-Modified-Since", time.Now().UTC().Format(http.TimeFormat))
+	rec = httptest.NewRecorder()
+	server.router.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusNotModified, rec.Code)
+
+	req = httptest.NewRequest(http.MethodGet, "/api/v1/coins/BTC", nil)
+	req.Header.Set("If-None-Match", `"other"`)
+	rec = httptest.NewRecorder()
+	server.router.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"coin_name":"BTC"`)
+}
+
+func Test_GetCoin_StaleIsNotCached(t *testing.T) {
+	t.Parallel()
+
+	createdAt := time.Now().Add(-20 * time.Second)
+	server := NewServer(&stubCoinService{coins: []entities.Coin{
+		{CoinName: "BTC", Price: 28000, CreatedAt: createdAt, Stale: true, Age: 90 * time.Second},
+	}}, "0", nil, WithRefreshInterval(time.Minute))
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/coins/BTC", nil)
+	req.Header.Set("If-Modified-Since", time.Now().UTC().Format(http.TimeFormat))
+	rec := httptest.NewRecorder()
+	server.router.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "no-cache", rec.Header().Get("Cache-Control"))
+	assert.Contains(t, rec.Body.String(), `"stale":true`)
+	assert.Contains(t, rec.Body.String(), `"age_seconds":90`)
+}
+
+func Test_GetCoin_ExposesObservedAndIngestedTime(t *testing.T) {
+	t.Parallel()
+
+	observedAt := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	server := NewServer(&stubCoinService{coins: []entities.Coin{
+		{CoinName: "BTC", Price: 28000, Source: "cryptocompare", ObservedAt: observedAt, CreatedAt: observedAt.Add(3 * time.Second)},
+	}}, "0", nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/coins/BTC", nil)
+	rec := httptest.NewRecorder()
+	server.router.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"source":"cryptocompare"`)
+	assert.Contains(t, rec.Body.String(), `"observed_at":"2025-01-01T12:00:00Z"`)
+	assert.Contains(t, rec.Body.String(), `"ingested_at":"2025-01-01T12:00:03Z"`)
+}
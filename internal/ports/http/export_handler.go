@@ -0,0 +1,179 @@
+package http
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"Cryptoproject/internal/adapters/export"
+	"Cryptoproject/internal/entities"
+)
+
+const exportDateLayout = "2006-01-02"
+
+// handleExport godoc
+// @Summary Export price history
+// @Description Streams stored price history as CSV, NDJSON or Parquet. Periods already downsampled by retention are exported as hourly or daily aggregates, see the resolution column. CSV and NDJSON are gzip-compressed when the client accepts it
+// @Tags export
+// @Produce text/csv
+// @Produce application/x-ndjson
+// @Produce application/vnd.apache.parquet
+// @Param titles query string false "Comma-separated coin symbols, all tracked coins by default" Example("BTC,ETH")
+// @Param from query string true "Range start, YYYY-MM-DD or RFC 3339" Example("2025-01-01")
+// @Param to query string false "Range end, exclusive; defaults to now" Example("2025-02-01")
+// @Param format query string false "Output format: csv, ndjson or parquet" default(csv)
+// @Success 200 {file} file
+// @Failure 400 {object} dto.ProblemResponse
+// @Failure 429 {object} dto.ProblemResponse
+// @Failure 500 {object} dto.ProblemResponse
+// @Router /api/v1/export [get]
+func (s *Server) handleExport(w http.ResponseWriter, r *http.Request) {
+	const op = "http.handleExport"
+	startTime := time.Now()
+	logger := s.logger.With(slog.String("op", op))
+	w.Header().Set("Vary", "Accept-Encoding")
+
+	query, format, err := parseExportParams(r)
+	if err != nil {
+		logger.Warn("Validation failed", slog.String("error", err.Error()))
+		s.renderError(w, r, err)
+		return
+	}
+	gzipped := !export.Compressed(format) && acceptsGzip(r.Header.Values("Accept-Encoding"))
+
+	// заголовки отправляются с первой строкой: до неё ошибку ещё можно вернуть обычным ответом
+	var (
+		out      export.Writer
+		gzWriter *gzip.Writer
+		started  bool
+		rows     int
+	)
+	start := func() error {
+		w.Header().Set("Content-Type", export.ContentType(format))
+		w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="history_%s_%s.%s"`,
+			query.From.UTC().Format(exportDateLayout), query.To.UTC().Format(exportDateLayout), format))
+
+		var dst io.Writer = w
+		if gzipped {
+			w.Header().Set("Content-Encoding", "gzip")
+			gzWriter = gzip.NewWriter(w)
+			dst = gzWriter
+		}
+		w.WriteHeader(http.StatusOK)
+		started = true
+
+		out, err = export.NewWriter(dst, format)
+		return err
+	}
+
+	err = s.coinService.Export(r.Context(), query, func(record entities.HistoryRecord) error {
+		if !started {
+			if err := start(); err != nil {
+				return err
+			}
+		}
+		rows++
+		return out.Write(record)
+	})
+	if err == nil && !started {
+		err = start()
+	}
+	if err == nil {
+		err = out.Close()
+	}
+	if err == nil && gzWriter != nil {
+		err = gzWriter.Close()
+	}
+	if err != nil {
+		logger.Error("Export failed",
+			slog.String("error", err.Error()),
+			slog.Int("rows", rows),
+			slog.Duration("duration", time.Since(startTime)))
+		if !started {
+			s.renderError(w, r, err)
+			return
+		}
+		// ответ уже начат: обрываем соединение, чтобы клиент не принял неполный файл за целый
+		panic(http.ErrAbortHandler)
+	}
+
+	logger.Info("Export completed",
+		slog.Int("rows", rows),
+		slog.String("format", string(format)),
+		slog.Bool("gzip", gzipped),
+		slog.Duration("duration", time.Since(startTime)))
+}
+
+// acceptsGzip reports whether the Accept-Encoding values allow gzip: listed
+// directly or through "*" with a non-zero q-value, e.g. "gzip;q=0" refuses it.
+func acceptsGzip(values []string) bool {
+	gzipQ, anyQ := -1.0, -1.0
+	for _, value := range values {
+		for _, item := range strings.Split(value, ",") {
+			coding, params, _ := strings.Cut(item, ";")
+			q := 1.0
+			for _, param := range strings.Split(params, ";") {
+				name, val, ok := strings.Cut(strings.TrimSpace(param), "=")
+				if !ok || !strings.EqualFold(strings.TrimSpace(name), "q") {
+					continue
+				}
+				parsed, err := strconv.ParseFloat(strings.TrimSpace(val), 64)
+				if err != nil {
+					parsed = 0
+				}
+				q = parsed
+			}
+
+			switch strings.ToLower(strings.TrimSpace(coding)) {
+			case "gzip", "x-gzip":
+				gzipQ = max(gzipQ, q)
+			case "*":
+				anyQ = max(anyQ, q)
+			}
+		}
+	}
+	if gzipQ >= 0 {
+		return gzipQ > 0
+	}
+	return anyQ > 0
+}
+
+func parseExportParams(r *http.Request) (entities.ExportQuery, entities.ExportFormat, error) {
+	format, err := entities.ParseExportFormat(r.URL.Query().Get("format"))
+	if err != nil {
+		return entities.ExportQuery{}, "", err
+	}
+
+	titles, err := titlesFromQuery(r)
+	if err != nil {
+		return entities.ExportQuery{}, "", err
+	}
+	query := entities.ExportQuery{Titles: titles, To: time.Now().UTC()}
+	fromParam := r.URL.Query().Get("from")
+	if fromParam == "" {
+		return entities.ExportQuery{}, "", errors.Wrap(entities.ErrInvalidParam, "from parameter is required")
+	}
+	if query.From, err = parseExportTime(fromParam); err != nil {
+		return entities.ExportQuery{}, "", errors.Wrapf(entities.ErrInvalidParam, "invalid from: %q", fromParam)
+	}
+	if toParam := r.URL.Query().Get("to"); toParam != "" {
+		if query.To, err = parseExportTime(toParam); err != nil {
+			return entities.ExportQuery{}, "", errors.Wrapf(entities.ErrInvalidParam, "invalid to: %q", toParam)
+		}
+	}
+	return query, format, nil
+}
+
+func parseExportTime(value string) (time.Time, error) {
+	if t, err := time.Parse(exportDateLayout, value); err == nil {
+		return t, nil
+	}
+	return time.Parse(time.RFC3339, value)
+}
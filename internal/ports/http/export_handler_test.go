@@ -0,0 +1,76 @@
+package http
+
+import (
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"Cryptoproject/internal/entities"
+)
+
+func Test_Export_GzipCSV(t *testing.T) {
+	t.Parallel()
+
+	server := NewServer(&stubCoinService{history: []entities.HistoryRecord{
+		{CoinName: "BTC", Time: time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC), Resolution: "raw",
+			Open: 94000, High: 94000, Low: 94000, Close: 94000, Avg: 94000, Count: 1},
+	}}, "0", nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/export?titles=BTC&from=2025-01-01&to=2025-01-02", nil)
+	req.Header.Set("Accept-Encoding", "gzip")
+	rec := httptest.NewRecorder()
+	server.router.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "gzip", rec.Header().Get("Content-Encoding"))
+	assert.Equal(t, "Accept-Encoding", rec.Header().Get("Vary"))
+	assert.Equal(t, `attachment; filename="history_2025-01-01_2025-01-02.csv"`, rec.Header().Get("Content-Disposition"))
+	body, err := gzip.NewReader(rec.Body)
+	require.NoError(t, err)
+	content, err := io.ReadAll(body)
+	require.NoError(t, err)
+	assert.Equal(t, "coin_name,time,resolution,open,high,low,close,avg,count\n"+
+		"BTC,2025-01-01T12:00:00Z,raw,94000,94000,94000,94000,94000,1\n", string(content))
+
+	req = httptest.NewRequest(http.MethodGet, "/api/v1/export?from=2025-01-01&format=ndjson", nil)
+	req.Header.Set("Accept-Encoding", "gzip;q=0, identity")
+	rec = httptest.NewRecorder()
+	server.router.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Empty(t, rec.Header().Get("Content-Encoding"))
+	assert.Equal(t, "application/x-ndjson", rec.Header().Get("Content-Type"))
+	assert.Contains(t, rec.Body.String(), `"coin_name":"BTC"`)
+
+	req = httptest.NewRequest(http.MethodGet, "/api/v1/export?format=csv", nil)
+	rec = httptest.NewRecorder()
+	server.router.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Equal(t, "Accept-Encoding", rec.Header().Get("Vary"))
+}
+
+func Test_AcceptsGzip(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		header string
+		want   bool
+	}{
+		{header: "", want: false},
+		{header: "gzip", want: true},
+		{header: "deflate, GZIP;q=0.5", want: true},
+		{header: "gzip;q=0", want: false},
+		{header: "gzip; q=0.000, *", want: false},
+		{header: "br, *;q=0.1", want: true},
+		{header: "*;q=0", want: false},
+		{header: "identity", want: false},
+	}
+	for _, tc := range testCases {
+		assert.Equal(t, tc.want, acceptsGzip([]string{tc.header}), tc.header)
+	}
+}
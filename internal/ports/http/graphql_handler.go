@@ -0,0 +1,19 @@
+package http
+
+import (
+	"net/http"
+)
+
+// handleGraphQL godoc
+// @Summary GraphQL query
+// @Description Runs a GraphQL query over coins: latest price, aggregates, summaries, recent prices and candles. Fields of all coins in one list are loaded with a single storage query per field. Errors are returned in the errors array with extensions.code
+// @Tags graphql
+// @Accept json
+// @Produce json
+// @Param request body dto.GraphQLRequest true "GraphQL query"
+// @Success 200 {object} dto.GraphQLResponse
+// @Failure 429 {object} dto.ProblemResponse
+// @Router /api/v1/graphql [post]
+func (s *Server) handleGraphQL(w http.ResponseWriter, r *http.Request) {
+	s.graphql.ServeHTTP(w, r)
+}
@@ -1,9 +1,11 @@
 package http
 
 import (
+	"context"
 	"encoding/json"
 	"log/slog"
 	"net/http"
+	"strconv"
 	"strings"
 	"time"
 
@@ -38,41 +40,35 @@ func (s *Server) renderError(w http.ResponseWriter, r *http.Request, err error)
 		slog.String("path", r.URL.Path),
 	)
 
-	type errorResponse struct {
-		Error string `json:"error"`
-		Code  int    `json:"code"`
-	}
+	problem := newProblem(err, r.URL.Path)
 
-	var status int
-	switch {
-	case errors.Is(err, entities.ErrInvalidParam):
-		status = http.StatusBadRequest
-	case errors.Is(err, entities.ErrNotFound):
-		status = http.StatusNotFound
-	default:
-		status = http.StatusInternalServerError
+	// полный текст ошибки остаётся в логах, клиенту уходит только detail
+	level := slog.LevelWarn
+	if problem.Status >= http.StatusInternalServerError {
+		level = slog.LevelError
 	}
-
-	logger.Warn("Rendering error response",
-		slog.Int("status", status),
+	logger.Log(r.Context(), level, "Rendering error response",
+		slog.Int("status", problem.Status),
+		slog.String("code", problem.Code),
 		slog.String("error", err.Error()))
 
-	s.renderResponse(w, status, errorResponse{
-		Error: err.Error(),
-		Code:  status,
-	})
+	s.renderProblem(w, problem)
 }
 
 // handleGetActualCoins godoc
 // @Summary Get latest coin prices
-// @Description Returns latest prices for requested coins. Tickers must be comma-separated without spaces (e.g. "BTC,ETH")
+// @Description Returns latest prices for requested coins. Titles are read from the JSON body, or from the titles query parameter when the body has none. At most 100 titles of latin letters and digits; duplicates are ignored. Coins the provider does not list are left out. When the provider fails, the last stored prices are returned with stale set and their age, as long as every coin has one within the configured limit; otherwise the whole request fails. Use /coins/quotes for a per-coin status
 // @Tags coins
 // @Accept json
 // @Produce json
-// @Param titles query string true "Comma-separated list of coin titles without spaces" Example("BTC,ETH")
+// @Param request body dto.CoinsRequest false "Coins to query"
+// @Param titles query string false "Comma-separated list of coin titles, used when the body has no titles" Example("BTC,ETH")
 // @Success 200 {array} dto.CoinResponse
-// @Failure 400 {object} dto.ErrorResponseDto
-// @Failure 500 {object} dto.ErrorResponseDto
+// @Failure 400 {object} dto.ProblemResponse
+// @Failure 429 {object} dto.ProblemResponse
+// @Failure 500 {object} dto.ProblemResponse
+// @Failure 502 {object} dto.ProblemResponse
+// @Failure 503 {object} dto.ProblemResponse
 // @Router /api/v1/coins/actual [post]
 func (s *Server) handleGetActualCoins(w http.ResponseWriter, r *http.Request) {
 	const op = "http.handleGetActualCoins"
@@ -83,62 +79,62 @@ func (s *Server) handleGetActualCoins(w http.ResponseWriter, r *http.Request) {
 		slog.String("path", r.URL.Path),
 	)
 
-	titlesParam := r.URL.Query().Get("titles")
-	if titlesParam == "" {
-		err := errors.Wrap(entities.ErrInvalidParam, "titles parameter is required")
-		logger.Warn("Validation failed", slog.String("error", err.Error()))
-		s.renderError(w, r, err)
-		return
-	}
-
-	titlesParam = strings.ReplaceAll(titlesParam, " ", "")
-	titles := strings.Split(titlesParam, ",")
-	logger = logger.With(slog.Int("titles_count", len(titles)))
-
-	if len(titles) == 0 {
-		err := errors.Wrap(entities.ErrInvalidParam, "empty titles list")
+	request, err := decodeCoinsRequest(w, r)
+	if err != nil {
 		logger.Warn("Validation failed", slog.String("error", err.Error()))
 		s.renderError(w, r, err)
 		return
 	}
+	logger = logger.With(slog.Int("titles_count", len(request.Titles)))
 
-	logger.Debug("Processing request", slog.Any("titles", titles))
-	coins, err := s.coinService.GetLastRates(r.Context(), titles)
+	logger.Debug("Processing request", slog.Any("titles", request.Titles))
+	response, err := s.actualCoins(r.Context(), request)
 	if err != nil {
 		logger.Error("Service call failed",
 			slog.String("error", err.Error()),
 			slog.Duration("duration", time.Since(startTime)))
-		s.renderError(w, r, errors.Wrap(err, "failed to get actual coins"))
+		s.renderError(w, r, err)
 		return
 	}
-	//convert coins to response dto
-	response := make([]dto.CoinResponse, 0, len(coins))
-	for _, coin := range coins {
-		response = append(response, dto.CoinResponse{
-			CoinName:  coin.CoinName,
-			Price:     coin.Price,
-			CreatedAt: coin.CreatedAt,
-		})
-	}
 
 	logger.Info("Request processed successfully",
 		slog.Int("coins_count", len(response)),
 		slog.Duration("duration", time.Since(startTime)))
 
-	s.renderResponse(w, http.StatusOK, coins)
+	s.renderResponse(w, http.StatusOK, response)
+}
+
+func (s *Server) actualCoins(ctx context.Context, request dto.CoinsRequest) ([]dto.CoinResponse, error) {
+	coins, err := s.coinService.GetLastRates(ctx, request.Titles)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get actual coins")
+	}
+	if err = checkCurrency(coins, request.Currency); err != nil {
+		return nil, err
+	}
+
+	response := make([]dto.CoinResponse, 0, len(coins))
+	for _, coin := range coins {
+		response = append(response, coinResponse(coin))
+	}
+	return response, nil
 }
 
 // handleGetAggregateCoins godoc
 // @Summary Get aggregated coin data
-// @Description Returns aggregated data (AVG/MAX/MIN) for requested coins
+// @Description Returns aggregated data (AVG/MAX/MIN) for requested coins. The function is taken from the path or from agg in the body; when both are given they must match. Titles are read as for /coins/actual
 // @Tags coins
 // @Accept json
 // @Produce json
 // @Param aggFunc path string true "Aggregation function (AVG, MAX, MIN)" Enums(AVG, MAX, MIN)
-// @Param titles query string true "Comma-separated list of coin titles" Example("BTC,ETH")
+// @Param request body dto.CoinsRequest false "Coins to query"
+// @Param titles query string false "Comma-separated list of coin titles, used when the body has no titles" Example("BTC,ETH")
 // @Success 200 {array} dto.AggregateCoinResponse
-// @Failure 400 {object} dto.ErrorResponseDto
-// @Failure 500 {object} dto.ErrorResponseDto
+// @Failure 400 {object} dto.ProblemResponse
+// @Failure 429 {object} dto.ProblemResponse
+// @Failure 500 {object} dto.ProblemResponse
+// @Failure 502 {object} dto.ProblemResponse
+// @Failure 503 {object} dto.ProblemResponse
 // @Router /api/v1/coins/aggregate/{aggFunc} [post]
 func (s *Server) handleGetAggregateCoins(w http.ResponseWriter, r *http.Request) {
 	const op = "http.handleGetAggregateCoins"
@@ -149,64 +145,283 @@ func (s *Server) handleGetAggregateCoins(w http.ResponseWriter, r *http.Request)
 		slog.String("path", r.URL.Path),
 	)
 
-	aggFunc := chi.URLParam(r, "aggFunc")
-	logger = logger.With(slog.String("agg_func", aggFunc))
+	request, err := decodeCoinsRequest(w, r)
+	if err != nil {
+		logger.Warn("Validation failed", slog.String("error", err.Error()))
+		s.renderError(w, r, err)
+		return
+	}
+	if aggFunc := strings.ToUpper(chi.URLParam(r, "aggFunc")); aggFunc != "" {
+		if request.Agg != "" && request.Agg != aggFunc {
+			err = errors.Wrapf(entities.ErrInvalidParam, "agg %s in body does not match %s in path", request.Agg, aggFunc)
+			logger.Warn("Validation failed", slog.String("error", err.Error()))
+			s.renderError(w, r, err)
+			return
+		}
+		request.Agg = aggFunc
+	}
+	logger = logger.With(
+		slog.String("agg_func", request.Agg),
+		slog.Int("titles_count", len(request.Titles)))
 
-	validAggFuncs := map[string]struct{}{
-		AggFuncAVG: {},
-		AggFuncMAX: {},
-		AggFuncMin: {},
+	logger.Debug("Processing aggregation request", slog.Any("titles", request.Titles))
+	response, err := s.aggregateCoins(r.Context(), request)
+	if err != nil {
+		logger.Error("Service call failed",
+			slog.String("error", err.Error()),
+			slog.Duration("duration", time.Since(startTime)))
+		s.renderError(w, r, err)
+		return
 	}
 
-	if _, ok := validAggFuncs[aggFunc]; !ok {
-		err := errors.Wrapf(entities.ErrInvalidParam, "invalid agg func: %s", aggFunc)
+	logger.Info("Aggregation request processed",
+		slog.Int("coins_count", len(response)),
+		slog.Duration("duration", time.Since(startTime)))
+
+	s.renderResponse(w, http.StatusOK, response)
+}
+
+// handleAggregateCoins godoc
+// @Summary Get aggregated coin data
+// @Description Same as /coins/aggregate/{aggFunc} with the function taken from agg in the body
+// @Tags coins
+// @Accept json
+// @Produce json
+// @Param request body dto.CoinsRequest true "Coins and aggregation function"
+// @Success 200 {array} dto.AggregateCoinResponse
+// @Failure 400 {object} dto.ProblemResponse
+// @Failure 429 {object} dto.ProblemResponse
+// @Failure 500 {object} dto.ProblemResponse
+// @Failure 502 {object} dto.ProblemResponse
+// @Failure 503 {object} dto.ProblemResponse
+// @Router /api/v1/coins/aggregate [post]
+func (s *Server) handleAggregateCoins(w http.ResponseWriter, r *http.Request) {
+	s.handleGetAggregateCoins(w, r)
+}
+
+func (s *Server) aggregateCoins(ctx context.Context, request dto.CoinsRequest) ([]dto.AggregateCoinResponse, error) {
+	switch request.Agg {
+	case AggFuncAVG, AggFuncMAX, AggFuncMin:
+	default:
+		return nil, errors.Wrapf(entities.ErrInvalidParam, "invalid agg func: %q", request.Agg)
+	}
+
+	aggregateData, err := s.coinService.GetRatesWithAgg(ctx, request.Titles, request.Agg)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get aggregate data")
+	}
+	if err = checkCurrency(aggregateData, request.Currency); err != nil {
+		return nil, err
+	}
+
+	response := make([]dto.AggregateCoinResponse, 0, len(aggregateData))
+	for _, data := range aggregateData {
+		response = append(response, dto.AggregateCoinResponse{
+			CoinName: data.CoinName,
+			Price:    data.Price,
+		})
+	}
+	return response, nil
+}
+
+// handleListCoins godoc
+// @Summary Get latest coin prices
+// @Description Cacheable equivalent of POST /coins/actual. Supports ETag/If-None-Match and Last-Modified/If-Modified-Since
+// @Tags coins
+// @Produce json
+// @Param titles query string true "Comma-separated list of coin titles" Example("BTC,ETH")
+// @Success 200 {array} dto.CoinResponse
+// @Success 304 "Not modified"
+// @Failure 400 {object} dto.ProblemResponse
+// @Failure 429 {object} dto.ProblemResponse
+// @Failure 500 {object} dto.ProblemResponse
+// @Failure 502 {object} dto.ProblemResponse
+// @Failure 503 {object} dto.ProblemResponse
+// @Router /api/v1/coins [get]
+func (s *Server) handleListCoins(w http.ResponseWriter, r *http.Request) {
+	const op = "http.handleListCoins"
+	startTime := time.Now()
+	logger := s.logger.With(
+		slog.String("op", op),
+		slog.String("method", r.Method),
+		slog.String("path", r.URL.Path),
+	)
+
+	titles, err := titlesFromQuery(r)
+	if err == nil && titles == nil {
+		err = errors.Wrap(entities.ErrInvalidParam, "titles parameter is required")
+	}
+	if err != nil {
 		logger.Warn("Validation failed", slog.String("error", err.Error()))
 		s.renderError(w, r, err)
 		return
 	}
 
-	titlesParam := r.URL.Query().Get("titles")
-	if titlesParam == "" {
-		err := errors.Wrap(entities.ErrInvalidParam, "titles parameter is required")
+	coins, err := s.coinService.GetLastRates(r.Context(), titles)
+	if err != nil {
+		logger.Error("Service call failed",
+			slog.String("error", err.Error()),
+			slog.Duration("duration", time.Since(startTime)))
+		s.renderError(w, r, errors.Wrap(err, "failed to get actual coins"))
+		return
+	}
+
+	if s.notModified(w, r, coins) {
+		logger.Debug("Not modified", slog.Duration("duration", time.Since(startTime)))
+		return
+	}
+
+	response := make([]dto.CoinResponse, 0, len(coins))
+	for _, coin := range coins {
+		response = append(response, coinResponse(coin))
+	}
+
+	logger.Info("Request processed successfully",
+		slog.Int("coins_count", len(response)),
+		slog.Duration("duration", time.Since(startTime)))
+
+	s.renderResponse(w, http.StatusOK, response)
+}
+
+// handleGetCoin godoc
+// @Summary Get latest price of a single coin
+// @Description Supports ETag/If-None-Match and Last-Modified/If-Modified-Since
+// @Tags coins
+// @Produce json
+// @Param title path string true "Coin title" Example("BTC")
+// @Success 200 {object} dto.CoinResponse
+// @Success 304 "Not modified"
+// @Failure 404 {object} dto.ProblemResponse
+// @Failure 429 {object} dto.ProblemResponse
+// @Failure 500 {object} dto.ProblemResponse
+// @Failure 502 {object} dto.ProblemResponse
+// @Failure 503 {object} dto.ProblemResponse
+// @Router /api/v1/coins/{title} [get]
+func (s *Server) handleGetCoin(w http.ResponseWriter, r *http.Request) {
+	const op = "http.handleGetCoin"
+	startTime := time.Now()
+	title := strings.TrimSpace(chi.URLParam(r, "title"))
+	logger := s.logger.With(
+		slog.String("op", op),
+		slog.String("method", r.Method),
+		slog.String("path", r.URL.Path),
+		slog.String("title", title),
+	)
+
+	if title == "" {
+		err := errors.Wrap(entities.ErrInvalidParam, "title is required")
 		logger.Warn("Validation failed", slog.String("error", err.Error()))
 		s.renderError(w, r, err)
 		return
 	}
 
-	titlesParam = strings.ReplaceAll(titlesParam, " ", "")
+	coins, err := s.coinService.GetLastRates(r.Context(), []string{title})
+	if err != nil {
+		logger.Error("Service call failed",
+			slog.String("error", err.Error()),
+			slog.Duration("duration", time.Since(startTime)))
+		s.renderError(w, r, errors.Wrap(err, "failed to get actual coin"))
+		return
+	}
+	if len(coins) == 0 {
+		s.renderError(w, r, errors.Wrapf(entities.ErrNotFound, "coin %s not found", title))
+		return
+	}
+
+	if s.notModified(w, r, coins[:1]) {
+		logger.Debug("Not modified", slog.Duration("duration", time.Since(startTime)))
+		return
+	}
+
+	logger.Info("Request processed successfully",
+		slog.Duration("duration", time.Since(startTime)))
+
+	s.renderResponse(w, http.StatusOK, coinResponse(coins[0]))
+}
+
+func coinResponse(coin entities.Coin) dto.CoinResponse {
+	return dto.CoinResponse{
+		CoinName:   coin.CoinName,
+		Price:      coin.Price,
+		Currency:   coin.Currency,
+		Source:     coin.Source,
+		ObservedAt: coin.ObservedAt,
+		IngestedAt: coin.CreatedAt,
+		CreatedAt:  coin.CreatedAt,
+		Stale:      coin.Stale,
+		AgeSeconds: coin.Age.Seconds(),
+	}
+}
 
-	titles := strings.Split(titlesParam, ",")
-	logger = logger.With(slog.Int("titles_count", len(titles)))
+// titlesFromQuery reads, upper-cases and validates the comma separated
+// titles query parameter. It returns nil when the parameter is absent.
+func titlesFromQuery(r *http.Request) ([]string, error) {
+	param := r.URL.Query().Get("titles")
+	if strings.TrimSpace(param) == "" {
+		return nil, nil
+	}
+	return entities.NormalizeTitles(strings.Split(param, ","))
+}
 
-	if len(titles) == 0 {
-		err := errors.Wrap(entities.ErrInvalidParam, "at least one title required")
+// handleConvert godoc
+// @Summary Convert an amount between currencies
+// @Description Converts using the latest stored prices of both legs, triangulating through the quote currency (USD). Refuses when a leg is older than the staleness limit. from_price_at and to_price_at are the provider observation times of the legs
+// @Tags convert
+// @Produce json
+// @Param from query string true "Source currency" Example("ETH")
+// @Param to query string true "Target currency" Example("BTC")
+// @Param amount query number true "Amount of source currency" Example(2.5)
+// @Success 200 {object} dto.ConversionResponse
+// @Failure 400 {object} dto.ProblemResponse
+// @Failure 404 {object} dto.ProblemResponse
+// @Failure 422 {object} dto.ProblemResponse
+// @Failure 500 {object} dto.ProblemResponse
+// @Router /api/v1/convert [get]
+func (s *Server) handleConvert(w http.ResponseWriter, r *http.Request) {
+	const op = "http.handleConvert"
+	startTime := time.Now()
+	logger := s.logger.With(
+		slog.String("op", op),
+		slog.String("method", r.Method),
+		slog.String("path", r.URL.Path),
+	)
+
+	query := r.URL.Query()
+	amount, err := strconv.ParseFloat(query.Get("amount"), 64)
+	if err != nil {
+		err = errors.Wrapf(entities.ErrInvalidParam, "invalid amount: %q", query.Get("amount"))
 		logger.Warn("Validation failed", slog.String("error", err.Error()))
 		s.renderError(w, r, err)
+		return
 	}
 
-	logger.Debug("Processing aggregation request",
-		slog.Any("titles", titles),
-		slog.String("agg_func", aggFunc))
-
-	aggregateData, err := s.coinService.GetRatesWithAgg(r.Context(), titles, aggFunc)
+	conversion, err := s.coinService.Convert(r.Context(), query.Get("from"), query.Get("to"), amount)
 	if err != nil {
 		logger.Error("Service call failed",
 			slog.String("error", err.Error()),
 			slog.Duration("duration", time.Since(startTime)))
-		s.renderError(w, r, errors.Wrap(err, "failed to get aggregate data"))
+		s.renderError(w, r, errors.Wrap(err, "failed to convert"))
 		return
 	}
 
-	response := make([]dto.AggregateCoinResponse, 0, len(aggregateData))
-	for _, data := range aggregateData {
-		response = append(response, dto.AggregateCoinResponse{
-			CoinName: data.CoinName,
-			Price:    data.Price,
-		})
+	response := dto.ConversionResponse{
+		From:   conversion.From,
+		To:     conversion.To,
+		Amount: conversion.Amount,
+		Rate:   conversion.Rate,
+		Result: conversion.Result,
+		Via:    conversion.Via,
+	}
+	if conversion.FromLeg != nil {
+		fromPriceAt := conversion.FromLeg.PriceAt()
+		response.FromPriceAt = &fromPriceAt
+	}
+	if conversion.ToLeg != nil {
+		toPriceAt := conversion.ToLeg.PriceAt()
+		response.ToPriceAt = &toPriceAt
 	}
 
-	logger.Info("Aggregation request processed",
-		slog.Int("coins_count", len(response)),
+	logger.Info("Conversion processed",
 		slog.Duration("duration", time.Since(startTime)))
 
 	s.renderResponse(w, http.StatusOK, response)
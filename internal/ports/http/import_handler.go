@@ -0,0 +1,107 @@
+package http
+
+import (
+	"compress/gzip"
+	"io"
+	"log/slog"
+	"mime"
+	"net/http"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"Cryptoproject/internal/adapters/importer"
+	"Cryptoproject/internal/entities"
+	"Cryptoproject/pkg/dto"
+)
+
+// handleImport godoc
+// @Summary Import price history
+// @Description Loads historical prices from a CSV or NDJSON body; gzip bodies are accepted with Content-Encoding: gzip. CSV needs a header with coin_name, price and observed_at columns, currency and source are optional; currency must be the quote currency. Invalid lines are reported and skipped, prices already stored are skipped, so an import can be safely re-run
+// @Tags admin
+// @Accept text/csv
+// @Accept application/x-ndjson
+// @Produce json
+// @Security AdminToken
+// @Param format query string false "Body format: csv or ndjson; taken from Content-Type by default" default(csv)
+// @Success 200 {object} dto.ImportResponse
+// @Failure 400 {object} dto.ProblemResponse
+// @Failure 401 {object} dto.ProblemResponse
+// @Failure 500 {object} dto.ProblemResponse
+// @Router /api/v1/admin/import [post]
+func (s *Server) handleImport(w http.ResponseWriter, r *http.Request) {
+	const op = "http.handleImport"
+	startTime := time.Now()
+	logger := s.logger.With(slog.String("op", op))
+
+	format, err := entities.ParseImportFormat(importFormatParam(r))
+	if err != nil {
+		logger.Warn("Validation failed", slog.String("error", err.Error()))
+		s.renderError(w, r, err)
+		return
+	}
+
+	var body io.Reader = r.Body
+	if r.Header.Get("Content-Encoding") == "gzip" {
+		gzReader, err := gzip.NewReader(r.Body)
+		if err != nil {
+			err = errors.Wrapf(entities.ErrInvalidParam, "invalid gzip body: %v", err)
+			logger.Warn("Validation failed", slog.String("error", err.Error()))
+			s.renderError(w, r, err)
+			return
+		}
+		defer gzReader.Close()
+		body = gzReader
+	}
+
+	reader, err := importer.NewReader(body, format)
+	if err != nil {
+		logger.Warn("Validation failed", slog.String("error", err.Error()))
+		s.renderError(w, r, err)
+		return
+	}
+
+	report, err := s.coinService.Import(r.Context(), reader)
+	if err != nil {
+		logger.Error("Service call failed",
+			slog.String("error", err.Error()),
+			slog.Duration("duration", time.Since(startTime)))
+		s.renderError(w, r, err)
+		return
+	}
+
+	response := dto.ImportResponse{
+		Lines:    report.Lines,
+		Imported: report.Imported,
+		Skipped:  report.Skipped,
+		Rejected: report.Rejected,
+		Errors:   make([]dto.ImportLineErrorResponse, 0, len(report.Errors)),
+	}
+	for _, lineErr := range report.Errors {
+		response.Errors = append(response.Errors, dto.ImportLineErrorResponse{
+			Line:  lineErr.Line,
+			Error: lineErr.Err.Error(),
+		})
+	}
+
+	logger.Info("Import processed",
+		slog.Int("lines", report.Lines),
+		slog.Int("imported", report.Imported),
+		slog.Int("rejected", report.Rejected),
+		slog.Duration("duration", time.Since(startTime)))
+	s.renderResponse(w, http.StatusOK, response)
+}
+
+// importFormatParam берёт формат из параметра format, а без него — из Content-Type.
+func importFormatParam(r *http.Request) string {
+	if format := r.URL.Query().Get("format"); format != "" {
+		return format
+	}
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	switch mediaType {
+	case "application/x-ndjson", "application/jsonl":
+		return string(entities.ExportNDJSON)
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,45 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Import_RequiresAdminToken(t *testing.T) {
+	t.Parallel()
+
+	body := "coin_name,price,observed_at\nBTC,7200,2020-01-01T00:00:00Z\nETH,abc,2020-01-01T00:00:00Z\n"
+
+	server := NewServer(&stubCoinService{}, "0", nil)
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/admin/import", strings.NewReader(body))
+	rec := httptest.NewRecorder()
+	server.router.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+
+	server = NewServer(&stubCoinService{}, "0", nil, WithAdminToken("secret"))
+	req = httptest.NewRequest(http.MethodPost, "/api/v1/admin/import", strings.NewReader(body))
+	req.Header.Set("Authorization", "Bearer wrong")
+	rec = httptest.NewRecorder()
+	server.router.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+
+	req = httptest.NewRequest(http.MethodPost, "/api/v1/admin/import", strings.NewReader(body))
+	req.Header.Set("Authorization", "Bearer secret")
+	req.Header.Set("Content-Type", "text/csv")
+	rec = httptest.NewRecorder()
+	server.router.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"lines":2,"imported":1,"skipped":0,"rejected":1,
+		"errors":[{"line":3,"error":"invalid price \"abc\""}]}`, rec.Body.String())
+
+	req = httptest.NewRequest(http.MethodPost, "/api/v1/admin/import?format=csv", strings.NewReader("coin,price\n"))
+	req.Header.Set("Authorization", "Bearer secret")
+	rec = httptest.NewRecorder()
+	server.router.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
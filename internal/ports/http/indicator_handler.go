@@ -0,0 +1,116 @@
+package http
+
+import (
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/pkg/errors"
+
+	"Cryptoproject/internal/entities"
+	"Cryptoproject/pkg/dto"
+)
+
+// handleGetIndicator godoc
+// @Summary Technical indicator
+// @Description Computes a technical indicator over close prices of stored history bucketed by interval. Values are keyed by line: "value" for sma, ema and rsi; "upper", "middle", "lower" for bollinger (2 standard deviations); "macd", "signal", "histogram" for macd (periods 12/26/9, period is ignored)
+// @Tags coins
+// @Produce json
+// @Param title path string true "Coin symbol" Example("BTC")
+// @Param type query string true "Indicator" Enums(sma, ema, rsi, bollinger, macd)
+// @Param period query int false "Period in buckets (default 20, 14 for rsi)"
+// @Param interval query string false "Bucket size" Enums(5m, 15m, 1h, 4h, 1d) default(1h)
+// @Param limit query int false "Number of latest points" default(100)
+// @Success 200 {object} dto.IndicatorResponse
+// @Failure 400 {object} dto.ProblemResponse
+// @Failure 404 {object} dto.ProblemResponse
+// @Failure 429 {object} dto.ProblemResponse
+// @Failure 500 {object} dto.ProblemResponse
+// @Router /api/v1/coins/{title}/indicators [get]
+func (s *Server) handleGetIndicator(w http.ResponseWriter, r *http.Request) {
+	const op = "http.handleGetIndicator"
+	startTime := time.Now()
+	title := strings.TrimSpace(chi.URLParam(r, "title"))
+	logger := s.logger.With(
+		slog.String("op", op),
+		slog.String("title", title),
+	)
+
+	query, err := parseIndicatorQuery(r, title)
+	if err != nil {
+		logger.Warn("Validation failed", slog.String("error", err.Error()))
+		s.renderError(w, r, err)
+		return
+	}
+
+	series, err := s.coinService.GetIndicator(r.Context(), query)
+	if err != nil {
+		logger.Error("Service call failed",
+			slog.String("error", err.Error()),
+			slog.Duration("duration", time.Since(startTime)))
+		s.renderError(w, r, err)
+		return
+	}
+
+	interval := r.URL.Query().Get("interval")
+	if interval == "" {
+		interval = "1h"
+	}
+	response := dto.IndicatorResponse{
+		CoinName: series.CoinName,
+		Type:     string(series.Type),
+		Period:   series.Period,
+		Interval: interval,
+		Points:   make([]dto.IndicatorPointResponse, 0, len(series.Points)),
+	}
+	for _, point := range series.Points {
+		response.Points = append(response.Points, dto.IndicatorPointResponse{
+			Time:   point.Time,
+			Close:  point.Close,
+			Values: point.Values,
+		})
+	}
+
+	logger.Info("Request processed successfully",
+		slog.Int("points_count", len(response.Points)),
+		slog.Duration("duration", time.Since(startTime)))
+	s.renderResponse(w, http.StatusOK, response)
+}
+
+func parseIndicatorQuery(r *http.Request, title string) (entities.IndicatorQuery, error) {
+	query := entities.IndicatorQuery{CoinName: title}
+	if title == "" {
+		return query, errors.Wrap(entities.ErrInvalidParam, "title is required")
+	}
+
+	var err error
+	if query.Type, err = entities.ParseIndicatorType(r.URL.Query().Get("type")); err != nil {
+		return query, err
+	}
+	if query.Interval, err = entities.ParseCandleInterval(r.URL.Query().Get("interval")); err != nil {
+		return query, err
+	}
+	if query.Period, err = intQueryParam(r, "period"); err != nil {
+		return query, err
+	}
+	if query.Limit, err = intQueryParam(r, "limit"); err != nil {
+		return query, err
+	}
+	return query, nil
+}
+
+// intQueryParam returns 0 when the parameter is not set.
+func intQueryParam(r *http.Request, name string) (int, error) {
+	param := r.URL.Query().Get(name)
+	if param == "" {
+		return 0, nil
+	}
+	value, err := strconv.Atoi(param)
+	if err != nil || value <= 0 {
+		return 0, errors.Wrapf(entities.ErrInvalidParam, "invalid %s: %q", name, param)
+	}
+	return value, nil
+}
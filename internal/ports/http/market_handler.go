@@ -0,0 +1,93 @@
+package http
+
+import (
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"Cryptoproject/internal/entities"
+	"Cryptoproject/pkg/dto"
+)
+
+const defaultMoversLimit = 10
+
+// handleMarketMovers godoc
+// @Summary Top market movers
+// @Description Ranks tracked coins by price change over the window computed from stored history: top gainers, top losers, most volatile and the number of coins up and down
+// @Tags market
+// @Produce json
+// @Param window query string false "Window" Enums(1h, 24h, 7d) default(24h)
+// @Param limit query int false "Coins per list" default(10)
+// @Success 200 {object} dto.MarketMoversResponse
+// @Failure 400 {object} dto.ProblemResponse
+// @Failure 429 {object} dto.ProblemResponse
+// @Failure 500 {object} dto.ProblemResponse
+// @Router /api/v1/market/movers [get]
+func (s *Server) handleMarketMovers(w http.ResponseWriter, r *http.Request) {
+	const op = "http.handleMarketMovers"
+	startTime := time.Now()
+	logger := s.logger.With(slog.String("op", op))
+
+	windowParam := r.URL.Query().Get("window")
+	window, err := entities.ParseMoverWindow(windowParam)
+	if err != nil {
+		logger.Warn("Validation failed", slog.String("error", err.Error()))
+		s.renderError(w, r, err)
+		return
+	}
+	if windowParam == "" {
+		windowParam = "24h"
+	}
+
+	limit := defaultMoversLimit
+	if limitParam := r.URL.Query().Get("limit"); limitParam != "" {
+		if limit, err = strconv.Atoi(limitParam); err != nil {
+			err = errors.Wrapf(entities.ErrInvalidParam, "invalid limit: %q", limitParam)
+			logger.Warn("Validation failed", slog.String("error", err.Error()))
+			s.renderError(w, r, err)
+			return
+		}
+	}
+
+	overview, err := s.coinService.GetMarketMovers(r.Context(), window, limit)
+	if err != nil {
+		logger.Error("Service call failed",
+			slog.String("error", err.Error()),
+			slog.Duration("duration", time.Since(startTime)))
+		s.renderError(w, r, err)
+		return
+	}
+
+	response := dto.MarketMoversResponse{
+		Window:       windowParam,
+		Since:        overview.Since,
+		Gainers:      moverResponses(overview.Gainers),
+		Losers:       moverResponses(overview.Losers),
+		MostVolatile: moverResponses(overview.MostVolatile),
+		Up:           overview.Up,
+		Down:         overview.Down,
+		Unchanged:    overview.Unchanged,
+	}
+
+	logger.Info("Request processed successfully",
+		slog.String("window", windowParam),
+		slog.Duration("duration", time.Since(startTime)))
+	s.renderResponse(w, http.StatusOK, response)
+}
+
+func moverResponses(movers []entities.MarketMover) []dto.MarketMoverResponse {
+	response := make([]dto.MarketMoverResponse, 0, len(movers))
+	for _, mover := range movers {
+		response = append(response, dto.MarketMoverResponse{
+			CoinName:   mover.CoinName,
+			OpenPrice:  mover.OpenPrice,
+			LastPrice:  mover.LastPrice,
+			ChangePct:  mover.ChangePct,
+			Volatility: mover.Volatility,
+		})
+	}
+	return response
+}
@@ -0,0 +1,304 @@
+package http
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/pkg/errors"
+
+	"Cryptoproject/internal/entities"
+	"Cryptoproject/pkg/dto"
+)
+
+// handleCreatePortfolio godoc
+// @Summary Create portfolio
+// @Tags portfolios
+// @Accept json
+// @Produce json
+// @Security AdminToken
+// @Param request body dto.CreatePortfolioRequest true "Portfolio"
+// @Success 201 {object} dto.PortfolioResponse
+// @Failure 400 {object} dto.ProblemResponse
+// @Failure 401 {object} dto.ProblemResponse
+// @Failure 429 {object} dto.ProblemResponse
+// @Failure 500 {object} dto.ProblemResponse
+// @Router /api/v1/portfolios [post]
+func (s *Server) handleCreatePortfolio(w http.ResponseWriter, r *http.Request) {
+	const op = "http.handleCreatePortfolio"
+	logger := s.logger.With(slog.String("op", op))
+
+	var request dto.CreatePortfolioRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		err = errors.Wrapf(entities.ErrInvalidParam, "invalid request body: %v", err)
+		logger.Warn("Validation failed", slog.String("error", err.Error()))
+		s.renderError(w, r, err)
+		return
+	}
+
+	portfolio, err := s.portfolios.CreatePortfolio(r.Context(), request.Name)
+	if err != nil {
+		logger.Error("Service call failed", slog.String("error", err.Error()))
+		s.renderError(w, r, err)
+		return
+	}
+
+	s.renderResponse(w, http.StatusCreated, portfolioResponse(*portfolio))
+}
+
+// handleListPortfolios godoc
+// @Summary List portfolios
+// @Tags portfolios
+// @Produce json
+// @Security AdminToken
+// @Success 200 {array} dto.PortfolioResponse
+// @Failure 401 {object} dto.ProblemResponse
+// @Failure 429 {object} dto.ProblemResponse
+// @Failure 500 {object} dto.ProblemResponse
+// @Router /api/v1/portfolios [get]
+func (s *Server) handleListPortfolios(w http.ResponseWriter, r *http.Request) {
+	const op = "http.handleListPortfolios"
+	logger := s.logger.With(slog.String("op", op))
+
+	portfolios, err := s.portfolios.ListPortfolios(r.Context())
+	if err != nil {
+		logger.Error("Service call failed", slog.String("error", err.Error()))
+		s.renderError(w, r, err)
+		return
+	}
+
+	response := make([]dto.PortfolioResponse, 0, len(portfolios))
+	for _, portfolio := range portfolios {
+		response = append(response, portfolioResponse(portfolio))
+	}
+	s.renderResponse(w, http.StatusOK, response)
+}
+
+// handleGetPortfolio godoc
+// @Summary Get portfolio
+// @Tags portfolios
+// @Produce json
+// @Security AdminToken
+// @Param id path int true "Portfolio ID"
+// @Success 200 {object} dto.PortfolioResponse
+// @Failure 400 {object} dto.ProblemResponse
+// @Failure 404 {object} dto.ProblemResponse
+// @Failure 401 {object} dto.ProblemResponse
+// @Failure 429 {object} dto.ProblemResponse
+// @Failure 500 {object} dto.ProblemResponse
+// @Router /api/v1/portfolios/{id} [get]
+func (s *Server) handleGetPortfolio(w http.ResponseWriter, r *http.Request) {
+	const op = "http.handleGetPortfolio"
+	logger := s.logger.With(slog.String("op", op))
+
+	id, err := portfolioID(r)
+	if err != nil {
+		s.renderError(w, r, err)
+		return
+	}
+
+	portfolio, err := s.portfolios.GetPortfolio(r.Context(), id)
+	if err != nil {
+		logger.Warn("Service call failed", slog.String("error", err.Error()))
+		s.renderError(w, r, err)
+		return
+	}
+
+	s.renderResponse(w, http.StatusOK, portfolioResponse(*portfolio))
+}
+
+// handleAddTransaction godoc
+// @Summary Record a buy or sell transaction
+// @Tags portfolios
+// @Accept json
+// @Produce json
+// @Security AdminToken
+// @Param id path int true "Portfolio ID"
+// @Param request body dto.TransactionRequest true "Transaction"
+// @Success 201 {object} dto.TransactionResponse
+// @Failure 400 {object} dto.ProblemResponse
+// @Failure 404 {object} dto.ProblemResponse
+// @Failure 401 {object} dto.ProblemResponse
+// @Failure 429 {object} dto.ProblemResponse
+// @Failure 500 {object} dto.ProblemResponse
+// @Router /api/v1/portfolios/{id}/transactions [post]
+func (s *Server) handleAddTransaction(w http.ResponseWriter, r *http.Request) {
+	const op = "http.handleAddTransaction"
+	logger := s.logger.With(slog.String("op", op))
+
+	id, err := portfolioID(r)
+	if err != nil {
+		s.renderError(w, r, err)
+		return
+	}
+
+	var request dto.TransactionRequest
+	if err = json.NewDecoder(r.Body).Decode(&request); err != nil {
+		err = errors.Wrapf(entities.ErrInvalidParam, "invalid request body: %v", err)
+		logger.Warn("Validation failed", slog.String("error", err.Error()))
+		s.renderError(w, r, err)
+		return
+	}
+
+	transaction, err := s.portfolios.AddTransaction(r.Context(), entities.Transaction{
+		PortfolioID: id,
+		CoinName:    request.CoinName,
+		Side:        entities.TransactionSide(request.Side),
+		Quantity:    request.Quantity,
+		Price:       request.Price,
+		Fee:         request.Fee,
+		ExecutedAt:  request.ExecutedAt,
+	})
+	if err != nil {
+		logger.Warn("Service call failed", slog.String("error", err.Error()))
+		s.renderError(w, r, err)
+		return
+	}
+
+	s.renderResponse(w, http.StatusCreated, transactionResponse(*transaction))
+}
+
+// handleGetTransactions godoc
+// @Summary List portfolio transactions
+// @Tags portfolios
+// @Produce json
+// @Security AdminToken
+// @Param id path int true "Portfolio ID"
+// @Success 200 {array} dto.TransactionResponse
+// @Failure 400 {object} dto.ProblemResponse
+// @Failure 404 {object} dto.ProblemResponse
+// @Failure 401 {object} dto.ProblemResponse
+// @Failure 429 {object} dto.ProblemResponse
+// @Failure 500 {object} dto.ProblemResponse
+// @Router /api/v1/portfolios/{id}/transactions [get]
+func (s *Server) handleGetTransactions(w http.ResponseWriter, r *http.Request) {
+	const op = "http.handleGetTransactions"
+	logger := s.logger.With(slog.String("op", op))
+
+	id, err := portfolioID(r)
+	if err != nil {
+		s.renderError(w, r, err)
+		return
+	}
+
+	transactions, err := s.portfolios.GetTransactions(r.Context(), id)
+	if err != nil {
+		logger.Warn("Service call failed", slog.String("error", err.Error()))
+		s.renderError(w, r, err)
+		return
+	}
+
+	response := make([]dto.TransactionResponse, 0, len(transactions))
+	for _, transaction := range transactions {
+		response = append(response, transactionResponse(transaction))
+	}
+	s.renderResponse(w, http.StatusOK, response)
+}
+
+// handleValuatePortfolio godoc
+// @Summary Value portfolio
+// @Description Values holdings at the latest stored prices, or at a historical point when "at" is set. P&L uses FIFO cost basis
+// @Tags portfolios
+// @Produce json
+// @Security AdminToken
+// @Param id path int true "Portfolio ID"
+// @Param at query string false "RFC3339 timestamp" Example("2025-01-01T00:00:00Z")
+// @Success 200 {object} dto.ValuationResponse
+// @Failure 400 {object} dto.ProblemResponse
+// @Failure 404 {object} dto.ProblemResponse
+// @Failure 401 {object} dto.ProblemResponse
+// @Failure 429 {object} dto.ProblemResponse
+// @Failure 500 {object} dto.ProblemResponse
+// @Router /api/v1/portfolios/{id}/valuation [get]
+func (s *Server) handleValuatePortfolio(w http.ResponseWriter, r *http.Request) {
+	const op = "http.handleValuatePortfolio"
+	startTime := time.Now()
+	logger := s.logger.With(slog.String("op", op))
+
+	id, err := portfolioID(r)
+	if err != nil {
+		s.renderError(w, r, err)
+		return
+	}
+
+	var at time.Time
+	if atParam := r.URL.Query().Get("at"); atParam != "" {
+		if at, err = time.Parse(time.RFC3339, atParam); err != nil {
+			err = errors.Wrapf(entities.ErrInvalidParam, "invalid at: %q", atParam)
+			logger.Warn("Validation failed", slog.String("error", err.Error()))
+			s.renderError(w, r, err)
+			return
+		}
+	}
+
+	valuation, err := s.portfolios.Valuate(r.Context(), id, at)
+	if err != nil {
+		logger.Warn("Service call failed", slog.String("error", err.Error()))
+		s.renderError(w, r, err)
+		return
+	}
+
+	response := dto.ValuationResponse{
+		PortfolioID:   valuation.PortfolioID,
+		At:            valuation.At,
+		Positions:     make([]dto.PositionResponse, 0, len(valuation.Positions)),
+		CostBasis:     valuation.CostBasis,
+		MarketValue:   valuation.MarketValue,
+		RealizedPnL:   valuation.RealizedPnL,
+		UnrealizedPnL: valuation.UnrealizedPnL,
+	}
+	for _, position := range valuation.Positions {
+		item := dto.PositionResponse{
+			CoinName:      position.CoinName,
+			Quantity:      position.Quantity,
+			CostBasis:     position.CostBasis,
+			Price:         position.Price,
+			MarketValue:   position.MarketValue,
+			RealizedPnL:   position.RealizedPnL,
+			UnrealizedPnL: position.UnrealizedPnL,
+		}
+		if !position.PriceAt.IsZero() {
+			priceAt := position.PriceAt
+			item.PriceAt = &priceAt
+		}
+		response.Positions = append(response.Positions, item)
+	}
+
+	logger.Info("Valuation processed",
+		slog.Int64("portfolio_id", id),
+		slog.Duration("duration", time.Since(startTime)))
+	s.renderResponse(w, http.StatusOK, response)
+}
+
+func portfolioID(r *http.Request) (int64, error) {
+	idParam := chi.URLParam(r, "id")
+	id, err := strconv.ParseInt(idParam, 10, 64)
+	if err != nil || id <= 0 {
+		return 0, errors.Wrapf(entities.ErrInvalidParam, "invalid portfolio id: %q", idParam)
+	}
+	return id, nil
+}
+
+func portfolioResponse(portfolio entities.Portfolio) dto.PortfolioResponse {
+	return dto.PortfolioResponse{
+		ID:        portfolio.ID,
+		Name:      portfolio.Name,
+		CreatedAt: portfolio.CreatedAt,
+	}
+}
+
+func transactionResponse(transaction entities.Transaction) dto.TransactionResponse {
+	return dto.TransactionResponse{
+		ID:          transaction.ID,
+		PortfolioID: transaction.PortfolioID,
+		CoinName:    transaction.CoinName,
+		Side:        string(transaction.Side),
+		Quantity:    transaction.Quantity,
+		Price:       transaction.Price,
+		Fee:         transaction.Fee,
+		ExecutedAt:  transaction.ExecutedAt,
+	}
+}
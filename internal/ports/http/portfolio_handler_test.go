@@ -0,0 +1,76 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"Cryptoproject/internal/entities"
+	"Cryptoproject/internal/ports/ratelimit"
+)
+
+type stubPortfolioService struct{}
+
+func (stubPortfolioService) CreatePortfolio(_ context.Context, name string) (*entities.Portfolio, error) {
+	return &entities.Portfolio{ID: 1, Name: name}, nil
+}
+
+func (stubPortfolioService) GetPortfolio(_ context.Context, id int64) (*entities.Portfolio, error) {
+	return &entities.Portfolio{ID: id}, nil
+}
+
+func (stubPortfolioService) ListPortfolios(_ context.Context) ([]entities.Portfolio, error) {
+	return []entities.Portfolio{{ID: 1, Name: "main"}}, nil
+}
+
+func (stubPortfolioService) AddTransaction(_ context.Context, tx entities.Transaction) (*entities.Transaction, error) {
+	return &tx, nil
+}
+
+func (stubPortfolioService) GetTransactions(_ context.Context, _ int64) ([]entities.Transaction, error) {
+	return nil, nil
+}
+
+func (stubPortfolioService) Valuate(_ context.Context, portfolioID int64, _ time.Time) (*entities.Valuation, error) {
+	return &entities.Valuation{PortfolioID: portfolioID}, nil
+}
+
+func Test_Portfolios_RequireAdminToken(t *testing.T) {
+	t.Parallel()
+
+	server := NewServer(&stubCoinService{}, "0", nil, WithPortfolioService(stubPortfolioService{}))
+	rec := httptest.NewRecorder()
+	server.router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/portfolios", nil))
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+
+	server = NewServer(&stubCoinService{}, "0", nil, WithPortfolioService(stubPortfolioService{}),
+		WithAdminToken("secret"), WithRateLimit(ratelimit.New(ratelimit.Config{Default: ratelimit.Limit{RPS: 1, Burst: 3}})))
+
+	for _, req := range []*http.Request{
+		httptest.NewRequest(http.MethodGet, "/api/v1/portfolios", nil),
+		httptest.NewRequest(http.MethodPost, "/api/v1/portfolios/1/transactions",
+			strings.NewReader(`{"coin_name":"BTC","side":"buy","quantity":1,"price":100}`)),
+	} {
+		rec = httptest.NewRecorder()
+		server.router.ServeHTTP(rec, req)
+		assert.Equal(t, http.StatusUnauthorized, rec.Code, req.URL.Path)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/portfolios", nil)
+	req.Header.Set("Authorization", "Bearer secret")
+	rec = httptest.NewRecorder()
+	server.router.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	// лимит считается и для запросов без токена
+	req = httptest.NewRequest(http.MethodGet, "/api/v1/portfolios/1", nil)
+	req.Header.Set("Authorization", "Bearer secret")
+	rec = httptest.NewRecorder()
+	server.router.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+}
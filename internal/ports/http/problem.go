@@ -0,0 +1,72 @@
+package http
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"Cryptoproject/internal/entities"
+	"Cryptoproject/pkg/dto"
+)
+
+const problemContentType = "application/problem+json"
+
+// Стабильные коды ошибок API: клиенты ветвятся по ним, а не по тексту
+const (
+	CodeInvalidParam        = entities.CodeInvalidParam
+	CodeUnknownSymbol       = entities.CodeUnknownSymbol
+	CodeNotFound            = entities.CodeNotFound
+	CodeStaleData           = entities.CodeStaleData
+	CodeRateLimited         = entities.CodeRateLimited
+	CodeUnauthorized        = entities.CodeUnauthorized
+	CodeProviderError       = entities.CodeProviderError
+	CodeProviderUnavailable = entities.CodeProviderUnavailable
+	CodeInternal            = entities.CodeInternal
+)
+
+// problemStatus is the HTTP status and title of an error code.
+type problemStatus struct {
+	status int
+	title  string
+}
+
+var problemStatuses = map[string]problemStatus{
+	CodeInvalidParam:        {http.StatusBadRequest, "Invalid request parameter"},
+	CodeUnknownSymbol:       {http.StatusNotFound, "Unknown coin symbol"},
+	CodeNotFound:            {http.StatusNotFound, "Resource not found"},
+	CodeStaleData:           {http.StatusUnprocessableEntity, "Stale price data"},
+	CodeRateLimited:         {http.StatusTooManyRequests, "Rate limit exceeded"},
+	CodeProviderError:       {http.StatusBadGateway, "Price provider error"},
+	CodeProviderUnavailable: {http.StatusServiceUnavailable, "Price provider unavailable"},
+	CodeInternal:            {http.StatusInternalServerError, "Internal server error"},
+}
+
+// newProblem builds the response for err without exposing internal details.
+func newProblem(err error, instance string) dto.ProblemResponse {
+	class, detail := entities.ClassifyError(err)
+	status := problemStatuses[class.Code]
+	return dto.ProblemResponse{
+		Type:     problemType(class.Code),
+		Title:    status.title,
+		Status:   status.status,
+		Detail:   detail,
+		Instance: instance,
+		Code:     class.Code,
+	}
+}
+
+func problemType(code string) string {
+	return "/problems/" + strings.ReplaceAll(strings.ToLower(code), "_", "-")
+}
+
+func (s *Server) renderProblem(w http.ResponseWriter, problem dto.ProblemResponse) {
+	w.Header().Set("Content-Type", problemContentType)
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	w.WriteHeader(problem.Status)
+	if err := json.NewEncoder(w).Encode(problem); err != nil {
+		s.logger.Error("Failed to render problem",
+			slog.Int("status", problem.Status),
+			slog.String("error", err.Error()))
+	}
+}
@@ -0,0 +1,98 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"Cryptoproject/internal/entities"
+	"Cryptoproject/pkg/dto"
+)
+
+func Test_RenderError_Problem(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		err    error
+		status int
+		code   string
+		detail string
+	}{
+		{
+			name:   "unknown symbol",
+			err:    errors.Wrap(errors.Wrapf(entities.ErrUnknownSymbol, "unknown titles: %v", []string{"XXX"}), "failed to check existing titles"),
+			status: http.StatusNotFound,
+			code:   CodeUnknownSymbol,
+			detail: "unknown titles: [XXX]",
+		},
+		{
+			name:   "internal",
+			err:    errors.Wrapf(entities.ErrInternal, "aggregate query failed, err: %v", "pq: password authentication failed"),
+			status: http.StatusInternalServerError,
+			code:   CodeInternal,
+			detail: "The request could not be processed",
+		},
+		{
+			name:   "unclassified",
+			err:    errors.New("connection reset by peer"),
+			status: http.StatusInternalServerError,
+			code:   CodeInternal,
+			detail: "The request could not be processed",
+		},
+		{
+			name:   "provider unavailable",
+			err:    errors.Wrap(entities.ErrProviderUnavailable, "execute request failure: dial tcp 10.0.0.1:443: i/o timeout"),
+			status: http.StatusServiceUnavailable,
+			code:   CodeProviderUnavailable,
+			detail: "The price provider is unavailable, retry later",
+		},
+		{
+			name:   "provider error",
+			err:    errors.Wrap(entities.ErrProviderError, "api error: bad fsyms"),
+			status: http.StatusBadGateway,
+			code:   CodeProviderError,
+			detail: "The price provider returned an unexpected response",
+		},
+		{
+			name:   "provider rate limit",
+			err:    errors.Wrap(entities.ErrRateLimited, "api error: You are over your rate limit"),
+			status: http.StatusTooManyRequests,
+			code:   CodeRateLimited,
+			detail: "Too many requests, retry later",
+		},
+		{
+			name:   "stale data",
+			err:    errors.Wrap(entities.ErrStaleData, "price of BTC is 10m old"),
+			status: http.StatusUnprocessableEntity,
+			code:   CodeStaleData,
+			detail: "price of BTC is 10m old",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			server := NewServer(&stubCoinService{err: tt.err}, "0", nil)
+			rec := httptest.NewRecorder()
+			server.router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/coins/BTC", nil))
+
+			require.Equal(t, tt.status, rec.Code)
+			assert.Equal(t, problemContentType, rec.Header().Get("Content-Type"))
+
+			var problem dto.ProblemResponse
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &problem))
+			assert.Equal(t, tt.status, problem.Status)
+			assert.Equal(t, tt.code, problem.Code)
+			assert.Equal(t, tt.detail, problem.Detail)
+			assert.Equal(t, "/api/v1/coins/BTC", problem.Instance)
+			assert.NotEmpty(t, problem.Type)
+			assert.NotEmpty(t, problem.Title)
+		})
+	}
+}
@@ -0,0 +1,93 @@
+package http
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"Cryptoproject/internal/entities"
+	"Cryptoproject/pkg/dto"
+)
+
+// handleGetQuotes godoc
+// @Summary Get latest coin prices with per-coin status
+// @Description Returns a result for every requested coin in request order instead of failing the whole request. Status is ok, unknown_symbol (the provider does not list the coin), rejected (the fetched quote failed validation; reason holds the quarantine reason), stale (the provider failed and the last stored price is returned) or provider_error (the provider failed and no price is stored). Titles are read as for /coins/actual
+// @Tags coins
+// @Accept json
+// @Produce json
+// @Param request body dto.CoinsRequest false "Coins to query"
+// @Param titles query string false "Comma-separated list of coin titles, used when the body has no titles" Example("BTC,ETH")
+// @Success 200 {object} dto.QuotesResponse
+// @Failure 400 {object} dto.ProblemResponse
+// @Failure 429 {object} dto.ProblemResponse
+// @Failure 500 {object} dto.ProblemResponse
+// @Router /api/v1/coins/quotes [post]
+func (s *Server) handleGetQuotes(w http.ResponseWriter, r *http.Request) {
+	const op = "http.handleGetQuotes"
+	startTime := time.Now()
+	logger := s.logger.With(
+		slog.String("op", op),
+		slog.String("method", r.Method),
+		slog.String("path", r.URL.Path),
+	)
+
+	request, err := decodeCoinsRequest(w, r)
+	if err != nil {
+		logger.Warn("Validation failed", slog.String("error", err.Error()))
+		s.renderError(w, r, err)
+		return
+	}
+	logger = logger.With(slog.Int("titles_count", len(request.Titles)))
+
+	response, err := s.quotes(r.Context(), request)
+	if err != nil {
+		logger.Error("Service call failed",
+			slog.String("error", err.Error()),
+			slog.Duration("duration", time.Since(startTime)))
+		s.renderError(w, r, err)
+		return
+	}
+
+	logger.Info("Request processed successfully",
+		slog.Bool("complete", response.Complete),
+		slog.Duration("duration", time.Since(startTime)))
+	s.renderResponse(w, http.StatusOK, response)
+}
+
+func (s *Server) quotes(ctx context.Context, request dto.CoinsRequest) (dto.QuotesResponse, error) {
+	quotes, err := s.coinService.GetQuotes(ctx, request.Titles)
+	if err != nil {
+		return dto.QuotesResponse{}, errors.Wrap(err, "failed to get quotes")
+	}
+
+	coins := make([]entities.Coin, 0, len(quotes))
+	for _, quote := range quotes {
+		if quote.Coin != nil {
+			coins = append(coins, *quote.Coin)
+		}
+	}
+	if err = checkCurrency(coins, request.Currency); err != nil {
+		return dto.QuotesResponse{}, err
+	}
+
+	response := dto.QuotesResponse{
+		Results:  make([]dto.QuoteResponse, 0, len(quotes)),
+		Complete: entities.CountQuotes(quotes, entities.QuoteOK) == len(quotes),
+	}
+	for _, quote := range quotes {
+		result := dto.QuoteResponse{
+			Title:  quote.Title,
+			Status: string(quote.Status),
+			Detail: quote.Reason,
+		}
+		if quote.Coin != nil {
+			coin := coinResponse(*quote.Coin)
+			result.Coin = &coin
+		}
+		response.Results = append(response.Results, result)
+	}
+	return response, nil
+}
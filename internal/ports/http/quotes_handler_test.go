@@ -0,0 +1,52 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"Cryptoproject/internal/entities"
+	"Cryptoproject/pkg/dto"
+)
+
+func Test_GetQuotes_PartialResults(t *testing.T) {
+	t.Parallel()
+
+	btc := entities.Coin{CoinName: "BTC", Price: 28000, Currency: "USD"}
+	eth := entities.Coin{CoinName: "ETH", Price: 1400, Currency: "USD"}
+	server := NewServer(&stubCoinService{quotes: []entities.Quote{
+		{Title: "BTC", Status: entities.QuoteOK, Coin: &btc},
+		{Title: "FOO", Status: entities.QuoteUnknownSymbol, Reason: "provider does not list the coin"},
+		{Title: "ETH", Status: entities.QuoteStale, Coin: &eth, Reason: "provider unavailable"},
+	}}, "0", nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/coins/quotes", strings.NewReader(`{"titles":["BTC","FOO","ETH"]}`))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	server.router.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+	var response dto.QuotesResponse
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&response))
+	assert.False(t, response.Complete)
+	require.Len(t, response.Results, 3)
+
+	assert.Equal(t, "ok", response.Results[0].Status)
+	require.NotNil(t, response.Results[0].Coin)
+	assert.Equal(t, 28000.0, response.Results[0].Coin.Price)
+
+	assert.Equal(t, dto.QuoteResponse{
+		Title:  "FOO",
+		Status: "unknown_symbol",
+		Detail: "provider does not list the coin",
+	}, response.Results[1])
+
+	assert.Equal(t, "stale", response.Results[2].Status)
+	require.NotNil(t, response.Results[2].Coin)
+	assert.Equal(t, "provider unavailable", response.Results[2].Detail)
+}
@@ -0,0 +1,55 @@
+package http
+
+import (
+	"log/slog"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+
+	"Cryptoproject/internal/entities"
+	"Cryptoproject/internal/ports/ratelimit"
+)
+
+const (
+	routeCoinsActual    = ratelimit.RouteCoinsActual
+	routeCoinsAggregate = ratelimit.RouteCoinsAggregate
+)
+
+func (s *Server) clientKey(r *http.Request) string {
+	var apiKey string
+	if header := s.limiter.KeyHeader(); header != "" {
+		apiKey = r.Header.Get(header)
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	return s.limiter.ClientKey(apiKey, host)
+}
+
+// rateLimit returns the limiting middleware for route, or a no-op when limiting is disabled.
+func (s *Server) rateLimit(route string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		if s.limiter == nil {
+			return next
+		}
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			client := s.clientKey(r)
+			ok, retryAfter := s.limiter.Allow(route, client)
+			if ok {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			seconds := int(math.Ceil(retryAfter.Seconds()))
+			s.logger.Warn("Rate limit exceeded",
+				slog.String("route", route),
+				slog.String("client", client),
+				slog.Int("retry_after", seconds))
+
+			w.Header().Set("Retry-After", strconv.Itoa(seconds))
+			s.renderProblem(w, newProblem(entities.ErrRateLimited, r.URL.Path))
+		})
+	}
+}
@@ -0,0 +1,28 @@
+package http
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"Cryptoproject/internal/ports/ratelimit"
+)
+
+func Test_Server_ClientKey(t *testing.T) {
+	t.Parallel()
+
+	server := NewServer(&stubCoinService{}, "0", nil,
+		WithRateLimit(ratelimit.New(ratelimit.Config{KeyHeader: "X-API-Key", APIKeys: []string{"secret"}})))
+
+	r := httptest.NewRequest("POST", "/api/v1/coins/actual", nil)
+	r.RemoteAddr = "10.0.0.1:5555"
+	assert.Equal(t, "ip:10.0.0.1", server.clientKey(r))
+
+	r.Header.Set("X-API-Key", "secret")
+	assert.Equal(t, "key:secret", server.clientKey(r))
+
+	// неизвестные ключи не обходят лимит клиента по IP
+	r.Header.Set("X-API-Key", "random-1")
+	assert.Equal(t, "ip:10.0.0.1", server.clientKey(r))
+}
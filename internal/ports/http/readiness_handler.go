@@ -0,0 +1,74 @@
+package http
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"Cryptoproject/pkg/dto"
+)
+
+const (
+	readinessTimeout = 2 * time.Second
+
+	readinessReady    = "ready"
+	readinessDegraded = "degraded"
+	readinessNotReady = "not_ready"
+)
+
+// ReadinessCheck reports the state of a dependency on /readyz. Check returns
+// the state to show and an error when the dependency is failing. A failing
+// check makes the service not ready unless it is Optional, e.g. a provider
+// outage the service rides out on stored prices only degrades it.
+type ReadinessCheck struct {
+	Name     string
+	Check    func(ctx context.Context) (string, error)
+	Optional bool
+}
+
+// handleReadiness godoc
+// @Summary Readiness probe
+// @Description Reports the state of every dependency. Returns 503 when a required one fails; failing optional ones, such as an open provider circuit, only make the status degraded
+// @Tags health
+// @Produce json
+// @Success 200 {object} dto.ReadinessResponse
+// @Failure 503 {object} dto.ReadinessResponse
+// @Router /readyz [get]
+func (s *Server) handleReadiness(w http.ResponseWriter, r *http.Request) {
+	const op = "http.handleReadiness"
+	logger := s.logger.With(slog.String("op", op))
+
+	ctx, cancel := context.WithTimeout(r.Context(), readinessTimeout)
+	defer cancel()
+
+	response := dto.ReadinessResponse{
+		Status: readinessReady,
+		Checks: make(map[string]string, len(s.readiness)),
+	}
+	for _, check := range s.readiness {
+		state, err := check.Check(ctx)
+		response.Checks[check.Name] = state
+		if err == nil {
+			continue
+		}
+
+		logger.Warn("Readiness check failed",
+			slog.String("check", check.Name),
+			slog.String("state", state),
+			slog.Bool("optional", check.Optional),
+			slog.String("error", err.Error()))
+		switch {
+		case !check.Optional:
+			response.Status = readinessNotReady
+		case response.Status == readinessReady:
+			response.Status = readinessDegraded
+		}
+	}
+
+	status := http.StatusOK
+	if response.Status == readinessNotReady {
+		status = http.StatusServiceUnavailable
+	}
+	s.renderResponse(w, status, response)
+}
@@ -0,0 +1,77 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"Cryptoproject/internal/entities"
+	"Cryptoproject/pkg/dto"
+)
+
+func Test_Readiness(t *testing.T) {
+	t.Parallel()
+
+	check := func(state string, err error) func(context.Context) (string, error) {
+		return func(context.Context) (string, error) { return state, err }
+	}
+	circuitOpen := errors.Wrap(entities.ErrCircuitOpen, "provider circuit is open")
+
+	tests := []struct {
+		name   string
+		checks []ReadinessCheck
+		status int
+		want   dto.ReadinessResponse
+	}{
+		{
+			name: "ready",
+			checks: []ReadinessCheck{
+				{Name: "storage", Check: check("ok", nil)},
+				{Name: "provider", Check: check("closed", nil), Optional: true},
+			},
+			status: http.StatusOK,
+			want:   dto.ReadinessResponse{Status: "ready", Checks: map[string]string{"storage": "ok", "provider": "closed"}},
+		},
+		{
+			name: "provider circuit open",
+			checks: []ReadinessCheck{
+				{Name: "storage", Check: check("ok", nil)},
+				{Name: "provider", Check: check("open", circuitOpen), Optional: true},
+			},
+			status: http.StatusOK,
+			want:   dto.ReadinessResponse{Status: "degraded", Checks: map[string]string{"storage": "ok", "provider": "open"}},
+		},
+		{
+			name: "storage down",
+			checks: []ReadinessCheck{
+				{Name: "storage", Check: check("unreachable", errors.New("connection refused"))},
+				{Name: "provider", Check: check("open", circuitOpen), Optional: true},
+			},
+			status: http.StatusServiceUnavailable,
+			want:   dto.ReadinessResponse{Status: "not_ready", Checks: map[string]string{"storage": "unreachable", "provider": "open"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := make([]ServerOption, 0, len(tt.checks))
+			for _, c := range tt.checks {
+				opts = append(opts, WithReadinessCheck(c))
+			}
+			server := NewServer(&stubCoinService{}, "0", nil, opts...)
+
+			rec := httptest.NewRecorder()
+			server.router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+			require.Equal(t, tt.status, rec.Code)
+
+			var response dto.ReadinessResponse
+			require.NoError(t, json.NewDecoder(rec.Body).Decode(&response))
+			assert.Equal(t, tt.want, response)
+		})
+	}
+}
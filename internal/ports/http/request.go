@@ -0,0 +1,85 @@
+package http
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"Cryptoproject/internal/entities"
+	"Cryptoproject/pkg/dto"
+)
+
+// maxRequestBodySize ограничивает JSON-тела запросов; пакет из 20 запросов по 100 монет в него укладывается
+const maxRequestBodySize = 1 << 20
+
+var currencyPattern = regexp.MustCompile(`^[A-Z]{3,5}$`)
+
+// decodeCoinsRequest reads titles, currency and aggregate function from the
+// JSON body, falling back to the titles query parameter when the body has no
+// titles, and validates them.
+func decodeCoinsRequest(w http.ResponseWriter, r *http.Request) (dto.CoinsRequest, error) {
+	var request dto.CoinsRequest
+	if err := decodeJSONBody(w, r, &request); err != nil {
+		return dto.CoinsRequest{}, err
+	}
+	if len(request.Titles) == 0 {
+		// совместимость со старыми клиентами, передававшими titles в строке запроса
+		if param := strings.TrimSpace(r.URL.Query().Get("titles")); param != "" {
+			request.Titles = strings.Split(param, ",")
+		}
+	}
+	return validateCoinsRequest(request)
+}
+
+func validateCoinsRequest(request dto.CoinsRequest) (dto.CoinsRequest, error) {
+	titles, err := entities.NormalizeTitles(request.Titles)
+	if err != nil {
+		return dto.CoinsRequest{}, err
+	}
+	request.Titles = titles
+
+	request.Currency = strings.ToUpper(strings.TrimSpace(request.Currency))
+	if request.Currency != "" && !currencyPattern.MatchString(request.Currency) {
+		return dto.CoinsRequest{}, errors.Wrapf(entities.ErrInvalidParam, "invalid currency: %q", request.Currency)
+	}
+	request.Agg = strings.ToUpper(strings.TrimSpace(request.Agg))
+	return request, nil
+}
+
+// decodeJSONBody decodes a JSON body into v. An empty body leaves v unchanged.
+func decodeJSONBody(w http.ResponseWriter, r *http.Request, v any) error {
+	if r.Body == nil || r.ContentLength == 0 {
+		return nil
+	}
+	if contentType := r.Header.Get("Content-Type"); contentType != "" && !strings.HasPrefix(contentType, "application/json") {
+		return errors.Wrapf(entities.ErrInvalidParam, "unsupported content type: %s", contentType)
+	}
+
+	decoder := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxRequestBodySize))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(v); err != nil && !errors.Is(err, io.EOF) {
+		return errors.Wrapf(entities.ErrInvalidParam, "invalid request body: %v", err)
+	}
+	if decoder.More() {
+		return errors.Wrap(entities.ErrInvalidParam, "invalid request body: unexpected data after JSON object")
+	}
+	return nil
+}
+
+// checkCurrency rejects a currency other than the one prices are quoted in.
+// Prices stored before currencies were recorded carry none and match any.
+func checkCurrency(coins []entities.Coin, currency string) error {
+	if currency == "" {
+		return nil
+	}
+	for _, coin := range coins {
+		if coin.Currency != "" && coin.Currency != currency {
+			return errors.Wrapf(entities.ErrInvalidParam, "unsupported currency %s: prices are quoted in %s", currency, coin.Currency)
+		}
+	}
+	return nil
+}
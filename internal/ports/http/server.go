@@ -13,6 +13,11 @@
 // @host localhost:8080
 // @BasePath /api/v1
 // @schemes http
+//
+// @securityDefinitions.apikey AdminToken
+// @in header
+// @name Authorization
+// @description Admin token as "Bearer <token>"; admin endpoints are disabled when ADMIN_TOKEN is not set
 package http
 
 import (
@@ -26,13 +31,75 @@ import (
 	httpSwagger "github.com/swaggo/http-swagger"
 
 	_ "Cryptoproject/docs"
+	"Cryptoproject/internal/ports/ratelimit"
 )
 
 type Server struct {
 	router      *chi.Mux
 	httpServer  *http.Server
 	coinService CoinService
+	portfolios  PortfolioService
+	stablecoins StablecoinService
+	limiter     *ratelimit.Limiter
+	graphql     http.Handler
+	metrics     http.Handler
+	readiness   []ReadinessCheck
+	adminToken  string
 	logger      *slog.Logger
+
+	refreshInterval time.Duration
+}
+
+type ServerOption func(s *Server)
+
+// WithRefreshInterval sets how often prices are refreshed; GET responses may be cached for this long.
+func WithRefreshInterval(interval time.Duration) ServerOption {
+	return func(s *Server) {
+		s.refreshInterval = interval
+	}
+}
+
+// WithPortfolioService enables the portfolio endpoints.
+func WithPortfolioService(portfolios PortfolioService) ServerOption {
+	return func(s *Server) {
+		s.portfolios = portfolios
+	}
+}
+
+// WithStablecoinMonitor enables the stablecoin and alert endpoints.
+func WithStablecoinMonitor(stablecoins StablecoinService) ServerOption {
+	return func(s *Server) {
+		s.stablecoins = stablecoins
+	}
+}
+
+// WithGraphQL enables the GraphQL endpoint served by handler.
+func WithGraphQL(handler http.Handler) ServerOption {
+	return func(s *Server) {
+		s.graphql = handler
+	}
+}
+
+// WithMetrics serves handler on /metrics.
+func WithMetrics(handler http.Handler) ServerOption {
+	return func(s *Server) {
+		s.metrics = handler
+	}
+}
+
+// WithReadinessCheck adds check to /readyz.
+func WithReadinessCheck(check ReadinessCheck) ServerOption {
+	return func(s *Server) {
+		s.readiness = append(s.readiness, check)
+	}
+}
+
+// WithRateLimit enables per-client token bucket limiting on API routes.
+// The limiter may be shared with the gRPC server so both ports draw from the same buckets.
+func WithRateLimit(limiter *ratelimit.Limiter) ServerOption {
+	return func(s *Server) {
+		s.limiter = limiter
+	}
 }
 
 type responseWriterWrapper struct {
@@ -49,7 +116,7 @@ func (w *responseWriterWrapper) WriteHeader(code int) {
 	}
 }
 
-func NewServer(coinService CoinService, port string, logger *slog.Logger) *Server {
+func NewServer(coinService CoinService, port string, logger *slog.Logger, opts ...ServerOption) *Server {
 	if logger == nil {
 		logger = slog.Default()
 	}
@@ -60,7 +127,8 @@ func NewServer(coinService CoinService, port string, logger *slog.Logger) *Serve
 	r.Use(cors.Handler(cors.Options{
 		AllowedOrigins:   []string{"*"}, // Разрешаем все origins для разработки
 		AllowedMethods:   []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
-		AllowedHeaders:   []string{"Accept", "Authorization", "Content-Type"},
+		AllowedHeaders:   []string{"Accept", "Authorization", "Content-Type", "X-API-Key", "If-None-Match", "If-Modified-Since"},
+		ExposedHeaders:   []string{"Retry-After", "ETag", "Last-Modified"},
 		AllowCredentials: true,
 		MaxAge:           300, // Максимальное время кеширования preflight запросов
 	}))
@@ -75,6 +143,10 @@ func NewServer(coinService CoinService, port string, logger *slog.Logger) *Serve
 		},
 	}
 
+	for _, opt := range opts {
+		opt(s)
+	}
+
 	s.initRoutes()
 	logger.Info("Server initialized", slog.String("port", port))
 	return s
@@ -103,13 +175,71 @@ func (s *Server) initRoutes() {
 		})
 	})
 
+	s.router.Get("/readyz", s.handleReadiness)
+	if s.metrics != nil {
+		s.router.Method(http.MethodGet, "/metrics", s.metrics)
+	}
+
 	s.router.Get("/api/v1/swagger/*", httpSwagger.Handler(
 		httpSwagger.URL("/api/v1/swagger/doc.json"),
 	))
 
 	s.router.Route("/api/v1", func(r chi.Router) {
-		r.Post("/coins/actual", s.handleGetActualCoins)
-		r.Post("/coins/aggregate/{aggFunc}", s.handleGetAggregateCoins)
+		r.With(s.rateLimit(routeCoinsActual)).Get("/coins", s.handleListCoins)
+		r.With(s.rateLimit(routeCoinsActual)).Get("/coins/{title}", s.handleGetCoin)
+		r.With(s.rateLimit(routeCoinsActual)).Post("/coins/actual", s.handleGetActualCoins)
+		r.With(s.rateLimit(routeCoinsActual)).Post("/coins/quotes", s.handleGetQuotes)
+		r.With(s.rateLimit(routeCoinsAggregate)).Get("/coins/{title}/indicators", s.handleGetIndicator)
+		r.With(s.rateLimit(routeCoinsAggregate)).Post("/coins/aggregate", s.handleAggregateCoins)
+		r.With(s.rateLimit(routeCoinsAggregate)).Post("/coins/aggregate/{aggFunc}", s.handleGetAggregateCoins)
+		r.Post("/batch", s.handleBatch)
+		r.Get("/convert", s.handleConvert)
+		r.With(s.rateLimit(routeCoinsActual)).Get("/tickers", s.handleListTickers)
+		r.With(s.rateLimit(routeCoinsAggregate)).Get("/market/movers", s.handleMarketMovers)
+		r.With(s.rateLimit(routeCoinsAggregate)).Get("/analytics/volatility", s.handleGetVolatility)
+		r.With(s.rateLimit(routeCoinsAggregate)).Get("/analytics/correlation", s.handleGetCorrelation)
+		r.With(s.rateLimit(routeCoinsAggregate)).Get("/export", s.handleExport)
+		if s.graphql != nil {
+			r.With(s.rateLimit(routeCoinsAggregate)).Post("/graphql", s.handleGraphQL)
+		}
+		if s.adminToken != "" {
+			r.Route("/admin", func(r chi.Router) {
+				r.Use(s.requireAdmin)
+				r.Post("/import", s.handleImport)
+			})
+		}
+		r.Route("/watchlist", func(r chi.Router) {
+			r.Get("/", s.handleGetWatchlist)
+			// изменения набора обновляемых монет и внеплановое обновление
+			// обращаются к провайдеру, поэтому доступны только администратору
+			if s.adminToken != "" {
+				r.Group(func(r chi.Router) {
+					r.Use(s.rateLimit(routeCoinsActual), s.requireAdmin)
+					r.Post("/", s.handleAddToWatchlist)
+					r.Post("/actualize", s.handleActualizeRates)
+					r.Delete("/{title}", s.handleRemoveFromWatchlist)
+				})
+			}
+		})
+
+		// портфели не привязаны к владельцу, поэтому доступны только администратору
+		if s.portfolios != nil && s.adminToken != "" {
+			r.Route("/portfolios", func(r chi.Router) {
+				r.Use(s.rateLimit(routeCoinsAggregate), s.requireAdmin)
+				r.Post("/", s.handleCreatePortfolio)
+				r.Get("/", s.handleListPortfolios)
+				r.Get("/{id}", s.handleGetPortfolio)
+				r.Post("/{id}/transactions", s.handleAddTransaction)
+				r.Get("/{id}/transactions", s.handleGetTransactions)
+				r.Get("/{id}/valuation", s.handleValuatePortfolio)
+			})
+		}
+
+		if s.stablecoins != nil {
+			r.Get("/stablecoins", s.handleListStablecoins)
+			r.With(s.rateLimit(routeCoinsAggregate)).Get("/stablecoins/{title}/history", s.handleGetDeviationHistory)
+			r.Get("/alerts", s.handleListAlerts)
+		}
 	})
 
 }
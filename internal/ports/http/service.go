@@ -3,9 +3,39 @@ package http
 import (
 	"Cryptoproject/internal/entities"
 	"context"
+	"time"
 )
 
 type CoinService interface {
 	GetLastRates(ctx context.Context, titles []string) ([]entities.Coin, error)
+	GetQuotes(ctx context.Context, titles []string) ([]entities.Quote, error)
 	GetRatesWithAgg(ctx context.Context, titles []string, aggFunc string) ([]entities.Coin, error)
+	GetHistory(ctx context.Context, title string, interval time.Duration, from, to time.Time) ([]entities.Candle, error)
+	Convert(ctx context.Context, from, to string, amount float64) (*entities.Conversion, error)
+	GetTickers(ctx context.Context, titles []string, order entities.TickerSort) ([]entities.Ticker, error)
+	GetMarketMovers(ctx context.Context, window time.Duration, limit int) (*entities.MarketOverview, error)
+	GetIndicator(ctx context.Context, query entities.IndicatorQuery) (*entities.IndicatorSeries, error)
+	GetVolatility(ctx context.Context, titles []string, window, interval time.Duration) ([]entities.Volatility, error)
+	GetCorrelation(ctx context.Context, titles []string, window, interval time.Duration) (*entities.CorrelationMatrix, error)
+	GetWatchlist(ctx context.Context) ([]string, error)
+	AddToWatchlist(ctx context.Context, titles []string) (int, error)
+	RemoveFromWatchlist(ctx context.Context, titles []string) (int, error)
+	ActualizeRates(ctx context.Context) error
+	Export(ctx context.Context, query entities.ExportQuery, fn func(entities.HistoryRecord) error) error
+	Import(ctx context.Context, source entities.CoinReader) (*entities.ImportReport, error)
+}
+
+type PortfolioService interface {
+	CreatePortfolio(ctx context.Context, name string) (*entities.Portfolio, error)
+	GetPortfolio(ctx context.Context, id int64) (*entities.Portfolio, error)
+	ListPortfolios(ctx context.Context) ([]entities.Portfolio, error)
+	AddTransaction(ctx context.Context, tx entities.Transaction) (*entities.Transaction, error)
+	GetTransactions(ctx context.Context, portfolioID int64) ([]entities.Transaction, error)
+	Valuate(ctx context.Context, portfolioID int64, at time.Time) (*entities.Valuation, error)
+}
+
+type StablecoinService interface {
+	GetDepegStatuses(ctx context.Context) ([]entities.DepegStatus, error)
+	GetDeviationHistory(ctx context.Context, title string, window, interval time.Duration) ([]entities.DeviationPoint, error)
+	GetAlerts(ctx context.Context, filter entities.AlertFilter) ([]entities.AlertEvent, error)
 }
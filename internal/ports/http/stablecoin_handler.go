@@ -0,0 +1,183 @@
+package http
+
+import (
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/pkg/errors"
+
+	"Cryptoproject/internal/entities"
+	"Cryptoproject/pkg/dto"
+)
+
+// handleListStablecoins godoc
+// @Summary Stablecoin peg deviations
+// @Description Returns the current deviation from the peg in basis points of every monitored stablecoin that has recent prices
+// @Tags stablecoins
+// @Produce json
+// @Success 200 {array} dto.DepegStatusResponse
+// @Failure 500 {object} dto.ProblemResponse
+// @Router /api/v1/stablecoins [get]
+func (s *Server) handleListStablecoins(w http.ResponseWriter, r *http.Request) {
+	const op = "http.handleListStablecoins"
+	startTime := time.Now()
+	logger := s.logger.With(slog.String("op", op))
+
+	statuses, err := s.stablecoins.GetDepegStatuses(r.Context())
+	if err != nil {
+		logger.Error("Service call failed",
+			slog.String("error", err.Error()),
+			slog.Duration("duration", time.Since(startTime)))
+		s.renderError(w, r, err)
+		return
+	}
+
+	response := make([]dto.DepegStatusResponse, 0, len(statuses))
+	for _, status := range statuses {
+		response = append(response, dto.DepegStatusResponse{
+			CoinName:      status.CoinName,
+			Peg:           status.Peg,
+			Price:         status.Price,
+			DeviationBps:  status.DeviationBps,
+			ObservedAt:    status.ObservedAt,
+			BreachedSince: status.BreachedSince,
+			Depegged:      status.Depegged,
+		})
+	}
+
+	logger.Info("Request processed successfully",
+		slog.Int("stablecoins_count", len(response)),
+		slog.Duration("duration", time.Since(startTime)))
+	s.renderResponse(w, http.StatusOK, response)
+}
+
+// handleGetDeviationHistory godoc
+// @Summary Stablecoin deviation history
+// @Description Returns the deviation from the peg in basis points at the close of every interval over the window
+// @Tags stablecoins
+// @Produce json
+// @Param title path string true "Stablecoin symbol" Example("USDT")
+// @Param window query string false "Window" Enums(7d, 30d, 90d, 365d) default(30d)
+// @Param interval query string false "Bucket size" Enums(5m, 15m, 1h, 4h, 1d) default(1h)
+// @Success 200 {object} dto.DeviationHistoryResponse
+// @Failure 400 {object} dto.ProblemResponse
+// @Failure 404 {object} dto.ProblemResponse
+// @Failure 500 {object} dto.ProblemResponse
+// @Router /api/v1/stablecoins/{title}/history [get]
+func (s *Server) handleGetDeviationHistory(w http.ResponseWriter, r *http.Request) {
+	const op = "http.handleGetDeviationHistory"
+	startTime := time.Now()
+	title := strings.TrimSpace(chi.URLParam(r, "title"))
+	logger := s.logger.With(
+		slog.String("op", op),
+		slog.String("title", title),
+	)
+
+	window, interval, err := parseAnalyticsParams(r)
+	if err != nil {
+		logger.Warn("Validation failed", slog.String("error", err.Error()))
+		s.renderError(w, r, err)
+		return
+	}
+
+	points, err := s.stablecoins.GetDeviationHistory(r.Context(), title, window, interval)
+	if err != nil {
+		logger.Error("Service call failed",
+			slog.String("error", err.Error()),
+			slog.Duration("duration", time.Since(startTime)))
+		s.renderError(w, r, err)
+		return
+	}
+
+	response := dto.DeviationHistoryResponse{
+		CoinName: strings.ToUpper(title),
+		Window:   queryParamOr(r, "window", "30d"),
+		Interval: queryParamOr(r, "interval", "1h"),
+		Points:   make([]dto.DeviationPointResponse, 0, len(points)),
+	}
+	for _, point := range points {
+		response.Points = append(response.Points, dto.DeviationPointResponse{
+			Time:         point.Time,
+			Price:        point.Price,
+			DeviationBps: point.DeviationBps,
+		})
+	}
+
+	logger.Info("Request processed successfully",
+		slog.Int("points_count", len(response.Points)),
+		slog.Duration("duration", time.Since(startTime)))
+	s.renderResponse(w, http.StatusOK, response)
+}
+
+// handleListAlerts godoc
+// @Summary Alert events
+// @Description Returns alert events raised by monitors, newest first
+// @Tags alerts
+// @Produce json
+// @Param coin query string false "Coin symbol" Example("USDC")
+// @Param kind query string false "Comma-separated event kinds" Example("depeg_started,depeg_recovered")
+// @Param since query string false "RFC3339 timestamp" Example("2025-01-01T00:00:00Z")
+// @Param limit query int false "Maximum number of events" default(100)
+// @Success 200 {array} dto.AlertEventResponse
+// @Failure 400 {object} dto.ProblemResponse
+// @Failure 500 {object} dto.ProblemResponse
+// @Router /api/v1/alerts [get]
+func (s *Server) handleListAlerts(w http.ResponseWriter, r *http.Request) {
+	const op = "http.handleListAlerts"
+	startTime := time.Now()
+	logger := s.logger.With(slog.String("op", op))
+
+	filter := entities.AlertFilter{CoinName: strings.ToUpper(strings.TrimSpace(r.URL.Query().Get("coin")))}
+	if kinds := strings.ReplaceAll(r.URL.Query().Get("kind"), " ", ""); kinds != "" {
+		for _, kind := range strings.Split(kinds, ",") {
+			if kind != "" {
+				filter.Kinds = append(filter.Kinds, entities.AlertKind(kind))
+			}
+		}
+	}
+	var err error
+	if sinceParam := r.URL.Query().Get("since"); sinceParam != "" {
+		if filter.Since, err = time.Parse(time.RFC3339, sinceParam); err != nil {
+			err = errors.Wrapf(entities.ErrInvalidParam, "invalid since: %q", sinceParam)
+			logger.Warn("Validation failed", slog.String("error", err.Error()))
+			s.renderError(w, r, err)
+			return
+		}
+	}
+	if filter.Limit, err = intQueryParam(r, "limit"); err != nil {
+		logger.Warn("Validation failed", slog.String("error", err.Error()))
+		s.renderError(w, r, err)
+		return
+	}
+
+	events, err := s.stablecoins.GetAlerts(r.Context(), filter)
+	if err != nil {
+		logger.Error("Service call failed",
+			slog.String("error", err.Error()),
+			slog.Duration("duration", time.Since(startTime)))
+		s.renderError(w, r, err)
+		return
+	}
+
+	response := make([]dto.AlertEventResponse, 0, len(events))
+	for _, event := range events {
+		response = append(response, dto.AlertEventResponse{
+			ID:        event.ID,
+			Kind:      string(event.Kind),
+			CoinName:  event.CoinName,
+			Price:     event.Price,
+			Value:     event.Value,
+			Threshold: event.Threshold,
+			Message:   event.Message,
+			CreatedAt: event.CreatedAt,
+		})
+	}
+
+	logger.Info("Request processed successfully",
+		slog.Int("events_count", len(response)),
+		slog.Duration("duration", time.Since(startTime)))
+	s.renderResponse(w, http.StatusOK, response)
+}
@@ -0,0 +1,73 @@
+package http
+
+import (
+	"log/slog"
+	"net/http"
+	"time"
+
+	"Cryptoproject/internal/entities"
+	"Cryptoproject/pkg/dto"
+)
+
+// handleListTickers godoc
+// @Summary List market tickers
+// @Description Returns the latest stored 24h market data. Without titles all tracked coins are returned. Prefix the sort field with "-" for descending order
+// @Tags tickers
+// @Produce json
+// @Param titles query string false "Comma-separated coin symbols" Example("BTC,ETH")
+// @Param sort query string false "Sort field: market_cap, change, volume or name" default(-market_cap)
+// @Success 200 {array} dto.TickerResponse
+// @Failure 400 {object} dto.ProblemResponse
+// @Failure 429 {object} dto.ProblemResponse
+// @Failure 500 {object} dto.ProblemResponse
+// @Router /api/v1/tickers [get]
+func (s *Server) handleListTickers(w http.ResponseWriter, r *http.Request) {
+	const op = "http.handleListTickers"
+	startTime := time.Now()
+	logger := s.logger.With(slog.String("op", op))
+
+	titles, err := titlesFromQuery(r)
+	if err != nil {
+		logger.Warn("Validation failed", slog.String("error", err.Error()))
+		s.renderError(w, r, err)
+		return
+	}
+	order, err := entities.ParseTickerSort(r.URL.Query().Get("sort"))
+	if err != nil {
+		logger.Warn("Validation failed", slog.String("error", err.Error()))
+		s.renderError(w, r, err)
+		return
+	}
+
+	tickers, err := s.coinService.GetTickers(r.Context(), titles, order)
+	if err != nil {
+		logger.Error("Service call failed",
+			slog.String("error", err.Error()),
+			slog.Duration("duration", time.Since(startTime)))
+		s.renderError(w, r, err)
+		return
+	}
+
+	response := make([]dto.TickerResponse, 0, len(tickers))
+	for _, ticker := range tickers {
+		response = append(response, dto.TickerResponse{
+			CoinName:     ticker.CoinName,
+			Currency:     ticker.Currency,
+			Source:       ticker.Source,
+			Price:        ticker.Price,
+			Volume24h:    ticker.Volume24h,
+			High24h:      ticker.High24h,
+			Low24h:       ticker.Low24h,
+			Change24hPct: ticker.Change24hPct,
+			MarketCap:    ticker.MarketCap,
+			Supply:       ticker.Supply,
+			ObservedAt:   ticker.ObservedAt,
+			UpdatedAt:    ticker.UpdatedAt,
+		})
+	}
+
+	logger.Info("Request processed successfully",
+		slog.Int("tickers_count", len(response)),
+		slog.Duration("duration", time.Since(startTime)))
+	s.renderResponse(w, http.StatusOK, response)
+}
@@ -0,0 +1,44 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"Cryptoproject/internal/entities"
+)
+
+func Test_ListTickers_Sort(t *testing.T) {
+	t.Parallel()
+
+	server := NewServer(&stubCoinService{}, "0", nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/tickers?sort=price", nil)
+	rec := httptest.NewRecorder()
+	server.router.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	server = NewServer(&stubCoinService{tickers: []entities.Ticker{
+		{CoinName: "BTC", Currency: "USD", MarketCap: 9.8e11, Change24hPct: 2.5},
+	}}, "0", nil)
+
+	req = httptest.NewRequest(http.MethodGet, "/api/v1/tickers?titles=BTC&sort=-change", nil)
+	rec = httptest.NewRecorder()
+	server.router.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"change_24h_pct":2.5`)
+}
+
+func Test_ListTickers_InvalidTitles(t *testing.T) {
+	t.Parallel()
+
+	server := NewServer(&stubCoinService{}, "0", nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/tickers?titles=btc,%24%24", nil)
+	rec := httptest.NewRecorder()
+	server.router.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
@@ -0,0 +1,131 @@
+package http
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/pkg/errors"
+
+	"Cryptoproject/internal/entities"
+	"Cryptoproject/pkg/dto"
+)
+
+// handleGetWatchlist godoc
+// @Summary List tracked coins
+// @Description Returns the coins refreshed by the scheduled actualization
+// @Tags watchlist
+// @Produce json
+// @Success 200 {object} dto.WatchlistResponse
+// @Failure 500 {object} dto.ProblemResponse
+// @Router /api/v1/watchlist [get]
+func (s *Server) handleGetWatchlist(w http.ResponseWriter, r *http.Request) {
+	const op = "http.handleGetWatchlist"
+	logger := s.logger.With(slog.String("op", op))
+
+	titles, err := s.coinService.GetWatchlist(r.Context())
+	if err != nil {
+		logger.Error("Service call failed", slog.String("error", err.Error()))
+		s.renderError(w, r, err)
+		return
+	}
+
+	s.renderResponse(w, http.StatusOK, dto.WatchlistResponse{Titles: append([]string{}, titles...)})
+}
+
+// handleAddToWatchlist godoc
+// @Summary Track coins
+// @Description Checks the coins against the provider and adds them to the watchlist
+// @Tags watchlist
+// @Accept json
+// @Produce json
+// @Security AdminToken
+// @Param request body dto.WatchlistRequest true "Coins to track"
+// @Success 200 {object} dto.WatchlistUpdateResponse
+// @Failure 400 {object} dto.ProblemResponse
+// @Failure 401 {object} dto.ProblemResponse
+// @Failure 404 {object} dto.ProblemResponse
+// @Failure 429 {object} dto.ProblemResponse
+// @Failure 500 {object} dto.ProblemResponse
+// @Failure 502 {object} dto.ProblemResponse
+// @Failure 503 {object} dto.ProblemResponse
+// @Router /api/v1/watchlist [post]
+func (s *Server) handleAddToWatchlist(w http.ResponseWriter, r *http.Request) {
+	const op = "http.handleAddToWatchlist"
+	logger := s.logger.With(slog.String("op", op))
+
+	var request dto.WatchlistRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		err = errors.Wrapf(entities.ErrInvalidParam, "invalid request body: %v", err)
+		logger.Warn("Validation failed", slog.String("error", err.Error()))
+		s.renderError(w, r, err)
+		return
+	}
+
+	added, err := s.coinService.AddToWatchlist(r.Context(), request.Titles)
+	if err != nil {
+		logger.Error("Service call failed", slog.String("error", err.Error()))
+		s.renderError(w, r, err)
+		return
+	}
+
+	s.renderResponse(w, http.StatusOK, dto.WatchlistUpdateResponse{Added: added})
+}
+
+// handleRemoveFromWatchlist godoc
+// @Summary Stop tracking coin
+// @Description Removes the coin from the watchlist, stored history is kept
+// @Tags watchlist
+// @Produce json
+// @Security AdminToken
+// @Param title path string true "Coin symbol" Example("BTC")
+// @Success 200 {object} dto.WatchlistUpdateResponse
+// @Failure 401 {object} dto.ProblemResponse
+// @Failure 404 {object} dto.ProblemResponse
+// @Failure 429 {object} dto.ProblemResponse
+// @Failure 500 {object} dto.ProblemResponse
+// @Router /api/v1/watchlist/{title} [delete]
+func (s *Server) handleRemoveFromWatchlist(w http.ResponseWriter, r *http.Request) {
+	const op = "http.handleRemoveFromWatchlist"
+	logger := s.logger.With(slog.String("op", op))
+
+	removed, err := s.coinService.RemoveFromWatchlist(r.Context(), []string{chi.URLParam(r, "title")})
+	if err != nil {
+		logger.Error("Service call failed", slog.String("error", err.Error()))
+		s.renderError(w, r, err)
+		return
+	}
+
+	s.renderResponse(w, http.StatusOK, dto.WatchlistUpdateResponse{Removed: removed})
+}
+
+// handleActualizeRates godoc
+// @Summary Refresh tracked coins
+// @Description Runs the rates actualization immediately instead of waiting for the schedule
+// @Tags watchlist
+// @Security AdminToken
+// @Success 204
+// @Failure 401 {object} dto.ProblemResponse
+// @Failure 429 {object} dto.ProblemResponse
+// @Failure 500 {object} dto.ProblemResponse
+// @Failure 502 {object} dto.ProblemResponse
+// @Failure 503 {object} dto.ProblemResponse
+// @Router /api/v1/watchlist/actualize [post]
+func (s *Server) handleActualizeRates(w http.ResponseWriter, r *http.Request) {
+	const op = "http.handleActualizeRates"
+	startTime := time.Now()
+	logger := s.logger.With(slog.String("op", op))
+
+	if err := s.coinService.ActualizeRates(r.Context()); err != nil {
+		logger.Error("Service call failed",
+			slog.String("error", err.Error()),
+			slog.Duration("duration", time.Since(startTime)))
+		s.renderError(w, r, err)
+		return
+	}
+
+	logger.Info("Rates actualized", slog.Duration("duration", time.Since(startTime)))
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,47 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"Cryptoproject/internal/ports/ratelimit"
+)
+
+func Test_Watchlist_ChangesRequireAdminToken(t *testing.T) {
+	t.Parallel()
+
+	server := NewServer(&stubCoinService{}, "0", nil)
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/watchlist/actualize", nil)
+	rec := httptest.NewRecorder()
+	server.router.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+
+	server = NewServer(&stubCoinService{}, "0", nil, WithAdminToken("secret"),
+		WithRateLimit(ratelimit.New(ratelimit.Config{Default: ratelimit.Limit{RPS: 1, Burst: 2}})))
+	req = httptest.NewRequest(http.MethodGet, "/api/v1/watchlist", nil)
+	rec = httptest.NewRecorder()
+	server.router.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	req = httptest.NewRequest(http.MethodDelete, "/api/v1/watchlist/BTC", nil)
+	rec = httptest.NewRecorder()
+	server.router.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+
+	req = httptest.NewRequest(http.MethodPost, "/api/v1/watchlist", strings.NewReader(`{"titles":["BTC"]}`))
+	req.Header.Set("Authorization", "Bearer secret")
+	rec = httptest.NewRecorder()
+	server.router.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	// лимит считается и для запросов без токена
+	req = httptest.NewRequest(http.MethodPost, "/api/v1/watchlist/actualize", nil)
+	req.Header.Set("Authorization", "Bearer secret")
+	rec = httptest.NewRecorder()
+	server.router.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+}
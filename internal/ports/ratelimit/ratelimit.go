@@ -0,0 +1,127 @@
+// Package ratelimit implements per-client token bucket limiting shared by the API ports.
+package ratelimit
+
+import (
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// Routes limited separately; the HTTP and gRPC ports map their endpoints onto them,
+// so a client cannot double its quota by switching protocols.
+const (
+	RouteCoinsActual    = "coins_actual"
+	RouteCoinsAggregate = "coins_aggregate"
+
+	defaultIdleTTL = 10 * time.Minute
+)
+
+// Limit describes a token bucket: RPS tokens are added per second up to Burst.
+type Limit struct {
+	RPS   float64
+	Burst int
+}
+
+// Config configures per-client rate limiting.
+// Clients are identified by KeyHeader (API key) when it holds one of APIKeys,
+// otherwise by IP, so made-up keys cannot get a fresh bucket each.
+type Config struct {
+	Default   Limit
+	Routes    map[string]Limit
+	KeyHeader string
+	APIKeys   []string
+	IdleTTL   time.Duration
+}
+
+type Limiter struct {
+	cfg       Config
+	apiKeys   map[string]struct{}
+	now       func() time.Time
+	mu        sync.Mutex
+	buckets   map[string]*bucket
+	lastSweep time.Time
+}
+
+type bucket struct {
+	limiter  *rate.Limiter
+	lastSeen time.Time
+}
+
+func New(cfg Config) *Limiter {
+	if cfg.IdleTTL <= 0 {
+		cfg.IdleTTL = defaultIdleTTL
+	}
+	apiKeys := make(map[string]struct{}, len(cfg.APIKeys))
+	for _, key := range cfg.APIKeys {
+		if key != "" {
+			apiKeys[key] = struct{}{}
+		}
+	}
+	return &Limiter{
+		cfg:     cfg,
+		apiKeys: apiKeys,
+		now:     time.Now,
+		buckets: make(map[string]*bucket),
+	}
+}
+
+// KeyHeader returns the header (or gRPC metadata key) carrying the client API key.
+func (l *Limiter) KeyHeader() string {
+	return l.cfg.KeyHeader
+}
+
+// ClientKey identifies a client by its API key when the key is known and by host otherwise.
+func (l *Limiter) ClientKey(apiKey, host string) string {
+	// неизвестный ключ не даёт отдельного bucket, иначе лимит обходится сменой ключа
+	if apiKey != "" {
+		if _, ok := l.apiKeys[apiKey]; ok {
+			return "key:" + apiKey
+		}
+	}
+	return "ip:" + host
+}
+
+func (l *Limiter) limitFor(route string) Limit {
+	if limit, ok := l.cfg.Routes[route]; ok {
+		return limit
+	}
+	return l.cfg.Default
+}
+
+// Allow takes a token from the client's bucket for route and reports
+// how long the client has to wait when the bucket is empty.
+func (l *Limiter) Allow(route, client string) (bool, time.Duration) {
+	limit := l.limitFor(route)
+	now := l.now()
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if now.Sub(l.lastSweep) >= l.cfg.IdleTTL {
+		for key, b := range l.buckets {
+			if now.Sub(b.lastSeen) >= l.cfg.IdleTTL {
+				delete(l.buckets, key)
+			}
+		}
+		l.lastSweep = now
+	}
+
+	key := route + "|" + client
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &bucket{limiter: rate.NewLimiter(rate.Limit(limit.RPS), limit.Burst)}
+		l.buckets[key] = b
+	}
+	b.lastSeen = now
+
+	reservation := b.limiter.ReserveN(now, 1)
+	if !reservation.OK() {
+		return false, time.Second
+	}
+	if delay := reservation.DelayFrom(now); delay > 0 {
+		reservation.CancelAt(now)
+		return false, delay
+	}
+	return true, 0
+}
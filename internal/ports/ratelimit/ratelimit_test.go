@@ -0,0 +1,67 @@
+package ratelimit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Limiter_BurstExhausted(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	limiter := New(Config{
+		Default: Limit{RPS: 1, Burst: 2},
+	})
+	limiter.now = func() time.Time { return now }
+
+	ok, _ := limiter.Allow(RouteCoinsActual, "ip:1.1.1.1")
+	require.True(t, ok)
+	ok, _ = limiter.Allow(RouteCoinsActual, "ip:1.1.1.1")
+	require.True(t, ok)
+
+	ok, retryAfter := limiter.Allow(RouteCoinsActual, "ip:1.1.1.1")
+	require.False(t, ok)
+	assert.Equal(t, time.Second, retryAfter)
+
+	// другой клиент имеет собственный bucket
+	ok, _ = limiter.Allow(RouteCoinsActual, "ip:2.2.2.2")
+	assert.True(t, ok)
+
+	now = now.Add(time.Second)
+	ok, _ = limiter.Allow(RouteCoinsActual, "ip:1.1.1.1")
+	assert.True(t, ok)
+}
+
+func Test_Limiter_RouteOverride(t *testing.T) {
+	t.Parallel()
+
+	limiter := New(Config{
+		Default: Limit{RPS: 100, Burst: 100},
+		Routes: map[string]Limit{
+			RouteCoinsActual: {RPS: 0.5, Burst: 1},
+		},
+	})
+
+	ok, _ := limiter.Allow(RouteCoinsActual, "ip:1.1.1.1")
+	require.True(t, ok)
+	ok, retryAfter := limiter.Allow(RouteCoinsActual, "ip:1.1.1.1")
+	require.False(t, ok)
+	assert.InDelta(t, 2*time.Second, retryAfter, float64(100*time.Millisecond))
+
+	ok, _ = limiter.Allow(RouteCoinsAggregate, "ip:1.1.1.1")
+	assert.True(t, ok)
+}
+
+func Test_Limiter_ClientKey(t *testing.T) {
+	t.Parallel()
+
+	limiter := New(Config{KeyHeader: "X-API-Key", APIKeys: []string{"secret"}})
+
+	assert.Equal(t, "ip:10.0.0.1", limiter.ClientKey("", "10.0.0.1"))
+	assert.Equal(t, "key:secret", limiter.ClientKey("secret", "10.0.0.1"))
+	// неизвестные ключи не обходят лимит клиента по IP
+	assert.Equal(t, "ip:10.0.0.1", limiter.ClientKey("random-1", "10.0.0.1"))
+}
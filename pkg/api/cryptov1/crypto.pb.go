@@ -0,0 +1,871 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: crypto/v1/crypto.proto
+
+package cryptov1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type AggregateFunction int32
+
+const (
+	AggregateFunction_AGGREGATE_FUNCTION_UNSPECIFIED AggregateFunction = 0
+	AggregateFunction_AGGREGATE_FUNCTION_AVG         AggregateFunction = 1
+	AggregateFunction_AGGREGATE_FUNCTION_MAX         AggregateFunction = 2
+	AggregateFunction_AGGREGATE_FUNCTION_MIN         AggregateFunction = 3
+)
+
+// Enum value maps for AggregateFunction.
+var (
+	AggregateFunction_name = map[int32]string{
+		0: "AGGREGATE_FUNCTION_UNSPECIFIED",
+		1: "AGGREGATE_FUNCTION_AVG",
+		2: "AGGREGATE_FUNCTION_MAX",
+		3: "AGGREGATE_FUNCTION_MIN",
+	}
+	AggregateFunction_value = map[string]int32{
+		"AGGREGATE_FUNCTION_UNSPECIFIED": 0,
+		"AGGREGATE_FUNCTION_AVG":         1,
+		"AGGREGATE_FUNCTION_MAX":         2,
+		"AGGREGATE_FUNCTION_MIN":         3,
+	}
+)
+
+func (x AggregateFunction) Enum() *AggregateFunction {
+	p := new(AggregateFunction)
+	*p = x
+	return p
+}
+
+func (x AggregateFunction) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (AggregateFunction) Descriptor() protoreflect.EnumDescriptor {
+	return file_crypto_v1_crypto_proto_enumTypes[0].Descriptor()
+}
+
+func (AggregateFunction) Type() protoreflect.EnumType {
+	return &file_crypto_v1_crypto_proto_enumTypes[0]
+}
+
+func (x AggregateFunction) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use AggregateFunction.Descriptor instead.
+func (AggregateFunction) EnumDescriptor() ([]byte, []int) {
+	return file_crypto_v1_crypto_proto_rawDescGZIP(), []int{0}
+}
+
+type Coin struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	CoinName string                 `protobuf:"bytes,1,opt,name=coin_name,json=coinName,proto3" json:"coin_name,omitempty"`
+	Price    float64                `protobuf:"fixed64,2,opt,name=price,proto3" json:"price,omitempty"`
+	Currency string                 `protobuf:"bytes,3,opt,name=currency,proto3" json:"currency,omitempty"`
+	Source   string                 `protobuf:"bytes,4,opt,name=source,proto3" json:"source,omitempty"`
+	// Time the provider observed the price on the market.
+	ObservedAt *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=observed_at,json=observedAt,proto3" json:"observed_at,omitempty"`
+	// Time the price was ingested into storage.
+	CreatedAt *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	// Set when the provider failed and the last stored price is served instead.
+	Stale bool `protobuf:"varint,7,opt,name=stale,proto3" json:"stale,omitempty"`
+	// Age of a stale price when it was served.
+	Age           *durationpb.Duration `protobuf:"bytes,8,opt,name=age,proto3" json:"age,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Coin) Reset() {
+	*x = Coin{}
+	mi := &file_crypto_v1_crypto_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Coin) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Coin) ProtoMessage() {}
+
+func (x *Coin) ProtoReflect() protoreflect.Message {
+	mi := &file_crypto_v1_crypto_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Coin.ProtoReflect.Descriptor instead.
+func (*Coin) Descriptor() ([]byte, []int) {
+	return file_crypto_v1_crypto_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Coin) GetCoinName() string {
+	if x != nil {
+		return x.CoinName
+	}
+	return ""
+}
+
+func (x *Coin) GetPrice() float64 {
+	if x != nil {
+		return x.Price
+	}
+	return 0
+}
+
+func (x *Coin) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *Coin) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+func (x *Coin) GetObservedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ObservedAt
+	}
+	return nil
+}
+
+func (x *Coin) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Coin) GetStale() bool {
+	if x != nil {
+		return x.Stale
+	}
+	return false
+}
+
+func (x *Coin) GetAge() *durationpb.Duration {
+	if x != nil {
+		return x.Age
+	}
+	return nil
+}
+
+type GetLastRatesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Titles        []string               `protobuf:"bytes,1,rep,name=titles,proto3" json:"titles,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetLastRatesRequest) Reset() {
+	*x = GetLastRatesRequest{}
+	mi := &file_crypto_v1_crypto_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetLastRatesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLastRatesRequest) ProtoMessage() {}
+
+func (x *GetLastRatesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_crypto_v1_crypto_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLastRatesRequest.ProtoReflect.Descriptor instead.
+func (*GetLastRatesRequest) Descriptor() ([]byte, []int) {
+	return file_crypto_v1_crypto_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *GetLastRatesRequest) GetTitles() []string {
+	if x != nil {
+		return x.Titles
+	}
+	return nil
+}
+
+type GetLastRatesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Coins         []*Coin                `protobuf:"bytes,1,rep,name=coins,proto3" json:"coins,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetLastRatesResponse) Reset() {
+	*x = GetLastRatesResponse{}
+	mi := &file_crypto_v1_crypto_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetLastRatesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLastRatesResponse) ProtoMessage() {}
+
+func (x *GetLastRatesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_crypto_v1_crypto_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLastRatesResponse.ProtoReflect.Descriptor instead.
+func (*GetLastRatesResponse) Descriptor() ([]byte, []int) {
+	return file_crypto_v1_crypto_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GetLastRatesResponse) GetCoins() []*Coin {
+	if x != nil {
+		return x.Coins
+	}
+	return nil
+}
+
+type GetAggregateRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Titles        []string               `protobuf:"bytes,1,rep,name=titles,proto3" json:"titles,omitempty"`
+	Function      AggregateFunction      `protobuf:"varint,2,opt,name=function,proto3,enum=crypto.v1.AggregateFunction" json:"function,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAggregateRequest) Reset() {
+	*x = GetAggregateRequest{}
+	mi := &file_crypto_v1_crypto_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAggregateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAggregateRequest) ProtoMessage() {}
+
+func (x *GetAggregateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_crypto_v1_crypto_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAggregateRequest.ProtoReflect.Descriptor instead.
+func (*GetAggregateRequest) Descriptor() ([]byte, []int) {
+	return file_crypto_v1_crypto_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GetAggregateRequest) GetTitles() []string {
+	if x != nil {
+		return x.Titles
+	}
+	return nil
+}
+
+func (x *GetAggregateRequest) GetFunction() AggregateFunction {
+	if x != nil {
+		return x.Function
+	}
+	return AggregateFunction_AGGREGATE_FUNCTION_UNSPECIFIED
+}
+
+type AggregateCoin struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CoinName      string                 `protobuf:"bytes,1,opt,name=coin_name,json=coinName,proto3" json:"coin_name,omitempty"`
+	Price         float64                `protobuf:"fixed64,2,opt,name=price,proto3" json:"price,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AggregateCoin) Reset() {
+	*x = AggregateCoin{}
+	mi := &file_crypto_v1_crypto_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AggregateCoin) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AggregateCoin) ProtoMessage() {}
+
+func (x *AggregateCoin) ProtoReflect() protoreflect.Message {
+	mi := &file_crypto_v1_crypto_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AggregateCoin.ProtoReflect.Descriptor instead.
+func (*AggregateCoin) Descriptor() ([]byte, []int) {
+	return file_crypto_v1_crypto_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *AggregateCoin) GetCoinName() string {
+	if x != nil {
+		return x.CoinName
+	}
+	return ""
+}
+
+func (x *AggregateCoin) GetPrice() float64 {
+	if x != nil {
+		return x.Price
+	}
+	return 0
+}
+
+type GetAggregateResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Coins         []*AggregateCoin       `protobuf:"bytes,1,rep,name=coins,proto3" json:"coins,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAggregateResponse) Reset() {
+	*x = GetAggregateResponse{}
+	mi := &file_crypto_v1_crypto_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAggregateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAggregateResponse) ProtoMessage() {}
+
+func (x *GetAggregateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_crypto_v1_crypto_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAggregateResponse.ProtoReflect.Descriptor instead.
+func (*GetAggregateResponse) Descriptor() ([]byte, []int) {
+	return file_crypto_v1_crypto_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *GetAggregateResponse) GetCoins() []*AggregateCoin {
+	if x != nil {
+		return x.Coins
+	}
+	return nil
+}
+
+type GetHistoryRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Title string                 `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty"`
+	// Candle interval: 5m, 15m, 1h, 4h or 1d; 1h by default.
+	Interval string                 `protobuf:"bytes,2,opt,name=interval,proto3" json:"interval,omitempty"`
+	From     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=from,proto3" json:"from,omitempty"`
+	// Exclusive; now by default.
+	To            *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=to,proto3" json:"to,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetHistoryRequest) Reset() {
+	*x = GetHistoryRequest{}
+	mi := &file_crypto_v1_crypto_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetHistoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetHistoryRequest) ProtoMessage() {}
+
+func (x *GetHistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_crypto_v1_crypto_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetHistoryRequest.ProtoReflect.Descriptor instead.
+func (*GetHistoryRequest) Descriptor() ([]byte, []int) {
+	return file_crypto_v1_crypto_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *GetHistoryRequest) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *GetHistoryRequest) GetInterval() string {
+	if x != nil {
+		return x.Interval
+	}
+	return ""
+}
+
+func (x *GetHistoryRequest) GetFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.From
+	}
+	return nil
+}
+
+func (x *GetHistoryRequest) GetTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.To
+	}
+	return nil
+}
+
+type Candle struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Bucket        *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=bucket,proto3" json:"bucket,omitempty"`
+	Open          float64                `protobuf:"fixed64,2,opt,name=open,proto3" json:"open,omitempty"`
+	High          float64                `protobuf:"fixed64,3,opt,name=high,proto3" json:"high,omitempty"`
+	Low           float64                `protobuf:"fixed64,4,opt,name=low,proto3" json:"low,omitempty"`
+	Close         float64                `protobuf:"fixed64,5,opt,name=close,proto3" json:"close,omitempty"`
+	Avg           float64                `protobuf:"fixed64,6,opt,name=avg,proto3" json:"avg,omitempty"`
+	Count         int64                  `protobuf:"varint,7,opt,name=count,proto3" json:"count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Candle) Reset() {
+	*x = Candle{}
+	mi := &file_crypto_v1_crypto_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Candle) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Candle) ProtoMessage() {}
+
+func (x *Candle) ProtoReflect() protoreflect.Message {
+	mi := &file_crypto_v1_crypto_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Candle.ProtoReflect.Descriptor instead.
+func (*Candle) Descriptor() ([]byte, []int) {
+	return file_crypto_v1_crypto_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *Candle) GetBucket() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Bucket
+	}
+	return nil
+}
+
+func (x *Candle) GetOpen() float64 {
+	if x != nil {
+		return x.Open
+	}
+	return 0
+}
+
+func (x *Candle) GetHigh() float64 {
+	if x != nil {
+		return x.High
+	}
+	return 0
+}
+
+func (x *Candle) GetLow() float64 {
+	if x != nil {
+		return x.Low
+	}
+	return 0
+}
+
+func (x *Candle) GetClose() float64 {
+	if x != nil {
+		return x.Close
+	}
+	return 0
+}
+
+func (x *Candle) GetAvg() float64 {
+	if x != nil {
+		return x.Avg
+	}
+	return 0
+}
+
+func (x *Candle) GetCount() int64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+type GetHistoryResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CoinName      string                 `protobuf:"bytes,1,opt,name=coin_name,json=coinName,proto3" json:"coin_name,omitempty"`
+	Candles       []*Candle              `protobuf:"bytes,2,rep,name=candles,proto3" json:"candles,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetHistoryResponse) Reset() {
+	*x = GetHistoryResponse{}
+	mi := &file_crypto_v1_crypto_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetHistoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetHistoryResponse) ProtoMessage() {}
+
+func (x *GetHistoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_crypto_v1_crypto_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetHistoryResponse.ProtoReflect.Descriptor instead.
+func (*GetHistoryResponse) Descriptor() ([]byte, []int) {
+	return file_crypto_v1_crypto_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *GetHistoryResponse) GetCoinName() string {
+	if x != nil {
+		return x.CoinName
+	}
+	return ""
+}
+
+func (x *GetHistoryResponse) GetCandles() []*Candle {
+	if x != nil {
+		return x.Candles
+	}
+	return nil
+}
+
+type WatchPricesRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Titles []string               `protobuf:"bytes,1,rep,name=titles,proto3" json:"titles,omitempty"`
+	// How often prices are checked; the server refresh interval by default.
+	Interval      *durationpb.Duration `protobuf:"bytes,2,opt,name=interval,proto3" json:"interval,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchPricesRequest) Reset() {
+	*x = WatchPricesRequest{}
+	mi := &file_crypto_v1_crypto_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchPricesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchPricesRequest) ProtoMessage() {}
+
+func (x *WatchPricesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_crypto_v1_crypto_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchPricesRequest.ProtoReflect.Descriptor instead.
+func (*WatchPricesRequest) Descriptor() ([]byte, []int) {
+	return file_crypto_v1_crypto_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *WatchPricesRequest) GetTitles() []string {
+	if x != nil {
+		return x.Titles
+	}
+	return nil
+}
+
+func (x *WatchPricesRequest) GetInterval() *durationpb.Duration {
+	if x != nil {
+		return x.Interval
+	}
+	return nil
+}
+
+type WatchPricesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Coin          *Coin                  `protobuf:"bytes,1,opt,name=coin,proto3" json:"coin,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchPricesResponse) Reset() {
+	*x = WatchPricesResponse{}
+	mi := &file_crypto_v1_crypto_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchPricesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchPricesResponse) ProtoMessage() {}
+
+func (x *WatchPricesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_crypto_v1_crypto_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchPricesResponse.ProtoReflect.Descriptor instead.
+func (*WatchPricesResponse) Descriptor() ([]byte, []int) {
+	return file_crypto_v1_crypto_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *WatchPricesResponse) GetCoin() *Coin {
+	if x != nil {
+		return x.Coin
+	}
+	return nil
+}
+
+var File_crypto_v1_crypto_proto protoreflect.FileDescriptor
+
+const file_crypto_v1_crypto_proto_rawDesc = "" +
+	"\n" +
+	"\x16crypto/v1/crypto.proto\x12\tcrypto.v1\x1a\x1egoogle/protobuf/duration.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\xa8\x02\n" +
+	"\x04Coin\x12\x1b\n" +
+	"\tcoin_name\x18\x01 \x01(\tR\bcoinName\x12\x14\n" +
+	"\x05price\x18\x02 \x01(\x01R\x05price\x12\x1a\n" +
+	"\bcurrency\x18\x03 \x01(\tR\bcurrency\x12\x16\n" +
+	"\x06source\x18\x04 \x01(\tR\x06source\x12;\n" +
+	"\vobserved_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"observedAt\x129\n" +
+	"\n" +
+	"created_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12\x14\n" +
+	"\x05stale\x18\a \x01(\bR\x05stale\x12+\n" +
+	"\x03age\x18\b \x01(\v2\x19.google.protobuf.DurationR\x03age\"-\n" +
+	"\x13GetLastRatesRequest\x12\x16\n" +
+	"\x06titles\x18\x01 \x03(\tR\x06titles\"=\n" +
+	"\x14GetLastRatesResponse\x12%\n" +
+	"\x05coins\x18\x01 \x03(\v2\x0f.crypto.v1.CoinR\x05coins\"g\n" +
+	"\x13GetAggregateRequest\x12\x16\n" +
+	"\x06titles\x18\x01 \x03(\tR\x06titles\x128\n" +
+	"\bfunction\x18\x02 \x01(\x0e2\x1c.crypto.v1.AggregateFunctionR\bfunction\"B\n" +
+	"\rAggregateCoin\x12\x1b\n" +
+	"\tcoin_name\x18\x01 \x01(\tR\bcoinName\x12\x14\n" +
+	"\x05price\x18\x02 \x01(\x01R\x05price\"F\n" +
+	"\x14GetAggregateResponse\x12.\n" +
+	"\x05coins\x18\x01 \x03(\v2\x18.crypto.v1.AggregateCoinR\x05coins\"\xa1\x01\n" +
+	"\x11GetHistoryRequest\x12\x14\n" +
+	"\x05title\x18\x01 \x01(\tR\x05title\x12\x1a\n" +
+	"\binterval\x18\x02 \x01(\tR\binterval\x12.\n" +
+	"\x04from\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x04from\x12*\n" +
+	"\x02to\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\x02to\"\xb4\x01\n" +
+	"\x06Candle\x122\n" +
+	"\x06bucket\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\x06bucket\x12\x12\n" +
+	"\x04open\x18\x02 \x01(\x01R\x04open\x12\x12\n" +
+	"\x04high\x18\x03 \x01(\x01R\x04high\x12\x10\n" +
+	"\x03low\x18\x04 \x01(\x01R\x03low\x12\x14\n" +
+	"\x05close\x18\x05 \x01(\x01R\x05close\x12\x10\n" +
+	"\x03avg\x18\x06 \x01(\x01R\x03avg\x12\x14\n" +
+	"\x05count\x18\a \x01(\x03R\x05count\"^\n" +
+	"\x12GetHistoryResponse\x12\x1b\n" +
+	"\tcoin_name\x18\x01 \x01(\tR\bcoinName\x12+\n" +
+	"\acandles\x18\x02 \x03(\v2\x11.crypto.v1.CandleR\acandles\"c\n" +
+	"\x12WatchPricesRequest\x12\x16\n" +
+	"\x06titles\x18\x01 \x03(\tR\x06titles\x125\n" +
+	"\binterval\x18\x02 \x01(\v2\x19.google.protobuf.DurationR\binterval\":\n" +
+	"\x13WatchPricesResponse\x12#\n" +
+	"\x04coin\x18\x01 \x01(\v2\x0f.crypto.v1.CoinR\x04coin*\x8b\x01\n" +
+	"\x11AggregateFunction\x12\"\n" +
+	"\x1eAGGREGATE_FUNCTION_UNSPECIFIED\x10\x00\x12\x1a\n" +
+	"\x16AGGREGATE_FUNCTION_AVG\x10\x01\x12\x1a\n" +
+	"\x16AGGREGATE_FUNCTION_MAX\x10\x02\x12\x1a\n" +
+	"\x16AGGREGATE_FUNCTION_MIN\x10\x032\xca\x02\n" +
+	"\vCoinService\x12O\n" +
+	"\fGetLastRates\x12\x1e.crypto.v1.GetLastRatesRequest\x1a\x1f.crypto.v1.GetLastRatesResponse\x12O\n" +
+	"\fGetAggregate\x12\x1e.crypto.v1.GetAggregateRequest\x1a\x1f.crypto.v1.GetAggregateResponse\x12I\n" +
+	"\n" +
+	"GetHistory\x12\x1c.crypto.v1.GetHistoryRequest\x1a\x1d.crypto.v1.GetHistoryResponse\x12N\n" +
+	"\vWatchPrices\x12\x1d.crypto.v1.WatchPricesRequest\x1a\x1e.crypto.v1.WatchPricesResponse0\x01B)Z'Cryptoproject/pkg/api/cryptov1;cryptov1b\x06proto3"
+
+var (
+	file_crypto_v1_crypto_proto_rawDescOnce sync.Once
+	file_crypto_v1_crypto_proto_rawDescData []byte
+)
+
+func file_crypto_v1_crypto_proto_rawDescGZIP() []byte {
+	file_crypto_v1_crypto_proto_rawDescOnce.Do(func() {
+		file_crypto_v1_crypto_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_crypto_v1_crypto_proto_rawDesc), len(file_crypto_v1_crypto_proto_rawDesc)))
+	})
+	return file_crypto_v1_crypto_proto_rawDescData
+}
+
+var file_crypto_v1_crypto_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_crypto_v1_crypto_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
+var file_crypto_v1_crypto_proto_goTypes = []any{
+	(AggregateFunction)(0),        // 0: crypto.v1.AggregateFunction
+	(*Coin)(nil),                  // 1: crypto.v1.Coin
+	(*GetLastRatesRequest)(nil),   // 2: crypto.v1.GetLastRatesRequest
+	(*GetLastRatesResponse)(nil),  // 3: crypto.v1.GetLastRatesResponse
+	(*GetAggregateRequest)(nil),   // 4: crypto.v1.GetAggregateRequest
+	(*AggregateCoin)(nil),         // 5: crypto.v1.AggregateCoin
+	(*GetAggregateResponse)(nil),  // 6: crypto.v1.GetAggregateResponse
+	(*GetHistoryRequest)(nil),     // 7: crypto.v1.GetHistoryRequest
+	(*Candle)(nil),                // 8: crypto.v1.Candle
+	(*GetHistoryResponse)(nil),    // 9: crypto.v1.GetHistoryResponse
+	(*WatchPricesRequest)(nil),    // 10: crypto.v1.WatchPricesRequest
+	(*WatchPricesResponse)(nil),   // 11: crypto.v1.WatchPricesResponse
+	(*timestamppb.Timestamp)(nil), // 12: google.protobuf.Timestamp
+	(*durationpb.Duration)(nil),   // 13: google.protobuf.Duration
+}
+var file_crypto_v1_crypto_proto_depIdxs = []int32{
+	12, // 0: crypto.v1.Coin.observed_at:type_name -> google.protobuf.Timestamp
+	12, // 1: crypto.v1.Coin.created_at:type_name -> google.protobuf.Timestamp
+	13, // 2: crypto.v1.Coin.age:type_name -> google.protobuf.Duration
+	1,  // 3: crypto.v1.GetLastRatesResponse.coins:type_name -> crypto.v1.Coin
+	0,  // 4: crypto.v1.GetAggregateRequest.function:type_name -> crypto.v1.AggregateFunction
+	5,  // 5: crypto.v1.GetAggregateResponse.coins:type_name -> crypto.v1.AggregateCoin
+	12, // 6: crypto.v1.GetHistoryRequest.from:type_name -> google.protobuf.Timestamp
+	12, // 7: crypto.v1.GetHistoryRequest.to:type_name -> google.protobuf.Timestamp
+	12, // 8: crypto.v1.Candle.bucket:type_name -> google.protobuf.Timestamp
+	8,  // 9: crypto.v1.GetHistoryResponse.candles:type_name -> crypto.v1.Candle
+	13, // 10: crypto.v1.WatchPricesRequest.interval:type_name -> google.protobuf.Duration
+	1,  // 11: crypto.v1.WatchPricesResponse.coin:type_name -> crypto.v1.Coin
+	2,  // 12: crypto.v1.CoinService.GetLastRates:input_type -> crypto.v1.GetLastRatesRequest
+	4,  // 13: crypto.v1.CoinService.GetAggregate:input_type -> crypto.v1.GetAggregateRequest
+	7,  // 14: crypto.v1.CoinService.GetHistory:input_type -> crypto.v1.GetHistoryRequest
+	10, // 15: crypto.v1.CoinService.WatchPrices:input_type -> crypto.v1.WatchPricesRequest
+	3,  // 16: crypto.v1.CoinService.GetLastRates:output_type -> crypto.v1.GetLastRatesResponse
+	6,  // 17: crypto.v1.CoinService.GetAggregate:output_type -> crypto.v1.GetAggregateResponse
+	9,  // 18: crypto.v1.CoinService.GetHistory:output_type -> crypto.v1.GetHistoryResponse
+	11, // 19: crypto.v1.CoinService.WatchPrices:output_type -> crypto.v1.WatchPricesResponse
+	16, // [16:20] is the sub-list for method output_type
+	12, // [12:16] is the sub-list for method input_type
+	12, // [12:12] is the sub-list for extension type_name
+	12, // [12:12] is the sub-list for extension extendee
+	0,  // [0:12] is the sub-list for field type_name
+}
+
+func init() { file_crypto_v1_crypto_proto_init() }
+func file_crypto_v1_crypto_proto_init() {
+	if File_crypto_v1_crypto_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_crypto_v1_crypto_proto_rawDesc), len(file_crypto_v1_crypto_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   11,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_crypto_v1_crypto_proto_goTypes,
+		DependencyIndexes: file_crypto_v1_crypto_proto_depIdxs,
+		EnumInfos:         file_crypto_v1_crypto_proto_enumTypes,
+		MessageInfos:      file_crypto_v1_crypto_proto_msgTypes,
+	}.Build()
+	File_crypto_v1_crypto_proto = out.File
+	file_crypto_v1_crypto_proto_goTypes = nil
+	file_crypto_v1_crypto_proto_depIdxs = nil
+}
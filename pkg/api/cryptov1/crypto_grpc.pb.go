@@ -0,0 +1,253 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: crypto/v1/crypto.proto
+
+package cryptov1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	CoinService_GetLastRates_FullMethodName = "/crypto.v1.CoinService/GetLastRates"
+	CoinService_GetAggregate_FullMethodName = "/crypto.v1.CoinService/GetAggregate"
+	CoinService_GetHistory_FullMethodName   = "/crypto.v1.CoinService/GetHistory"
+	CoinService_WatchPrices_FullMethodName  = "/crypto.v1.CoinService/WatchPrices"
+)
+
+// CoinServiceClient is the client API for CoinService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// CoinService exposes the same operations as the HTTP API.
+type CoinServiceClient interface {
+	// GetLastRates returns the latest prices of the requested coins.
+	GetLastRates(ctx context.Context, in *GetLastRatesRequest, opts ...grpc.CallOption) (*GetLastRatesResponse, error)
+	// GetAggregate returns the average, maximum or minimum stored price of the requested coins.
+	GetAggregate(ctx context.Context, in *GetAggregateRequest, opts ...grpc.CallOption) (*GetAggregateResponse, error)
+	// GetHistory returns the price history of a coin as candles.
+	GetHistory(ctx context.Context, in *GetHistoryRequest, opts ...grpc.CallOption) (*GetHistoryResponse, error)
+	// WatchPrices streams the prices of the requested coins: the current ones
+	// first, then every new observation.
+	WatchPrices(ctx context.Context, in *WatchPricesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[WatchPricesResponse], error)
+}
+
+type coinServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewCoinServiceClient(cc grpc.ClientConnInterface) CoinServiceClient {
+	return &coinServiceClient{cc}
+}
+
+func (c *coinServiceClient) GetLastRates(ctx context.Context, in *GetLastRatesRequest, opts ...grpc.CallOption) (*GetLastRatesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetLastRatesResponse)
+	err := c.cc.Invoke(ctx, CoinService_GetLastRates_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *coinServiceClient) GetAggregate(ctx context.Context, in *GetAggregateRequest, opts ...grpc.CallOption) (*GetAggregateResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetAggregateResponse)
+	err := c.cc.Invoke(ctx, CoinService_GetAggregate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *coinServiceClient) GetHistory(ctx context.Context, in *GetHistoryRequest, opts ...grpc.CallOption) (*GetHistoryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetHistoryResponse)
+	err := c.cc.Invoke(ctx, CoinService_GetHistory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *coinServiceClient) WatchPrices(ctx context.Context, in *WatchPricesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[WatchPricesResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &CoinService_ServiceDesc.Streams[0], CoinService_WatchPrices_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchPricesRequest, WatchPricesResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type CoinService_WatchPricesClient = grpc.ServerStreamingClient[WatchPricesResponse]
+
+// CoinServiceServer is the server API for CoinService service.
+// All implementations must embed UnimplementedCoinServiceServer
+// for forward compatibility.
+//
+// CoinService exposes the same operations as the HTTP API.
+type CoinServiceServer interface {
+	// GetLastRates returns the latest prices of the requested coins.
+	GetLastRates(context.Context, *GetLastRatesRequest) (*GetLastRatesResponse, error)
+	// GetAggregate returns the average, maximum or minimum stored price of the requested coins.
+	GetAggregate(context.Context, *GetAggregateRequest) (*GetAggregateResponse, error)
+	// GetHistory returns the price history of a coin as candles.
+	GetHistory(context.Context, *GetHistoryRequest) (*GetHistoryResponse, error)
+	// WatchPrices streams the prices of the requested coins: the current ones
+	// first, then every new observation.
+	WatchPrices(*WatchPricesRequest, grpc.ServerStreamingServer[WatchPricesResponse]) error
+	mustEmbedUnimplementedCoinServiceServer()
+}
+
+// UnimplementedCoinServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedCoinServiceServer struct{}
+
+func (UnimplementedCoinServiceServer) GetLastRates(context.Context, *GetLastRatesRequest) (*GetLastRatesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetLastRates not implemented")
+}
+func (UnimplementedCoinServiceServer) GetAggregate(context.Context, *GetAggregateRequest) (*GetAggregateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAggregate not implemented")
+}
+func (UnimplementedCoinServiceServer) GetHistory(context.Context, *GetHistoryRequest) (*GetHistoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetHistory not implemented")
+}
+func (UnimplementedCoinServiceServer) WatchPrices(*WatchPricesRequest, grpc.ServerStreamingServer[WatchPricesResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method WatchPrices not implemented")
+}
+func (UnimplementedCoinServiceServer) mustEmbedUnimplementedCoinServiceServer() {}
+func (UnimplementedCoinServiceServer) testEmbeddedByValue()                     {}
+
+// UnsafeCoinServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to CoinServiceServer will
+// result in compilation errors.
+type UnsafeCoinServiceServer interface {
+	mustEmbedUnimplementedCoinServiceServer()
+}
+
+func RegisterCoinServiceServer(s grpc.ServiceRegistrar, srv CoinServiceServer) {
+	// If the following call pancis, it indicates UnimplementedCoinServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&CoinService_ServiceDesc, srv)
+}
+
+func _CoinService_GetLastRates_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetLastRatesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CoinServiceServer).GetLastRates(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CoinService_GetLastRates_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CoinServiceServer).GetLastRates(ctx, req.(*GetLastRatesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CoinService_GetAggregate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAggregateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CoinServiceServer).GetAggregate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CoinService_GetAggregate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CoinServiceServer).GetAggregate(ctx, req.(*GetAggregateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CoinService_GetHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CoinServiceServer).GetHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CoinService_GetHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CoinServiceServer).GetHistory(ctx, req.(*GetHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CoinService_WatchPrices_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchPricesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(CoinServiceServer).WatchPrices(m, &grpc.GenericServerStream[WatchPricesRequest, WatchPricesResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type CoinService_WatchPricesServer = grpc.ServerStreamingServer[WatchPricesResponse]
+
+// CoinService_ServiceDesc is the grpc.ServiceDesc for CoinService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var CoinService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "crypto.v1.CoinService",
+	HandlerType: (*CoinServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetLastRates",
+			Handler:    _CoinService_GetLastRates_Handler,
+		},
+		{
+			MethodName: "GetAggregate",
+			Handler:    _CoinService_GetAggregate_Handler,
+		},
+		{
+			MethodName: "GetHistory",
+			Handler:    _CoinService_GetHistory_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchPrices",
+			Handler:       _CoinService_WatchPrices_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "crypto/v1/crypto.proto",
+}
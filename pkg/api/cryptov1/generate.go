@@ -0,0 +1,5 @@
+// Package cryptov1 contains the Go bindings of the gRPC API generated from
+// api/proto/crypto/v1/crypto.proto.
+package cryptov1
+
+//go:generate protoc -I ../../../api/proto --go_out=. --go_opt=module=Cryptoproject/pkg/api/cryptov1 --go-grpc_out=. --go-grpc_opt=module=Cryptoproject/pkg/api/cryptov1 crypto/v1/crypto.proto
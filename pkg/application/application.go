@@ -6,18 +6,29 @@ import (
 	"os"
 	"time"
 
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	cronJob "github.com/robfig/cron/v3"
 
+	"Cryptoproject/internal/adapters/provider/breaker"
 	"Cryptoproject/internal/adapters/provider/cryptocompare"
 	"Cryptoproject/internal/adapters/storage/postgres"
 	"Cryptoproject/internal/cases"
+	"Cryptoproject/internal/ports/graphql"
+	grpcport "Cryptoproject/internal/ports/grpc"
 	"Cryptoproject/internal/ports/http"
+	"Cryptoproject/internal/ports/ratelimit"
 )
 
 type App struct {
 	httpServer *http.Server
+	grpcServer *grpcport.Server
 	cron       *cronJob.Cron
 	service    *cases.Service
+	retention  *cases.RetentionService
+	depeg      *cases.DepegMonitor
+	cfg        Config
 	logger     *slog.Logger
 }
 
@@ -29,6 +40,8 @@ func NewApp() *App {
 
 	logger.Info("Initializing application")
 
+	cfg := LoadConfig(logger)
+
 	storage, err := postgres.NewStorage(os.Getenv("PG_URL"), logger)
 	if err != nil {
 		logger.Error("Failed to initialize storage", slog.String("error", err.Error()))
@@ -36,25 +49,124 @@ func NewApp() *App {
 	}
 
 	// Передаем логгер в NewClient
-	cryptoProvider, err := cryptocompare.NewClient("your_api_key", logger)
+	cryptoClient, err := cryptocompare.NewClient("your_api_key", logger,
+		cryptocompare.WithPriceIn(cfg.QuoteCurrency))
 	if err != nil {
 		logger.Error("Failed to initialize crypto provider", slog.String("error", err.Error()))
 		panic(err)
 	}
 
+	metrics := prometheus.NewRegistry()
+	metrics.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}))
+
+	readinessChecks := []http.ReadinessCheck{{
+		Name: "storage",
+		Check: func(ctx context.Context) (string, error) {
+			if err := storage.Ping(ctx); err != nil {
+				return "unreachable", err
+			}
+			return "ok", nil
+		},
+	}}
+
+	var cryptoProvider cases.CryptoProvider = cryptoClient
+	if cfg.ProviderBreakerEnabled {
+		providerBreaker, err := breaker.New(cryptoClient, cfg.ProviderBreaker, logger,
+			breaker.WithRegisterer(metrics))
+		if err != nil {
+			logger.Error("Failed to initialize provider circuit breaker", slog.String("error", err.Error()))
+			panic(err)
+		}
+		cryptoProvider = providerBreaker
+		// при открытой цепи сервис отдаёт сохранённые цены, поэтому проверка необязательная
+		readinessChecks = append(readinessChecks, http.ReadinessCheck{
+			Name:     "provider",
+			Check:    providerBreaker.Check,
+			Optional: true,
+		})
+	}
+
 	// Передаем логгер в NewService
-	service, err := cases.NewService(storage, cryptoProvider, logger)
+	service, err := cases.NewService(storage, cryptoProvider, logger, cfg.ServiceOptions()...)
 	if err != nil {
 		logger.Error("Failed to initialize service", slog.String("error", err.Error()))
 		panic(err)
 	}
 
+	portfolioService, err := cases.NewPortfolioService(storage, storage, logger)
+	if err != nil {
+		logger.Error("Failed to initialize portfolio service", slog.String("error", err.Error()))
+		panic(err)
+	}
+
+	graphqlHandler, err := graphql.NewHandler(service, logger)
+	if err != nil {
+		logger.Error("Failed to initialize GraphQL handler", slog.String("error", err.Error()))
+		panic(err)
+	}
+
+	serverOpts := []http.ServerOption{
+		http.WithRefreshInterval(cfg.RefreshInterval),
+		http.WithPortfolioService(portfolioService),
+		http.WithGraphQL(graphqlHandler),
+		http.WithMetrics(promhttp.HandlerFor(metrics, promhttp.HandlerOpts{})),
+	}
+	for _, check := range readinessChecks {
+		serverOpts = append(serverOpts, http.WithReadinessCheck(check))
+	}
+
+	var depeg *cases.DepegMonitor
+	if cfg.DepegEnabled && len(cfg.Depeg.Stablecoins) > 0 {
+		depeg, err = cases.NewDepegMonitor(storage, cfg.Depeg, logger)
+		if err != nil {
+			logger.Error("Failed to initialize depeg monitor", slog.String("error", err.Error()))
+			panic(err)
+		}
+		serverOpts = append(serverOpts, http.WithStablecoinMonitor(depeg))
+	}
+	// один limiter на оба порта: переход с HTTP на gRPC не удваивает лимит клиента
+	var limiter *ratelimit.Limiter
+	if cfg.RateLimitEnabled {
+		limiter = ratelimit.New(cfg.RateLimit)
+		serverOpts = append(serverOpts, http.WithRateLimit(limiter))
+	}
+	if cfg.AdminToken != "" {
+		serverOpts = append(serverOpts, http.WithAdminToken(cfg.AdminToken))
+	}
+
 	// Передаем логгер в NewServer
-	httpServer := http.NewServer(service, "8080", logger)
+	httpServer := http.NewServer(service, "8080", logger, serverOpts...)
+
+	var grpcServer *grpcport.Server
+	if cfg.GRPCEnabled {
+		grpcOpts := []grpcport.ServerOption{
+			grpcport.WithWatchInterval(cfg.RefreshInterval),
+			grpcport.WithWatchStreamLimit(cfg.GRPCMaxWatchStreams, cfg.GRPCMaxWatchStreamsPerClient),
+		}
+		if limiter != nil {
+			grpcOpts = append(grpcOpts, grpcport.WithRateLimit(limiter))
+		}
+		grpcServer = grpcport.NewServer(service, cfg.GRPCPort, logger, grpcOpts...)
+	}
+
+	var retention *cases.RetentionService
+	if cfg.RetentionEnabled {
+		retention, err = cases.NewRetentionService(storage, cfg.Retention, logger)
+		if err != nil {
+			logger.Error("Failed to initialize retention service", slog.String("error", err.Error()))
+			panic(err)
+		}
+	}
 
 	app := &App{
 		httpServer: httpServer,
+		grpcServer: grpcServer,
 		service:    service,
+		retention:  retention,
+		depeg:      depeg,
+		cfg:        cfg,
 		cron: cronJob.New(cronJob.WithLogger(
 			cronJob.VerbosePrintfLogger(slog.NewLogLogger(logger.Handler(), slog.LevelDebug)),
 		)),
@@ -67,9 +179,25 @@ func NewApp() *App {
 }
 
 func (a *App) setupCron() {
-	const jobName = "coin_data_update"
+	a.addJob("coin_data_update", "@every "+a.cfg.RefreshInterval.String(), a.service.ActualizeRates)
+
+	if a.retention != nil {
+		a.addJob("coin_data_retention", a.cfg.RetentionSchedule, a.retention.ApplyRetention)
+	}
+
+	if a.depeg != nil {
+		a.addJob("stablecoin_depeg_monitor", a.cfg.DepegSchedule, a.depeg.CheckPegs)
+	}
 
-	_, err := a.cron.AddFunc("*/1 * * * *", func() {
+	go func() {
+		a.logger.Info("Starting cron scheduler")
+		a.cron.Start()
+	}()
+}
+
+func (a *App) addJob(jobName, spec string, job func(ctx context.Context) error) {
+	// SkipIfStillRunning: долгие задачи (retention) не должны запускаться параллельно сами с собой
+	wrapped := cronJob.NewChain(cronJob.SkipIfStillRunning(cronJob.DiscardLogger)).Then(cronJob.FuncJob(func() {
 		ctx := context.Background()
 		startTime := time.Now()
 		logger := a.logger.With(
@@ -79,7 +207,7 @@ func (a *App) setupCron() {
 
 		logger.Info("Starting cron job execution")
 
-		if err := a.service.ActualizeRates(ctx); err != nil {
+		if err := job(ctx); err != nil {
 			logger.Error("Cron job failed",
 				slog.String("error", err.Error()),
 				slog.Duration("duration", time.Since(startTime)))
@@ -88,19 +216,14 @@ func (a *App) setupCron() {
 
 		logger.Info("Cron job completed successfully",
 			slog.Duration("duration", time.Since(startTime)))
-	})
+	}))
 
-	if err != nil {
+	if _, err := a.cron.AddJob(spec, wrapped); err != nil {
 		a.logger.Error("Failed to schedule cron job",
 			slog.String("job", jobName),
 			slog.String("error", err.Error()))
 		panic(err)
 	}
-
-	go func() {
-		a.logger.Info("Starting cron scheduler")
-		a.cron.Start()
-	}()
 }
 
 func (a *App) updateCoinData() {
@@ -125,8 +248,24 @@ func (a *App) updateCoinData() {
 }
 
 func (a *App) Run() error {
-	a.logger.Info("Starting HTTP server", slog.String("port", "8080"))
-	return a.httpServer.Start()
+	errCh := make(chan error, 2)
+
+	if a.grpcServer != nil {
+		go func() {
+			a.logger.Info("Starting gRPC server", slog.String("port", a.cfg.GRPCPort))
+			// после GracefulStop Serve возвращает nil — это не ошибка запуска
+			if err := a.grpcServer.Start(); err != nil {
+				errCh <- err
+			}
+		}()
+	}
+
+	go func() {
+		a.logger.Info("Starting HTTP server", slog.String("port", "8080"))
+		errCh <- a.httpServer.Start()
+	}()
+
+	return <-errCh
 }
 
 func (a *App) Shutdown(ctx context.Context) error {
@@ -144,6 +283,17 @@ func (a *App) Shutdown(ctx context.Context) error {
 		}
 	}
 
+	// Остановка gRPC сервера: подписки WatchPrices закрываются, текущие вызовы дорабатывают
+	if a.grpcServer != nil {
+		a.logger.Info("Stopping gRPC server")
+		if err := a.grpcServer.Stop(ctx); err != nil {
+			a.logger.Warn("Forced gRPC server shutdown",
+				slog.String("error", err.Error()))
+		} else {
+			a.logger.Info("gRPC server stopped")
+		}
+	}
+
 	// Остановка HTTP сервера
 	if a.httpServer != nil {
 		a.logger.Info("Stopping HTTP server")
@@ -155,6 +305,14 @@ func (a *App) Shutdown(ctx context.Context) error {
 		a.logger.Info("HTTP server stopped")
 	}
 
+	// Фоновые обновления курсов дорабатывают после остановки серверов
+	if a.service != nil {
+		if err := a.service.Shutdown(ctx); err != nil {
+			a.logger.Warn("Background refreshes did not finish",
+				slog.String("error", err.Error()))
+		}
+	}
+
 	a.logger.Info("Application shutdown completed")
 	return nil
 }
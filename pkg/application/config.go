@@ -0,0 +1,272 @@
+package application
+
+import (
+	"log/slog"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"Cryptoproject/internal/adapters/provider/breaker"
+	"Cryptoproject/internal/cases"
+	"Cryptoproject/internal/entities"
+	"Cryptoproject/internal/ports/ratelimit"
+)
+
+type Config struct {
+	RefreshInterval time.Duration
+	PriceMaxAge     time.Duration
+	QuoteCurrency   string
+	MaxStaleness    time.Duration
+	// StaleFallbackMaxAge limits the age of stored prices served while the provider fails; zero disables the fallback.
+	StaleFallbackMaxAge time.Duration
+	Validation          cases.ValidationConfig
+
+	RateLimitEnabled bool
+	RateLimit        ratelimit.Config
+
+	ProviderBreakerEnabled bool
+	ProviderBreaker        breaker.Config
+
+	// AdminToken enables the admin endpoints, watchlist changes and portfolios; empty disables them.
+	AdminToken string
+
+	GRPCEnabled bool
+	GRPCPort    string
+	// GRPCMaxWatchStreams caps concurrent WatchPrices streams in total and per client.
+	GRPCMaxWatchStreams          int
+	GRPCMaxWatchStreamsPerClient int
+
+	RetentionEnabled  bool
+	RetentionSchedule string
+	Retention         cases.RetentionConfig
+
+	DepegEnabled  bool
+	DepegSchedule string
+	Depeg         cases.DepegConfig
+}
+
+// LoadConfig reads the configuration from the environment, logging and
+// replacing invalid values with defaults.
+func LoadConfig(logger *slog.Logger) Config {
+	return Config{
+		RefreshInterval:     getEnvPositiveDuration(logger, "RATES_REFRESH_INTERVAL", time.Minute),
+		PriceMaxAge:         getEnvDuration(logger, "PRICE_CACHE_MAX_AGE", time.Minute),
+		QuoteCurrency:       getEnv("QUOTE_CURRENCY", "USD"),
+		MaxStaleness:        getEnvDuration(logger, "CONVERT_MAX_STALENESS", 5*time.Minute),
+		StaleFallbackMaxAge: getEnvDuration(logger, "STALE_FALLBACK_MAX_AGE", 10*time.Minute),
+		Validation: cases.ValidationConfig{
+			MaxJumpPct:   getEnvFloat(logger, "INGEST_MAX_JUMP_PCT", 30),
+			MaxZScore:    getEnvFloat(logger, "INGEST_MAX_ZSCORE", 10),
+			ZScoreWindow: getEnvDuration(logger, "INGEST_ZSCORE_WINDOW", 6*time.Hour),
+			FrozenAfter:  getEnvDuration(logger, "INGEST_FROZEN_AFTER", 6*time.Hour),
+		},
+
+		RateLimitEnabled: getEnvBool(logger, "RATE_LIMIT_ENABLED", true),
+		RateLimit: ratelimit.Config{
+			Default: ratelimit.Limit{
+				RPS:   getEnvPositiveFloat(logger, "RATE_LIMIT_RPS", 5),
+				Burst: getEnvPositiveInt(logger, "RATE_LIMIT_BURST", 10),
+			},
+			// Формат: route=rps:burst через запятую, например "coins_actual=1:5"
+			Routes:    parseRouteLimits(logger, getEnv("RATE_LIMIT_ROUTES", "coins_actual=1:5")),
+			KeyHeader: getEnv("RATE_LIMIT_KEY_HEADER", "X-API-Key"),
+			// Ключи через запятую; клиенты с другими ключами ограничиваются по IP
+			APIKeys: parseList(getEnv("RATE_LIMIT_API_KEYS", "")),
+			IdleTTL: getEnvPositiveDuration(logger, "RATE_LIMIT_IDLE_TTL", 10*time.Minute),
+		},
+
+		ProviderBreakerEnabled: getEnvBool(logger, "PROVIDER_BREAKER_ENABLED", true),
+		ProviderBreaker: breaker.Config{
+			FailureThreshold:  getEnvInt(logger, "PROVIDER_BREAKER_FAILURES", 5),
+			Cooldown:          getEnvDuration(logger, "PROVIDER_BREAKER_COOLDOWN", 30*time.Second),
+			HalfOpenSuccesses: getEnvInt(logger, "PROVIDER_BREAKER_HALF_OPEN_SUCCESSES", 1),
+		},
+
+		AdminToken: getEnv("ADMIN_TOKEN", ""),
+
+		GRPCEnabled:                  getEnvBool(logger, "GRPC_ENABLED", true),
+		GRPCPort:                     getEnv("GRPC_PORT", "9090"),
+		GRPCMaxWatchStreams:          getEnvPositiveInt(logger, "GRPC_MAX_WATCH_STREAMS", 1000),
+		GRPCMaxWatchStreamsPerClient: getEnvPositiveInt(logger, "GRPC_MAX_WATCH_STREAMS_PER_CLIENT", 10),
+
+		RetentionEnabled:  getEnvBool(logger, "RETENTION_ENABLED", true),
+		RetentionSchedule: getEnv("RETENTION_SCHEDULE", "@hourly"),
+		Retention: cases.RetentionConfig{
+			RawRetention:    getEnvDuration(logger, "RETENTION_RAW_PERIOD", 30*24*time.Hour),
+			HourlyRetention: getEnvDuration(logger, "RETENTION_HOURLY_PERIOD", 365*24*time.Hour),
+			BatchSize:       getEnvInt(logger, "RETENTION_BATCH_SIZE", 5000),
+			BatchPause:      getEnvDuration(logger, "RETENTION_BATCH_PAUSE", 100*time.Millisecond),
+			PartitionsAhead: getEnvInt(logger, "RETENTION_PARTITIONS_AHEAD", 2),
+		},
+
+		DepegEnabled:  getEnvBool(logger, "DEPEG_ENABLED", true),
+		DepegSchedule: getEnv("DEPEG_SCHEDULE", "@every 1m"),
+		Depeg: cases.DepegConfig{
+			// Формат: COIN=peg через запятую, привязка по умолчанию 1
+			Stablecoins:  parseStablecoins(logger, getEnv("STABLECOINS", "USDT,USDC,DAI")),
+			ThresholdBps: getEnvFloat(logger, "DEPEG_THRESHOLD_BPS", 50),
+			SustainFor:   getEnvDuration(logger, "DEPEG_SUSTAIN", 15*time.Minute),
+		},
+	}
+}
+
+// ServiceOptions returns the options the coin service is built with, so
+// every entry point validates and serves prices the same way.
+func (cfg Config) ServiceOptions() []cases.ServiceOption {
+	return []cases.ServiceOption{
+		cases.WithMaxAge(cfg.PriceMaxAge),
+		cases.WithQuoteCurrency(cfg.QuoteCurrency),
+		cases.WithMaxStaleness(cfg.MaxStaleness),
+		cases.WithStaleFallback(cfg.StaleFallbackMaxAge),
+		cases.WithValidation(cfg.Validation),
+	}
+}
+
+func getEnv(key, fallback string) string {
+	if value, ok := os.LookupEnv(key); ok && value != "" {
+		return value
+	}
+	return fallback
+}
+
+func getEnvInt(logger *slog.Logger, key string, fallback int) int {
+	value := getEnv(key, "")
+	if value == "" {
+		return fallback
+	}
+	parsed, err := strconv.Atoi(value)
+	if err != nil {
+		logger.Warn("Invalid int env, using default",
+			slog.String("key", key),
+			slog.String("error", err.Error()))
+		return fallback
+	}
+	return parsed
+}
+
+func getEnvFloat(logger *slog.Logger, key string, fallback float64) float64 {
+	value := getEnv(key, "")
+	if value == "" {
+		return fallback
+	}
+	parsed, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		logger.Warn("Invalid float env, using default",
+			slog.String("key", key),
+			slog.String("error", err.Error()))
+		return fallback
+	}
+	return parsed
+}
+
+func getEnvBool(logger *slog.Logger, key string, fallback bool) bool {
+	value := getEnv(key, "")
+	if value == "" {
+		return fallback
+	}
+	parsed, err := strconv.ParseBool(value)
+	if err != nil {
+		logger.Warn("Invalid bool env, using default",
+			slog.String("key", key),
+			slog.String("error", err.Error()))
+		return fallback
+	}
+	return parsed
+}
+
+func getEnvDuration(logger *slog.Logger, key string, fallback time.Duration) time.Duration {
+	value := getEnv(key, "")
+	if value == "" {
+		return fallback
+	}
+	parsed, err := time.ParseDuration(value)
+	if err != nil {
+		logger.Warn("Invalid duration env, using default",
+			slog.String("key", key),
+			slog.String("error", err.Error()))
+		return fallback
+	}
+	return parsed
+}
+
+// getEnvPositiveDuration is getEnvDuration for intervals that must be greater than zero.
+func getEnvPositiveDuration(logger *slog.Logger, key string, fallback time.Duration) time.Duration {
+	parsed := getEnvDuration(logger, key, fallback)
+	if parsed <= 0 {
+		// cron.Every округляет такие значения до секунды и дёргал бы провайдера каждую секунду
+		logger.Warn("Non-positive duration env, using default",
+			slog.String("key", key),
+			slog.Duration("value", parsed))
+		return fallback
+	}
+	return parsed
+}
+
+// getEnvPositiveInt is getEnvInt for limits that must be greater than zero.
+func getEnvPositiveInt(logger *slog.Logger, key string, fallback int) int {
+	parsed := getEnvInt(logger, key, fallback)
+	if parsed <= 0 {
+		logger.Warn("Non-positive int env, using default",
+			slog.String("key", key),
+			slog.Int("value", parsed))
+		return fallback
+	}
+	return parsed
+}
+
+// getEnvPositiveFloat is getEnvFloat for rates that must be greater than zero.
+func getEnvPositiveFloat(logger *slog.Logger, key string, fallback float64) float64 {
+	parsed := getEnvFloat(logger, key, fallback)
+	if parsed <= 0 || math.IsNaN(parsed) || math.IsInf(parsed, 0) {
+		logger.Warn("Non-positive float env, using default",
+			slog.String("key", key),
+			slog.Float64("value", parsed))
+		return fallback
+	}
+	return parsed
+}
+
+// parseList splits a comma separated value and drops empty items.
+func parseList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+func parseRouteLimits(logger *slog.Logger, value string) map[string]ratelimit.Limit {
+	limits := make(map[string]ratelimit.Limit)
+	for _, item := range strings.Split(value, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		route, spec, ok := strings.Cut(item, "=")
+		rpsValue, burstValue, okSpec := strings.Cut(spec, ":")
+		rps, errRPS := strconv.ParseFloat(rpsValue, 64)
+		burst, errBurst := strconv.Atoi(burstValue)
+		// нулевой burst отклонял бы все запросы маршрута
+		if !ok || !okSpec || errRPS != nil || errBurst != nil || rps <= 0 || math.IsNaN(rps) || math.IsInf(rps, 0) || burst <= 0 {
+			logger.Warn("Invalid route rate limit, skipping", slog.String("value", item))
+			continue
+		}
+		limits[route] = ratelimit.Limit{RPS: rps, Burst: burst}
+	}
+	return limits
+}
+
+func parseStablecoins(logger *slog.Logger, value string) []entities.Stablecoin {
+	stablecoins, err := entities.ParseStablecoins(value)
+	if err != nil {
+		logger.Warn("Invalid stablecoins env, depeg monitor disabled",
+			slog.String("value", value),
+			slog.String("error", err.Error()))
+		return nil
+	}
+	return stablecoins
+}
@@ -0,0 +1,28 @@
+package dto
+
+// VolatilityResponse DTO реализованной волатильности монеты
+// swagger:model VolatilityResponse
+type VolatilityResponse struct {
+	CoinName   string  `json:"coin_name" example:"BTC"`
+	Returns    int     `json:"returns" example:"720"`
+	Volatility float64 `json:"volatility" example:"0.54"` // годовая, доля (0.54 = 54%)
+}
+
+// VolatilityListResponse DTO волатильности за окно
+// swagger:model VolatilityListResponse
+type VolatilityListResponse struct {
+	Window   string               `json:"window" example:"30d"`
+	Interval string               `json:"interval" example:"1h"`
+	Coins    []VolatilityResponse `json:"coins"`
+}
+
+// CorrelationResponse DTO матрицы корреляций доходностей;
+// null в матрице — корреляция не определена
+// swagger:model CorrelationResponse
+type CorrelationResponse struct {
+	Window       string       `json:"window" example:"30d"`
+	Interval     string       `json:"interval" example:"1h"`
+	Titles       []string     `json:"titles"`
+	Matrix       [][]*float64 `json:"matrix"`
+	Observations [][]int      `json:"observations"`
+}
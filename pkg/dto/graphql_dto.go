@@ -0,0 +1,24 @@
+package dto
+
+// GraphQLRequest DTO запроса GraphQL
+// swagger:model GraphQLRequest
+type GraphQLRequest struct {
+	Query         string         `json:"query" example:"{ coins(titles: [\"BTC\", \"ETH\"]) { title latest { price } avg: aggregate(function: AVG) } }"`
+	OperationName string         `json:"operationName,omitempty"`
+	Variables     map[string]any `json:"variables,omitempty"`
+}
+
+// GraphQLError DTO ошибки GraphQL
+// swagger:model GraphQLError
+type GraphQLError struct {
+	Message    string         `json:"message"`
+	Path       []any          `json:"path,omitempty"`
+	Extensions map[string]any `json:"extensions,omitempty"` // code: INVALID_ARGUMENT, NOT_FOUND, STALE_DATA или INTERNAL
+}
+
+// GraphQLResponse DTO ответа GraphQL
+// swagger:model GraphQLResponse
+type GraphQLResponse struct {
+	Data   map[string]any `json:"data"`
+	Errors []GraphQLError `json:"errors,omitempty"`
+}
@@ -0,0 +1,18 @@
+package dto
+
+// ImportLineErrorResponse DTO строки, не прошедшей импорт
+// swagger:model ImportLineErrorResponse
+type ImportLineErrorResponse struct {
+	Line  int    `json:"line"`
+	Error string `json:"error"`
+}
+
+// ImportResponse DTO итогов импорта
+// swagger:model ImportResponse
+type ImportResponse struct {
+	Lines    int                       `json:"lines"`
+	Imported int                       `json:"imported"`
+	Skipped  int                       `json:"skipped"` // уже были в хранилище
+	Rejected int                       `json:"rejected"`
+	Errors   []ImportLineErrorResponse `json:"errors"` // первые 100 отклонённых строк
+}
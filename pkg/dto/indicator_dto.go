@@ -0,0 +1,21 @@
+package dto
+
+import "time"
+
+// IndicatorPointResponse DTO значения индикатора в одной свече
+// swagger:model IndicatorPointResponse
+type IndicatorPointResponse struct {
+	Time   time.Time          `json:"time"`
+	Close  float64            `json:"close"`
+	Values map[string]float64 `json:"values" example:"value:42000.5"`
+}
+
+// IndicatorResponse DTO технического индикатора
+// swagger:model IndicatorResponse
+type IndicatorResponse struct {
+	CoinName string                   `json:"coin_name" example:"BTC"`
+	Type     string                   `json:"type" example:"sma"`
+	Period   int                      `json:"period" example:"20"`
+	Interval string                   `json:"interval" example:"1h"`
+	Points   []IndicatorPointResponse `json:"points"`
+}
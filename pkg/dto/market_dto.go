@@ -0,0 +1,26 @@
+package dto
+
+import "time"
+
+// MarketMoverResponse DTO движения цены монеты за окно
+// swagger:model MarketMoverResponse
+type MarketMoverResponse struct {
+	CoinName   string  `json:"coin_name"`
+	OpenPrice  float64 `json:"open_price"`
+	LastPrice  float64 `json:"last_price"`
+	ChangePct  float64 `json:"change_pct"`
+	Volatility float64 `json:"volatility"` // стандартное отклонение цены в % от средней
+}
+
+// MarketMoversResponse DTO обзора рынка
+// swagger:model MarketMoversResponse
+type MarketMoversResponse struct {
+	Window       string                `json:"window" example:"24h"`
+	Since        time.Time             `json:"since"`
+	Gainers      []MarketMoverResponse `json:"gainers"`
+	Losers       []MarketMoverResponse `json:"losers"`
+	MostVolatile []MarketMoverResponse `json:"most_volatile"`
+	Up           int                   `json:"up"`
+	Down         int                   `json:"down"`
+	Unchanged    int                   `json:"unchanged"`
+}
@@ -0,0 +1,66 @@
+package dto
+
+import "time"
+
+// CreatePortfolioRequest DTO для создания портфеля
+// swagger:model CreatePortfolioRequest
+type CreatePortfolioRequest struct {
+	Name string `json:"name" example:"main"`
+}
+
+// PortfolioResponse DTO портфеля
+// swagger:model PortfolioResponse
+type PortfolioResponse struct {
+	ID        int64     `json:"id"`
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// TransactionRequest DTO для записи сделки
+// swagger:model TransactionRequest
+type TransactionRequest struct {
+	CoinName   string    `json:"coin_name" example:"BTC"`
+	Side       string    `json:"side" example:"buy" enums:"buy,sell"`
+	Quantity   float64   `json:"quantity" example:"0.5"`
+	Price      float64   `json:"price" example:"30000"`
+	Fee        float64   `json:"fee" example:"10"`
+	ExecutedAt time.Time `json:"executed_at"` // по умолчанию текущее время
+}
+
+// TransactionResponse DTO сделки
+// swagger:model TransactionResponse
+type TransactionResponse struct {
+	ID          int64     `json:"id"`
+	PortfolioID int64     `json:"portfolio_id"`
+	CoinName    string    `json:"coin_name"`
+	Side        string    `json:"side"`
+	Quantity    float64   `json:"quantity"`
+	Price       float64   `json:"price"`
+	Fee         float64   `json:"fee"`
+	ExecutedAt  time.Time `json:"executed_at"`
+}
+
+// PositionResponse DTO позиции портфеля
+// swagger:model PositionResponse
+type PositionResponse struct {
+	CoinName      string     `json:"coin_name"`
+	Quantity      float64    `json:"quantity"`
+	CostBasis     float64    `json:"cost_basis"`
+	Price         float64    `json:"price"`
+	PriceAt       *time.Time `json:"price_at,omitempty"`
+	MarketValue   float64    `json:"market_value"`
+	RealizedPnL   float64    `json:"realized_pnl"`
+	UnrealizedPnL float64    `json:"unrealized_pnl"`
+}
+
+// ValuationResponse DTO оценки портфеля (FIFO)
+// swagger:model ValuationResponse
+type ValuationResponse struct {
+	PortfolioID   int64              `json:"portfolio_id"`
+	At            time.Time          `json:"at"`
+	Positions     []PositionResponse `json:"positions"`
+	CostBasis     float64            `json:"cost_basis"`
+	MarketValue   float64            `json:"market_value"`
+	RealizedPnL   float64            `json:"realized_pnl"`
+	UnrealizedPnL float64            `json:"unrealized_pnl"`
+}
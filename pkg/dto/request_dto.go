@@ -0,0 +1,56 @@
+package dto
+
+import "time"
+
+// CoinsRequest DTO запроса актуальных или агрегированных цен
+// swagger:model CoinsRequest
+type CoinsRequest struct {
+	Titles   []string `json:"titles" example:"BTC,ETH"`         // до 100 символов, дубликаты отбрасываются
+	Currency string   `json:"currency,omitempty" example:"USD"` // должна совпадать с валютой котировок
+	Agg      string   `json:"agg,omitempty" example:"AVG"`      // только для агрегатов: AVG, MAX или MIN
+}
+
+// BatchSubRequest DTO одного запроса внутри пакета
+// swagger:model BatchSubRequest
+type BatchSubRequest struct {
+	CoinsRequest
+	ID       string     `json:"id,omitempty" example:"btc-latest"` // по умолчанию номер запроса в пакете
+	Type     string     `json:"type" example:"latest"`             // latest, quotes, aggregate или history
+	Interval string     `json:"interval,omitempty" example:"1h"`   // только для history
+	From     *time.Time `json:"from,omitempty"`                    // только для history
+	To       *time.Time `json:"to,omitempty"`                      // только для history, по умолчанию сейчас
+}
+
+// BatchRequest DTO пакетного запроса
+// swagger:model BatchRequest
+type BatchRequest struct {
+	Requests []BatchSubRequest `json:"requests"` // не больше 20
+}
+
+// BatchResult DTO результата одного запроса пакета
+// swagger:model BatchResult
+type BatchResult struct {
+	ID     string `json:"id"`
+	Status int    `json:"status"`          // HTTP-статус, который вернул бы отдельный запрос
+	Data   any    `json:"data,omitempty"`  // []CoinResponse, QuotesResponse, []AggregateCoinResponse или []CandleResponse
+	Code   string `json:"code,omitempty"`  // стабильный код ошибки при status >= 400, как в ProblemResponse
+	Error  string `json:"error,omitempty"` // detail ошибки при status >= 400
+}
+
+// BatchResponse DTO ответа на пакетный запрос
+// swagger:model BatchResponse
+type BatchResponse struct {
+	Results []BatchResult `json:"results"` // в порядке запросов
+}
+
+// CandleResponse DTO свечи
+// swagger:model CandleResponse
+type CandleResponse struct {
+	Bucket time.Time `json:"bucket"`
+	Open   float64   `json:"open"`
+	High   float64   `json:"high"`
+	Low    float64   `json:"low"`
+	Close  float64   `json:"close"`
+	Avg    float64   `json:"avg"`
+	Count  int64     `json:"count"`
+}
@@ -5,9 +5,17 @@ import "time"
 // CoinResponse DTO для ответа API (актуальные данные)
 // swagger:model CoinResponse
 type CoinResponse struct {
-	CoinName  string    `json:"coin_name"`
-	Price     float64   `json:"price"`
+	CoinName   string    `json:"coin_name"`
+	Price      float64   `json:"price"`
+	Currency   string    `json:"currency,omitempty"`
+	Source     string    `json:"source,omitempty"`
+	ObservedAt time.Time `json:"observed_at,omitempty"` // время котировки у провайдера
+	IngestedAt time.Time `json:"ingested_at,omitempty"` // время записи в хранилище
+	// Deprecated: совпадает с ingested_at, оставлено для совместимости
 	CreatedAt time.Time `json:"created_at,omitempty"`
+	// Stale — провайдер недоступен, отдана последняя сохранённая цена возрастом AgeSeconds
+	Stale      bool    `json:"stale,omitempty"`
+	AgeSeconds float64 `json:"age_seconds,omitempty"`
 }
 
 // AggregateCoinResponse DTO для агрегированных данных
@@ -16,3 +24,39 @@ type AggregateCoinResponse struct {
 	CoinName string  `json:"coin_name"`
 	Price    float64 `json:"price"` // AVG, MAX или MIN
 }
+
+// ConversionResponse DTO для конвертации валют
+// swagger:model ConversionResponse
+type ConversionResponse struct {
+	From        string     `json:"from"`
+	To          string     `json:"to"`
+	Amount      float64    `json:"amount"`
+	Rate        float64    `json:"rate"`
+	Result      float64    `json:"result"`
+	Via         string     `json:"via,omitempty"`           // валюта триангуляции
+	FromPriceAt *time.Time `json:"from_price_at,omitempty"` // время наблюдения цены у провайдера
+	ToPriceAt   *time.Time `json:"to_price_at,omitempty"`
+}
+
+// QuoteResponse DTO результата по одной монете
+// swagger:model QuoteResponse
+type QuoteResponse struct {
+	Title  string        `json:"title" example:"BTC"`
+	Status string        `json:"status" example:"ok" enums:"ok,unknown_symbol,rejected,stale,provider_error"`
+	Coin   *CoinResponse `json:"coin,omitempty"`   // есть при статусах ok и stale
+	Detail string        `json:"detail,omitempty"` // причина, если статус не ok
+}
+
+// QuotesResponse DTO ответа с результатом по каждой запрошенной монете
+// swagger:model QuotesResponse
+type QuotesResponse struct {
+	Results  []QuoteResponse `json:"results"`  // в порядке запроса
+	Complete bool            `json:"complete"` // у всех монет статус ok
+}
+
+// ReadinessResponse DTO ответа /readyz
+// swagger:model ReadinessResponse
+type ReadinessResponse struct {
+	Status string            `json:"status" example:"ready" enums:"ready,degraded,not_ready"`
+	Checks map[string]string `json:"checks"` // состояние каждой зависимости, например "provider": "open"
+}
@@ -1,8 +1,12 @@
 package dto
 
-// ErrorResponseDto represents error response structure
-// swagger:model ErrorResponseDto
-type ErrorResponseDto struct {
-	Error string `json:"error"`
-	Code  int    `json:"code"`
+// ProblemResponse DTO ошибки в формате RFC 7807 (application/problem+json)
+// swagger:model ProblemResponse
+type ProblemResponse struct {
+	Type     string `json:"type" example:"/problems/unknown-symbol"`
+	Title    string `json:"title" example:"Unknown coin symbol"`
+	Status   int    `json:"status" example:"404"`
+	Detail   string `json:"detail,omitempty" example:"unknown titles: [XXX]"` // для ошибок сервера — без внутренних подробностей
+	Instance string `json:"instance,omitempty" example:"/api/v1/coins/actual"`
+	Code     string `json:"code" example:"UNKNOWN_SYMBOL"` // стабильный код ошибки
 }
@@ -0,0 +1,45 @@
+package dto
+
+import "time"
+
+// DepegStatusResponse DTO текущего отклонения стейблкоина от привязки
+// swagger:model DepegStatusResponse
+type DepegStatusResponse struct {
+	CoinName      string     `json:"coin_name" example:"USDT"`
+	Peg           float64    `json:"peg" example:"1"`
+	Price         float64    `json:"price" example:"0.9987"`
+	DeviationBps  float64    `json:"deviation_bps" example:"-13"`
+	ObservedAt    time.Time  `json:"observed_at"`
+	BreachedSince *time.Time `json:"breached_since,omitempty"`
+	Depegged      bool       `json:"depegged"`
+}
+
+// DeviationPointResponse DTO отклонения от привязки на закрытии интервала
+// swagger:model DeviationPointResponse
+type DeviationPointResponse struct {
+	Time         time.Time `json:"time"`
+	Price        float64   `json:"price"`
+	DeviationBps float64   `json:"deviation_bps"`
+}
+
+// DeviationHistoryResponse DTO истории отклонений стейблкоина
+// swagger:model DeviationHistoryResponse
+type DeviationHistoryResponse struct {
+	CoinName string                   `json:"coin_name" example:"USDT"`
+	Window   string                   `json:"window" example:"7d"`
+	Interval string                   `json:"interval" example:"1h"`
+	Points   []DeviationPointResponse `json:"points"`
+}
+
+// AlertEventResponse DTO события монитора
+// swagger:model AlertEventResponse
+type AlertEventResponse struct {
+	ID        int64     `json:"id"`
+	Kind      string    `json:"kind" example:"depeg_started"`
+	CoinName  string    `json:"coin_name" example:"USDC"`
+	Price     float64   `json:"price"`
+	Value     float64   `json:"value"`
+	Threshold float64   `json:"threshold"`
+	Message   string    `json:"message"`
+	CreatedAt time.Time `json:"created_at"`
+}
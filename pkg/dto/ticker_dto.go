@@ -0,0 +1,20 @@
+package dto
+
+import "time"
+
+// TickerResponse DTO рыночного снимка монеты
+// swagger:model TickerResponse
+type TickerResponse struct {
+	CoinName     string    `json:"coin_name"`
+	Currency     string    `json:"currency"`
+	Source       string    `json:"source"`
+	Price        float64   `json:"price"`
+	Volume24h    float64   `json:"volume_24h"` // объём торгов за 24 часа в currency
+	High24h      float64   `json:"high_24h"`
+	Low24h       float64   `json:"low_24h"`
+	Change24hPct float64   `json:"change_24h_pct"`
+	MarketCap    float64   `json:"market_cap"`
+	Supply       float64   `json:"supply"`
+	ObservedAt   time.Time `json:"observed_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
@@ -0,0 +1,20 @@
+package dto
+
+// WatchlistRequest DTO для добавления монет в список отслеживания
+// swagger:model WatchlistRequest
+type WatchlistRequest struct {
+	Titles []string `json:"titles" example:"BTC,ETH"`
+}
+
+// WatchlistResponse DTO списка отслеживаемых монет
+// swagger:model WatchlistResponse
+type WatchlistResponse struct {
+	Titles []string `json:"titles"`
+}
+
+// WatchlistUpdateResponse DTO результата изменения списка отслеживания
+// swagger:model WatchlistUpdateResponse
+type WatchlistUpdateResponse struct {
+	Added   int `json:"added,omitempty"`
+	Removed int `json:"removed,omitempty"`
+}
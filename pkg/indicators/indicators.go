@@ -0,0 +1,197 @@
+// Package indicators implements technical indicators over a price series.
+//
+// Every function takes prices ordered from oldest to newest and returns one
+// value per input point once the indicator has warmed up: the last result
+// always matches the last price, so result i corresponds to
+// values[len(values)-len(result)+i].
+package indicators
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+var (
+	ErrInvalidPeriod = errors.New("invalid period")
+	ErrNotEnoughData = errors.New("not enough data")
+)
+
+// Band is a Bollinger band point.
+type Band struct {
+	Upper  float64
+	Middle float64
+	Lower  float64
+}
+
+// MACDPoint is a point of the MACD line, its signal line and their difference.
+type MACDPoint struct {
+	MACD      float64
+	Signal    float64
+	Histogram float64
+}
+
+func checkPeriod(values []float64, period, required int) error {
+	if period <= 0 {
+		return fmt.Errorf("%w: %d", ErrInvalidPeriod, period)
+	}
+	if len(values) < required {
+		return fmt.Errorf("%w: need %d values, got %d", ErrNotEnoughData, required, len(values))
+	}
+	return nil
+}
+
+// SMA is the simple moving average over period values.
+func SMA(values []float64, period int) ([]float64, error) {
+	if err := checkPeriod(values, period, period); err != nil {
+		return nil, err
+	}
+
+	result := make([]float64, 0, len(values)-period+1)
+	var sum float64
+	for i, value := range values {
+		sum += value
+		if i >= period {
+			sum -= values[i-period]
+		}
+		if i >= period-1 {
+			result = append(result, sum/float64(period))
+		}
+	}
+	return result, nil
+}
+
+// EMA is the exponential moving average with smoothing 2/(period+1),
+// seeded with the SMA of the first period values.
+func EMA(values []float64, period int) ([]float64, error) {
+	if err := checkPeriod(values, period, period); err != nil {
+		return nil, err
+	}
+
+	alpha := 2 / float64(period+1)
+	var seed float64
+	for _, value := range values[:period] {
+		seed += value
+	}
+
+	result := make([]float64, 0, len(values)-period+1)
+	result = append(result, seed/float64(period))
+	for _, value := range values[period:] {
+		prev := result[len(result)-1]
+		result = append(result, alpha*value+(1-alpha)*prev)
+	}
+	return result, nil
+}
+
+// RSI is the relative strength index with Wilder's smoothing. It needs
+// period+1 values for the first point.
+func RSI(values []float64, period int) ([]float64, error) {
+	if err := checkPeriod(values, period, period+1); err != nil {
+		return nil, err
+	}
+
+	var avgGain, avgLoss float64
+	for i := 1; i <= period; i++ {
+		gain, loss := change(values[i-1], values[i])
+		avgGain += gain
+		avgLoss += loss
+	}
+	avgGain /= float64(period)
+	avgLoss /= float64(period)
+
+	result := make([]float64, 0, len(values)-period)
+	result = append(result, rsi(avgGain, avgLoss))
+	for i := period + 1; i < len(values); i++ {
+		gain, loss := change(values[i-1], values[i])
+		avgGain = (avgGain*float64(period-1) + gain) / float64(period)
+		avgLoss = (avgLoss*float64(period-1) + loss) / float64(period)
+		result = append(result, rsi(avgGain, avgLoss))
+	}
+	return result, nil
+}
+
+func change(prev, next float64) (gain, loss float64) {
+	if next > prev {
+		return next - prev, 0
+	}
+	return 0, prev - next
+}
+
+func rsi(avgGain, avgLoss float64) float64 {
+	if avgLoss == 0 {
+		if avgGain == 0 {
+			// цена не менялась — ни перекупленности, ни перепроданности
+			return 50
+		}
+		return 100
+	}
+	return 100 - 100/(1+avgGain/avgLoss)
+}
+
+// Bollinger returns the SMA over period values with bands k population
+// standard deviations above and below it.
+func Bollinger(values []float64, period int, k float64) ([]Band, error) {
+	middle, err := SMA(values, period)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]Band, 0, len(middle))
+	for i, mean := range middle {
+		var variance float64
+		for _, value := range values[i : i+period] {
+			variance += (value - mean) * (value - mean)
+		}
+		deviation := math.Sqrt(variance / float64(period))
+		result = append(result, Band{
+			Upper:  mean + k*deviation,
+			Middle: mean,
+			Lower:  mean - k*deviation,
+		})
+	}
+	return result, nil
+}
+
+// MACD returns EMA(fast) - EMA(slow) with its EMA(signal) signal line.
+// The first point needs slow+signal-1 values.
+func MACD(values []float64, fast, slow, signal int) ([]MACDPoint, error) {
+	if fast <= 0 || fast >= slow {
+		return nil, fmt.Errorf("%w: fast period %d must be positive and less than slow period %d",
+			ErrInvalidPeriod, fast, slow)
+	}
+	if err := checkPeriod(values, signal, slow+signal-1); err != nil {
+		return nil, err
+	}
+
+	fastEMA, err := EMA(values, fast)
+	if err != nil {
+		return nil, err
+	}
+	slowEMA, err := EMA(values, slow)
+	if err != nil {
+		return nil, err
+	}
+
+	// выравниваем быструю EMA по последним точкам медленной
+	fastEMA = fastEMA[len(fastEMA)-len(slowEMA):]
+	line := make([]float64, len(slowEMA))
+	for i := range slowEMA {
+		line[i] = fastEMA[i] - slowEMA[i]
+	}
+
+	signalLine, err := EMA(line, signal)
+	if err != nil {
+		return nil, err
+	}
+
+	line = line[len(line)-len(signalLine):]
+	result := make([]MACDPoint, len(signalLine))
+	for i := range signalLine {
+		result[i] = MACDPoint{
+			MACD:      line[i],
+			Signal:    signalLine[i],
+			Histogram: line[i] - signalLine[i],
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,92 @@
+package indicators_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"Cryptoproject/pkg/indicators"
+)
+
+// closes — эталонный ряд из примера расчёта RSI Уайлдера (StockCharts)
+var closes = []float64{
+	44.34, 44.09, 44.15, 43.61, 44.33, 44.83, 45.10, 45.42, 45.84, 46.08,
+	45.89, 46.03, 45.61, 46.28, 46.28, 46.00, 46.03, 46.41, 46.22, 45.64,
+}
+
+const delta = 1e-3
+
+func Test_SMA(t *testing.T) {
+	t.Parallel()
+
+	result, err := indicators.SMA(closes, 10)
+	require.NoError(t, err)
+	require.Len(t, result, 11)
+	assert.InDelta(t, 44.779, result[0], delta)
+	assert.InDelta(t, 45.541, result[4], delta)
+	assert.InDelta(t, 46.039, result[10], delta)
+}
+
+func Test_EMA(t *testing.T) {
+	t.Parallel()
+
+	result, err := indicators.EMA(closes, 10)
+	require.NoError(t, err)
+	require.Len(t, result, 11)
+	assert.InDelta(t, 44.779, result[0], delta)
+	assert.InDelta(t, 45.4384, result[4], delta)
+	assert.InDelta(t, 45.8704, result[10], delta)
+}
+
+func Test_RSI(t *testing.T) {
+	t.Parallel()
+
+	result, err := indicators.RSI(closes, 14)
+	require.NoError(t, err)
+	require.Len(t, result, 6)
+	assert.InDelta(t, 70.4641, result[0], delta)
+	assert.InDelta(t, 66.2496, result[1], delta)
+	assert.InDelta(t, 57.915, result[5], delta)
+
+	flat, err := indicators.RSI([]float64{1, 1, 1}, 2)
+	require.NoError(t, err)
+	assert.Equal(t, []float64{50}, flat)
+}
+
+func Test_Bollinger(t *testing.T) {
+	t.Parallel()
+
+	result, err := indicators.Bollinger(closes, 20, 2)
+	require.NoError(t, err)
+	require.Len(t, result, 1)
+	assert.InDelta(t, 47.1153, result[0].Upper, delta)
+	assert.InDelta(t, 45.409, result[0].Middle, delta)
+	assert.InDelta(t, 43.7027, result[0].Lower, delta)
+}
+
+func Test_MACD(t *testing.T) {
+	t.Parallel()
+
+	result, err := indicators.MACD(closes, 3, 6, 4)
+	require.NoError(t, err)
+	require.Len(t, result, 12)
+	assert.InDelta(t, 0.4138, result[0].MACD, delta)
+	assert.InDelta(t, 0.3328, result[0].Signal, delta)
+	assert.InDelta(t, 0.4138-0.3328, result[0].Histogram, delta)
+	assert.InDelta(t, 0.3287, result[2].MACD, delta)
+	assert.InDelta(t, 0.3535, result[2].Signal, delta)
+}
+
+func Test_InvalidInput(t *testing.T) {
+	t.Parallel()
+
+	_, err := indicators.SMA(closes, 0)
+	assert.ErrorIs(t, err, indicators.ErrInvalidPeriod)
+
+	_, err = indicators.RSI(closes[:14], 14)
+	assert.ErrorIs(t, err, indicators.ErrNotEnoughData)
+
+	_, err = indicators.MACD(closes, 6, 3, 4)
+	assert.ErrorIs(t, err, indicators.ErrInvalidPeriod)
+}
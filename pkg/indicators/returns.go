@@ -0,0 +1,82 @@
+package indicators
+
+import (
+	"fmt"
+	"math"
+)
+
+// LogReturns returns ln(values[i]/values[i-1]) for consecutive values.
+func LogReturns(values []float64) ([]float64, error) {
+	if len(values) < 2 {
+		return nil, fmt.Errorf("%w: need 2 values, got %d", ErrNotEnoughData, len(values))
+	}
+
+	result := make([]float64, 0, len(values)-1)
+	for i := 1; i < len(values); i++ {
+		if values[i-1] <= 0 || values[i] <= 0 {
+			return nil, fmt.Errorf("non-positive price at %d", i)
+		}
+		result = append(result, math.Log(values[i]/values[i-1]))
+	}
+	return result, nil
+}
+
+// StdDev is the sample standard deviation.
+func StdDev(values []float64) (float64, error) {
+	if len(values) < 2 {
+		return 0, fmt.Errorf("%w: need 2 values, got %d", ErrNotEnoughData, len(values))
+	}
+
+	mean := 0.0
+	for _, value := range values {
+		mean += value
+	}
+	mean /= float64(len(values))
+
+	var variance float64
+	for _, value := range values {
+		variance += (value - mean) * (value - mean)
+	}
+	return math.Sqrt(variance / float64(len(values)-1)), nil
+}
+
+// RealizedVolatility annualises the standard deviation of returns observed
+// periodsPerYear times a year.
+func RealizedVolatility(returns []float64, periodsPerYear float64) (float64, error) {
+	deviation, err := StdDev(returns)
+	if err != nil {
+		return 0, err
+	}
+	return deviation * math.Sqrt(periodsPerYear), nil
+}
+
+// Correlation is the Pearson correlation of two aligned series. It is NaN
+// when either series does not vary.
+func Correlation(a, b []float64) (float64, error) {
+	if len(a) != len(b) {
+		return 0, fmt.Errorf("series length mismatch: %d and %d", len(a), len(b))
+	}
+	if len(a) < 2 {
+		return 0, fmt.Errorf("%w: need 2 values, got %d", ErrNotEnoughData, len(a))
+	}
+
+	var meanA, meanB float64
+	for i := range a {
+		meanA += a[i]
+		meanB += b[i]
+	}
+	meanA /= float64(len(a))
+	meanB /= float64(len(b))
+
+	var cov, varA, varB float64
+	for i := range a {
+		da, db := a[i]-meanA, b[i]-meanB
+		cov += da * db
+		varA += da * da
+		varB += db * db
+	}
+	if varA == 0 || varB == 0 {
+		return math.NaN(), nil
+	}
+	return cov / math.Sqrt(varA*varB), nil
+}
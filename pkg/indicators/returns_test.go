@@ -0,0 +1,46 @@
+package indicators_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"Cryptoproject/pkg/indicators"
+)
+
+func Test_RealizedVolatility(t *testing.T) {
+	t.Parallel()
+
+	returns, err := indicators.LogReturns(closes[:10])
+	require.NoError(t, err)
+	require.Len(t, returns, 9)
+	assert.InDelta(t, -0.0056542, returns[0], 1e-6)
+
+	volatility, err := indicators.RealizedVolatility(returns, 365)
+	require.NoError(t, err)
+	assert.InDelta(t, 0.1671854, volatility, 1e-6)
+
+	_, err = indicators.LogReturns([]float64{1})
+	assert.ErrorIs(t, err, indicators.ErrNotEnoughData)
+}
+
+func Test_Correlation(t *testing.T) {
+	t.Parallel()
+
+	correlation, err := indicators.Correlation([]float64{1, 2, 3, 4, 5}, []float64{2, 4.1, 5.9, 8.2, 9.8})
+	require.NoError(t, err)
+	assert.InDelta(t, 0.9988296, correlation, 1e-6)
+
+	correlation, err = indicators.Correlation([]float64{1, 2, 3}, []float64{3, 2, 1})
+	require.NoError(t, err)
+	assert.InDelta(t, -1, correlation, 1e-9)
+
+	correlation, err = indicators.Correlation([]float64{1, 2, 3}, []float64{5, 5, 5})
+	require.NoError(t, err)
+	assert.True(t, math.IsNaN(correlation))
+
+	_, err = indicators.Correlation([]float64{1, 2}, []float64{1})
+	assert.Error(t, err)
+}
@@ -0,0 +1,119 @@
+// Package migrations embeds the SQL migrations so binaries can apply them
+// without the migration files on disk.
+package migrations
+
+import (
+	"embed"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+//go:embed postgres/up/*.sql postgres/down/*.sql
+var postgresFS embed.FS
+
+// Migration is an up script and its optional down script.
+type Migration struct {
+	// Version is the numeric prefix of the file name.
+	Version int
+	// Name is the up file name without the ".up.sql" suffix, e.g. "0001_create_coins".
+	Name string
+	Up   string
+	// Down is empty when the migration cannot be rolled back.
+	Down string
+}
+
+// Postgres returns the PostgreSQL migrations ordered by version.
+func Postgres() ([]Migration, error) {
+	ups, err := fs.Glob(postgresFS, "postgres/up/*.up.sql")
+	if err != nil {
+		return nil, fmt.Errorf("list up migrations: %w", err)
+	}
+	downs, err := fs.Glob(postgresFS, "postgres/down/*.down.sql")
+	if err != nil {
+		return nil, fmt.Errorf("list down migrations: %w", err)
+	}
+
+	// ранние down-файлы названы не так, как up, поэтому сопоставляем по номеру версии
+	downByVersion := make(map[int]string, len(downs))
+	for _, downPath := range downs {
+		version, err := parseVersion(path.Base(downPath))
+		if err != nil {
+			return nil, err
+		}
+		downByVersion[version] = downPath
+	}
+
+	migrations := make([]Migration, 0, len(ups))
+	for _, upPath := range ups {
+		version, err := parseVersion(path.Base(upPath))
+		if err != nil {
+			return nil, err
+		}
+		up, err := postgresFS.ReadFile(upPath)
+		if err != nil {
+			return nil, fmt.Errorf("read %s: %w", upPath, err)
+		}
+
+		if err = checkScript(upPath, string(up)); err != nil {
+			return nil, err
+		}
+
+		migration := Migration{
+			Version: version,
+			Name:    strings.TrimSuffix(path.Base(upPath), ".up.sql"),
+			Up:      string(up),
+		}
+		if downPath, ok := downByVersion[version]; ok {
+			down, err := postgresFS.ReadFile(downPath)
+			if err != nil {
+				return nil, fmt.Errorf("read %s: %w", downPath, err)
+			}
+			if err = checkScript(downPath, string(down)); err != nil {
+				return nil, err
+			}
+			migration.Down = string(down)
+		}
+		migrations = append(migrations, migration)
+	}
+
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+	return migrations, nil
+}
+
+// checkScript rejects top-level transaction control. Scripts are run inside
+// the transaction that records them in schema_migrations; a COMMIT in the
+// script would end that transaction early and leave a failed migration half
+// applied.
+func checkScript(fileName, script string) error {
+	// BEGIN и END внутри тел PL/pgSQL ($$ ... $$) — это блоки, а не транзакции
+	inBody := false
+	for _, line := range strings.Split(script, "\n") {
+		if strings.Count(line, "$$")%2 == 1 {
+			inBody = !inBody
+			continue
+		}
+		if inBody {
+			continue
+		}
+		switch strings.ToUpper(strings.TrimSpace(line)) {
+		case "BEGIN;", "BEGIN TRANSACTION;", "START TRANSACTION;", "COMMIT;", "END;", "ROLLBACK;":
+			return fmt.Errorf("migration %s controls the transaction itself: %s", fileName, strings.TrimSpace(line))
+		}
+	}
+	return nil
+}
+
+func parseVersion(fileName string) (int, error) {
+	prefix, _, _ := strings.Cut(fileName, "_")
+	version, err := strconv.Atoi(prefix)
+	if err != nil {
+		return 0, fmt.Errorf("migration %s has no numeric version prefix", fileName)
+	}
+	return version, nil
+}
@@ -0,0 +1,25 @@
+package migrations_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"Cryptoproject/pkg/migrations"
+)
+
+func Test_Postgres(t *testing.T) {
+	t.Parallel()
+
+	list, err := migrations.Postgres()
+	require.NoError(t, err)
+	require.NotEmpty(t, list)
+
+	assert.Equal(t, "0001_create_coins", list[0].Name)
+	assert.NotEmpty(t, list[0].Down)
+	for i := 1; i < len(list); i++ {
+		assert.Equal(t, list[i-1].Version+1, list[i].Version, "gap before %s", list[i].Name)
+		assert.NotEmpty(t, list[i].Up)
+	}
+}
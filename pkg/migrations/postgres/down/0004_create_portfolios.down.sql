@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS portfolio_transactions;
+DROP TABLE IF EXISTS portfolios;
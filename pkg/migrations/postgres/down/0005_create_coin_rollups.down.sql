@@ -0,0 +1,5 @@
+DROP VIEW IF EXISTS coins_history;
+DROP INDEX IF EXISTS idx_coins_created_at;
+DROP TABLE IF EXISTS coins_retention_state;
+DROP TABLE IF EXISTS coins_daily;
+DROP TABLE IF EXISTS coins_hourly;
@@ -0,0 +1,23 @@
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_class WHERE relname = 'coins' AND relkind = 'p') THEN
+        RETURN;
+    END IF;
+
+    DROP VIEW IF EXISTS coins_history;
+    ALTER TABLE coins RENAME TO coins_partitioned;
+
+    CREATE TABLE coins (
+        id BIGSERIAL PRIMARY KEY,
+        coin_name VARCHAR(50) NOT NULL,
+        price DECIMAL(15, 2) NOT NULL CHECK (price > 0),
+        created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+    );
+
+    INSERT INTO coins (coin_name, price, created_at)
+    SELECT coin_name, price, created_at FROM coins_partitioned;
+
+    DROP TABLE coins_partitioned;
+END $$;
+
+CREATE INDEX IF NOT EXISTS idx_coins_coin_name ON coins (coin_name);
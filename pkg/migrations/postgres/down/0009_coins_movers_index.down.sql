@@ -0,0 +1 @@
+DROP INDEX IF EXISTS idx_coins_coin_name_observed_at_price;
@@ -0,0 +1,30 @@
+DO $$
+BEGIN
+    IF (
+        SELECT numeric_scale FROM information_schema.columns
+        WHERE table_name = 'coins' AND column_name = 'price'
+    ) IS DISTINCT FROM 10 THEN
+        RETURN;
+    END IF;
+
+    DROP VIEW IF EXISTS coins_history;
+    -- цены, округлённые до нуля, нарушили бы CHECK (price > 0)
+    DELETE FROM coins WHERE price < 0.005;
+    ALTER TABLE coins ALTER COLUMN price TYPE DECIMAL(15, 2);
+END $$;
+
+CREATE OR REPLACE VIEW coins_history AS
+SELECT c.coin_name, c.observed_at AS bucket, 'raw'::text AS resolution,
+       c.price::numeric AS open, c.price::numeric AS high, c.price::numeric AS low,
+       c.price::numeric AS close, c.price::numeric AS avg, 1::bigint AS count
+FROM coins c
+WHERE c.observed_at >= (SELECT raw_watermark FROM coins_retention_state)
+UNION ALL
+SELECT h.coin_name, h.bucket, 'hour'::text, h.open, h.high, h.low, h.close, h.avg, h.count
+FROM coins_hourly h
+WHERE h.bucket >= (SELECT daily_watermark FROM coins_retention_state)
+  AND h.bucket < (SELECT raw_watermark FROM coins_retention_state)
+UNION ALL
+SELECT d.coin_name, d.bucket, 'day'::text, d.open, d.high, d.low, d.close, d.avg, d.count
+FROM coins_daily d
+WHERE d.bucket < (SELECT daily_watermark FROM coins_retention_state);
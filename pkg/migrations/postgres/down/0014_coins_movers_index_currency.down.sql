@@ -0,0 +1,3 @@
+CREATE INDEX IF NOT EXISTS idx_coins_coin_name_observed_at_price
+    ON coins (coin_name, observed_at DESC) INCLUDE (price);
+DROP INDEX IF EXISTS idx_coins_coin_name_observed_at_price_currency;
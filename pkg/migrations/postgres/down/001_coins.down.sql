@@ -1,3 +1 @@
-BEGIN;
-DROP  TABLE IF EXISTS coins;
-END;
\ No newline at end of file
+DROP  TABLE IF EXISTS coins;
\ No newline at end of file
@@ -1,5 +1,3 @@
-BEGIN;
-
 CREATE TABLE coins_new IF EXISTS(
     coin_name VARCHAR(50) PRIMARY KEY,
     price DECIMAL(15, 2) NOT NULL CHECK (price > 0),
@@ -13,6 +11,4 @@ ORDER BY coin_name, created_at DESC;
 
 DROP TABLE coins;
 
-ALTER TABLE coins_new RENAME TO coins;
-
-COMMIT;
\ No newline at end of file
+ALTER TABLE coins_new RENAME TO coins;
\ No newline at end of file
@@ -1,5 +1,3 @@
-BEGIN;
-
 -- Создаем новую таблицу с автоинкрементным ID и без unique constraint
 CREATE TABLE IF NOT EXISTS coins (
                                      id SERIAL PRIMARY KEY,
@@ -9,6 +7,4 @@ CREATE TABLE IF NOT EXISTS coins (
     );
 
 -- Создаем индекс для поиска (но не unique!)
-CREATE INDEX IF NOT EXISTS idx_coins_coin_name ON coins(coin_name);
-
-COMMIT;
\ No newline at end of file
+CREATE INDEX IF NOT EXISTS idx_coins_coin_name ON coins(coin_name);
\ No newline at end of file
@@ -0,0 +1,19 @@
+CREATE TABLE IF NOT EXISTS portfolios (
+    id BIGSERIAL PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS portfolio_transactions (
+    id BIGSERIAL PRIMARY KEY,
+    portfolio_id BIGINT NOT NULL REFERENCES portfolios (id) ON DELETE CASCADE,
+    coin_name VARCHAR(50) NOT NULL,
+    side VARCHAR(4) NOT NULL CHECK (side IN ('buy', 'sell')),
+    quantity NUMERIC(30, 10) NOT NULL CHECK (quantity > 0),
+    price NUMERIC(30, 10) NOT NULL CHECK (price > 0),
+    fee NUMERIC(30, 10) NOT NULL DEFAULT 0 CHECK (fee >= 0),
+    executed_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_portfolio_transactions_portfolio
+    ON portfolio_transactions (portfolio_id, executed_at);
@@ -0,0 +1,53 @@
+-- Почасовые и дневные свёртки сырых минутных цен
+CREATE TABLE IF NOT EXISTS coins_hourly (
+    coin_name VARCHAR(50) NOT NULL,
+    bucket TIMESTAMPTZ NOT NULL,
+    open NUMERIC NOT NULL,
+    high NUMERIC NOT NULL,
+    low NUMERIC NOT NULL,
+    close NUMERIC NOT NULL,
+    avg NUMERIC NOT NULL,
+    count BIGINT NOT NULL,
+    PRIMARY KEY (coin_name, bucket)
+);
+
+CREATE TABLE IF NOT EXISTS coins_daily (
+    coin_name VARCHAR(50) NOT NULL,
+    bucket TIMESTAMPTZ NOT NULL,
+    open NUMERIC NOT NULL,
+    high NUMERIC NOT NULL,
+    low NUMERIC NOT NULL,
+    close NUMERIC NOT NULL,
+    avg NUMERIC NOT NULL,
+    count BIGINT NOT NULL,
+    PRIMARY KEY (coin_name, bucket)
+);
+
+-- raw_watermark: сырые строки раньше этой отметки представлены в coins_hourly,
+-- daily_watermark: почасовые строки раньше этой отметки представлены в coins_daily
+CREATE TABLE IF NOT EXISTS coins_retention_state (
+    id BOOLEAN PRIMARY KEY DEFAULT TRUE CHECK (id),
+    raw_watermark TIMESTAMPTZ NOT NULL DEFAULT '-infinity',
+    daily_watermark TIMESTAMPTZ NOT NULL DEFAULT '-infinity'
+);
+
+INSERT INTO coins_retention_state (id) VALUES (TRUE) ON CONFLICT DO NOTHING;
+
+CREATE INDEX IF NOT EXISTS idx_coins_created_at ON coins (created_at);
+
+-- История цен в наилучшем доступном разрешении без пересечений между уровнями
+CREATE OR REPLACE VIEW coins_history AS
+SELECT c.coin_name, c.created_at AS bucket, 'raw'::text AS resolution,
+       c.price::numeric AS open, c.price::numeric AS high, c.price::numeric AS low,
+       c.price::numeric AS close, c.price::numeric AS avg, 1::bigint AS count
+FROM coins c
+WHERE c.created_at >= (SELECT raw_watermark FROM coins_retention_state)
+UNION ALL
+SELECT h.coin_name, h.bucket, 'hour'::text, h.open, h.high, h.low, h.close, h.avg, h.count
+FROM coins_hourly h
+WHERE h.bucket >= (SELECT daily_watermark FROM coins_retention_state)
+  AND h.bucket < (SELECT raw_watermark FROM coins_retention_state)
+UNION ALL
+SELECT d.coin_name, d.bucket, 'day'::text, d.open, d.high, d.low, d.close, d.avg, d.count
+FROM coins_daily d
+WHERE d.bucket < (SELECT daily_watermark FROM coins_retention_state);
@@ -0,0 +1,61 @@
+-- Переводим coins на помесячное декларативное партиционирование по created_at.
+-- Миграция идемпотентна: если таблица уже партиционирована, блок ничего не делает.
+DO $$
+DECLARE
+    month_start TIMESTAMP;
+BEGIN
+    IF EXISTS (SELECT 1 FROM pg_class WHERE relname = 'coins' AND relkind = 'p') THEN
+        RETURN;
+    END IF;
+
+    DROP VIEW IF EXISTS coins_history;
+    ALTER TABLE coins RENAME TO coins_unpartitioned;
+
+    CREATE TABLE coins (
+        id BIGINT GENERATED BY DEFAULT AS IDENTITY,
+        coin_name VARCHAR(50) NOT NULL,
+        price DECIMAL(15, 2) NOT NULL CHECK (price > 0),
+        created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+        PRIMARY KEY (id, created_at)
+    ) PARTITION BY RANGE (created_at);
+
+    -- страховка на случай, если задача обслуживания не успела создать партицию
+    CREATE TABLE coins_default PARTITION OF coins DEFAULT;
+
+    FOR month_start IN
+        SELECT DISTINCT date_trunc('month', created_at AT TIME ZONE 'UTC') FROM coins_unpartitioned
+        UNION
+        SELECT date_trunc('month', NOW() AT TIME ZONE 'UTC') + make_interval(months => n)
+        FROM generate_series(0, 2) AS n
+    LOOP
+        EXECUTE format(
+            'CREATE TABLE %I PARTITION OF coins FOR VALUES FROM (%L) TO (%L)',
+            'coins_p' || to_char(month_start, 'YYYY_MM'),
+            month_start AT TIME ZONE 'UTC',
+            (month_start + INTERVAL '1 month') AT TIME ZONE 'UTC');
+    END LOOP;
+
+    INSERT INTO coins (coin_name, price, created_at)
+    SELECT coin_name, price, created_at FROM coins_unpartitioned;
+
+    DROP TABLE coins_unpartitioned;
+END $$;
+
+CREATE INDEX IF NOT EXISTS idx_coins_coin_name_created_at ON coins (coin_name, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_coins_created_at ON coins (created_at);
+
+CREATE OR REPLACE VIEW coins_history AS
+SELECT c.coin_name, c.created_at AS bucket, 'raw'::text AS resolution,
+       c.price::numeric AS open, c.price::numeric AS high, c.price::numeric AS low,
+       c.price::numeric AS close, c.price::numeric AS avg, 1::bigint AS count
+FROM coins c
+WHERE c.created_at >= (SELECT raw_watermark FROM coins_retention_state)
+UNION ALL
+SELECT h.coin_name, h.bucket, 'hour'::text, h.open, h.high, h.low, h.close, h.avg, h.count
+FROM coins_hourly h
+WHERE h.bucket >= (SELECT daily_watermark FROM coins_retention_state)
+  AND h.bucket < (SELECT raw_watermark FROM coins_retention_state)
+UNION ALL
+SELECT d.coin_name, d.bucket, 'day'::text, d.open, d.high, d.low, d.close, d.avg, d.count
+FROM coins_daily d
+WHERE d.bucket < (SELECT daily_watermark FROM coins_retention_state);
@@ -0,0 +1,71 @@
+-- Уникальность наблюдения (монета, валюта, источник, время котировки).
+-- Ключ партиционирования обязан входить в уникальный ключ, поэтому таблица
+-- пересоздаётся с партиционированием по observed_at; created_at становится ingested_at.
+DO $$
+DECLARE
+    month_start TIMESTAMP;
+BEGIN
+    IF EXISTS (
+        SELECT 1 FROM information_schema.columns
+        WHERE table_name = 'coins' AND column_name = 'observed_at'
+    ) THEN
+        RETURN;
+    END IF;
+
+    CREATE TEMP TABLE coins_backup ON COMMIT DROP AS
+    SELECT coin_name, price, created_at FROM coins;
+
+    DROP VIEW IF EXISTS coins_history;
+    DROP TABLE coins;
+
+    CREATE TABLE coins (
+        id BIGINT GENERATED BY DEFAULT AS IDENTITY,
+        coin_name VARCHAR(50) NOT NULL,
+        currency VARCHAR(10) NOT NULL DEFAULT 'USD',
+        source VARCHAR(50) NOT NULL DEFAULT 'cryptocompare',
+        price DECIMAL(15, 2) NOT NULL CHECK (price > 0),
+        observed_at TIMESTAMPTZ NOT NULL,
+        ingested_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+        PRIMARY KEY (id, observed_at),
+        CONSTRAINT coins_observation_key UNIQUE (coin_name, currency, source, observed_at)
+    ) PARTITION BY RANGE (observed_at);
+
+    CREATE TABLE coins_default PARTITION OF coins DEFAULT;
+
+    FOR month_start IN
+        SELECT DISTINCT date_trunc('month', created_at AT TIME ZONE 'UTC') FROM coins_backup
+        UNION
+        SELECT date_trunc('month', NOW() AT TIME ZONE 'UTC') + make_interval(months => n)
+        FROM generate_series(0, 2) AS n
+    LOOP
+        EXECUTE format(
+            'CREATE TABLE %I PARTITION OF coins FOR VALUES FROM (%L) TO (%L)',
+            'coins_p' || to_char(month_start, 'YYYY_MM'),
+            month_start AT TIME ZONE 'UTC',
+            (month_start + INTERVAL '1 month') AT TIME ZONE 'UTC');
+    END LOOP;
+
+    -- накопленные дубли схлопываются по новому ключу
+    INSERT INTO coins (coin_name, price, observed_at, ingested_at)
+    SELECT coin_name, price, created_at, created_at FROM coins_backup
+    ON CONFLICT ON CONSTRAINT coins_observation_key DO NOTHING;
+END $$;
+
+CREATE INDEX IF NOT EXISTS idx_coins_coin_name_observed_at ON coins (coin_name, observed_at DESC);
+CREATE INDEX IF NOT EXISTS idx_coins_observed_at ON coins (observed_at);
+
+CREATE OR REPLACE VIEW coins_history AS
+SELECT c.coin_name, c.observed_at AS bucket, 'raw'::text AS resolution,
+       c.price::numeric AS open, c.price::numeric AS high, c.price::numeric AS low,
+       c.price::numeric AS close, c.price::numeric AS avg, 1::bigint AS count
+FROM coins c
+WHERE c.observed_at >= (SELECT raw_watermark FROM coins_retention_state)
+UNION ALL
+SELECT h.coin_name, h.bucket, 'hour'::text, h.open, h.high, h.low, h.close, h.avg, h.count
+FROM coins_hourly h
+WHERE h.bucket >= (SELECT daily_watermark FROM coins_retention_state)
+  AND h.bucket < (SELECT raw_watermark FROM coins_retention_state)
+UNION ALL
+SELECT d.coin_name, d.bucket, 'day'::text, d.open, d.high, d.low, d.close, d.avg, d.count
+FROM coins_daily d
+WHERE d.bucket < (SELECT daily_watermark FROM coins_retention_state);
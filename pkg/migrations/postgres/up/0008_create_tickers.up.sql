@@ -0,0 +1,16 @@
+-- Последний рыночный снимок по каждой монете, валюте и источнику
+CREATE TABLE IF NOT EXISTS tickers (
+    coin_name VARCHAR(50) NOT NULL,
+    currency VARCHAR(10) NOT NULL,
+    source VARCHAR(50) NOT NULL,
+    price NUMERIC NOT NULL,
+    volume_24h NUMERIC NOT NULL DEFAULT 0,
+    high_24h NUMERIC NOT NULL DEFAULT 0,
+    low_24h NUMERIC NOT NULL DEFAULT 0,
+    change_pct_24h NUMERIC NOT NULL DEFAULT 0,
+    market_cap NUMERIC NOT NULL DEFAULT 0,
+    supply NUMERIC NOT NULL DEFAULT 0,
+    observed_at TIMESTAMPTZ NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (coin_name, currency, source)
+);
@@ -0,0 +1,6 @@
+-- Покрывающий индекс для расчёта движений рынка: первая и последняя цена
+-- монеты в окне и статистика по окну читаются index-only сканированием.
+-- idx_coins_coin_name_observed_at из 0007 остаётся: up-скрипты повторно
+-- применяются при старте контейнера, и 0007 пересоздавал бы удалённый индекс.
+CREATE INDEX IF NOT EXISTS idx_coins_coin_name_observed_at_price
+    ON coins (coin_name, observed_at DESC) INCLUDE (price);
@@ -0,0 +1,16 @@
+-- котировки, отклонённые проверкой при загрузке; цена без CHECK, чтобы сохранить и нули
+CREATE TABLE IF NOT EXISTS coins_quarantine (
+    id BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    coin_name VARCHAR(50) NOT NULL,
+    currency VARCHAR(10) NOT NULL,
+    source VARCHAR(50) NOT NULL,
+    price NUMERIC NOT NULL,
+    observed_at TIMESTAMPTZ NOT NULL,
+    reason VARCHAR(50) NOT NULL,
+    detail TEXT NOT NULL DEFAULT '',
+    reference_price NUMERIC,
+    quarantined_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_coins_quarantine_coin_name_quarantined_at
+    ON coins_quarantine (coin_name, quarantined_at DESC);
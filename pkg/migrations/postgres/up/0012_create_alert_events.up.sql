@@ -0,0 +1,14 @@
+-- События мониторов (отвязка стейблкоинов и т.п.)
+CREATE TABLE IF NOT EXISTS alert_events (
+    id BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    kind VARCHAR(50) NOT NULL,
+    coin_name VARCHAR(50) NOT NULL,
+    price NUMERIC NOT NULL,
+    value NUMERIC NOT NULL,
+    threshold NUMERIC NOT NULL,
+    message TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_alert_events_coin_name_created_at ON alert_events (coin_name, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_alert_events_created_at ON alert_events (created_at DESC);
@@ -0,0 +1,16 @@
+-- Отслеживаемые монеты; заполняется из истории только при создании,
+-- чтобы повторный прогон миграций не возвращал удалённые монеты
+DO $$
+BEGIN
+    IF EXISTS (SELECT 1 FROM information_schema.tables WHERE table_name = 'watchlist') THEN
+        RETURN;
+    END IF;
+
+    CREATE TABLE watchlist (
+        coin_name VARCHAR(50) PRIMARY KEY,
+        added_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+    );
+
+    INSERT INTO watchlist (coin_name)
+    SELECT DISTINCT coin_name FROM coins;
+END $$;
@@ -0,0 +1,5 @@
+-- Движения рынка считаются только по монетам из watchlist в валюте котировки:
+-- currency в INCLUDE сохраняет index-only сканирование с этим фильтром.
+CREATE INDEX IF NOT EXISTS idx_coins_coin_name_observed_at_price_currency
+    ON coins (coin_name, observed_at DESC) INCLUDE (price, currency);
+DROP INDEX IF EXISTS idx_coins_coin_name_observed_at_price;